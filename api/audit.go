@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+// Request and response bodies are cut to this length in the audit log
+const auditSummaryLength = 1024
+
+// Values of JSON fields containing any of these words are not written to the audit log
+var auditRedactedFields = []string{"password", "secret", "token", "mnemonic"}
+
+// Captures the request and the response of a state changing API call for the audit log
+type auditRecorder struct {
+	http.ResponseWriter
+	request  []byte
+	status   int
+	response bytes.Buffer
+}
+
+func newAuditRecorder(w http.ResponseWriter, r *http.Request) *auditRecorder {
+	a := &auditRecorder{ResponseWriter: w}
+	if r.Body != nil {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			log.Error(err)
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		a.request = body
+	}
+	return a
+}
+
+func (a *auditRecorder) WriteHeader(code int) {
+	if a.status == 0 {
+		a.status = code
+	}
+	a.ResponseWriter.WriteHeader(code)
+}
+
+func (a *auditRecorder) Write(b []byte) (int, error) {
+	if a.status == 0 {
+		a.status = http.StatusOK
+	}
+	if a.response.Len() < auditSummaryLength {
+		a.response.Write(b)
+	}
+	return a.ResponseWriter.Write(b)
+}
+
+func (a *auditRecorder) save(auditLog repo.AuditLog, method, path, principal string) {
+	status := a.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	entry := repo.AuditEntry{
+		Timestamp: time.Now(),
+		Method:    method,
+		Path:      path,
+		Principal: principal,
+		Request:   summarize(a.request),
+		Status:    status,
+		Result:    summarize(a.response.Bytes()),
+	}
+	if err := auditLog.Put(entry); err != nil {
+		log.Errorf("Error writing %s %s to the audit log: %s", method, path, err.Error())
+	}
+}
+
+/* Write a websocket call to the audit log. The method name is recorded as the path and JSON-RPC
+   errors are given the status the matching API error would have. */
+func auditWebsocketCall(auditLog repo.AuditLog, principal string, req wsRequest, resp wsResponse) {
+	a := &auditRecorder{request: req.Params, status: http.StatusOK}
+	if resp.Error != nil {
+		switch resp.Error.Code {
+		case wsForbidden:
+			a.status = http.StatusForbidden
+		case wsInvalidParams:
+			a.status = http.StatusBadRequest
+		default:
+			a.status = http.StatusInternalServerError
+		}
+	}
+	out, err := json.Marshal(resp)
+	if err != nil {
+		log.Error(err)
+	}
+	a.response.Write(out)
+	a.save(auditLog, "WS", req.Method, principal)
+}
+
+// Redact secrets from a JSON body and cut it to a length suitable for the audit log
+func summarize(body []byte) string {
+	var i interface{}
+	if err := json.Unmarshal(body, &i); err == nil {
+		redact(i)
+		if b, err := json.Marshal(i); err == nil {
+			body = b
+		}
+	}
+	s := string(body)
+	if len(s) > auditSummaryLength {
+		s = s[:auditSummaryLength] + "..."
+	}
+	return s
+}
+
+func redact(data interface{}) {
+	switch d := data.(type) {
+	case map[string]interface{}:
+		for k, v := range d {
+			if isRedactedField(k) {
+				d[k] = "REDACTED"
+				continue
+			}
+			redact(v)
+		}
+	case []interface{}:
+		for _, v := range d {
+			redact(v)
+		}
+	}
+}
+
+func isRedactedField(key string) bool {
+	key = strings.ToLower(key)
+	for _, f := range auditRedactedFields {
+		if strings.Contains(key, f) {
+			return true
+		}
+	}
+	return false
+}
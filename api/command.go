@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+const commandTimeout = 30 * time.Second
+
+func init() {
+	registerNotifier(notifierRegistration{
+		name: "command",
+		create: func(m *notificationManager, settings repo.SettingsData) notifier {
+			conf := settings.CommandSettings
+			if conf == nil || !conf.Notifications {
+				return nil
+			}
+			return &commandNotifier{
+				settings:  conf,
+				dir:       path.Join(m.node.RepoPath, "scripts"),
+				templates: m.templates(),
+			}
+		},
+		validate: validateCommandSettings,
+	})
+}
+
+/* Run a local command for each notification. The rendered body is written to the command's
+   stdin and the subject, type and serialized notification are passed in the environment.
+   Only commands in the scripts directory of the repo can be run so the settings API can't be
+   used to run arbitrary programs. */
+type commandNotifier struct {
+	settings  *repo.CommandSettings
+	dir       string
+	templates *notificationTemplates
+}
+
+func (notifier *commandNotifier) notify(n interface{}) error {
+	subject, body, err := notifier.templates.render(n)
+	if err != nil || subject == "" || body == "" {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path.Join(notifier.dir, notifier.settings.Command), notifier.settings.Args...)
+	cmd.Dir = notifier.dir
+	cmd.Stdin = strings.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"OB_NOTIFICATION_TYPE="+notificationType(n),
+		"OB_NOTIFICATION_SUBJECT="+subject,
+		"OB_NOTIFICATION_JSON="+string(notifications.Serialize(n)),
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.New("Notification command failed: " + err.Error() + " " + strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func validateCommandSettings(s repo.SettingsData) error {
+	if s.CommandSettings == nil || !s.CommandSettings.Notifications {
+		return nil
+	}
+	command := s.CommandSettings.Command
+	if command == "" {
+		return errors.New("Command must be set if notifications are turned on")
+	}
+	if strings.ContainsAny(command, `/\`) || command == "." || command == ".." {
+		return errors.New("Command must be the name of a file in the scripts directory")
+	}
+	return nil
+}
@@ -6,6 +6,9 @@ import (
 )
 
 func put(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, "PUT", path) {
+		return
+	}
 	switch {
 	case strings.HasPrefix(path, "/ob/profile"):
 		i.PUTProfile(w, r)
@@ -15,12 +18,17 @@ func put(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request)
 		i.PUTModerator(w, r)
 	case strings.HasPrefix(path, "/ob/listing"):
 		i.PUTListing(w, r)
+	case strings.HasPrefix(path, "/ob/post"):
+		i.PUTPost(w, r)
 	default:
 		ErrorResponse(w, http.StatusNotFound, "Not Found")
 	}
 }
 
 func post(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, "POST", path) {
+		return
+	}
 	switch {
 	case strings.HasPrefix(path, "/ob/listing"):
 		i.POSTListing(w, r)
@@ -56,12 +64,22 @@ func post(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request
 		i.POSTResyncBlockchain(w, r)
 	case strings.HasPrefix(path, "/wallet/bumpfee"):
 		i.POSTBumpFee(w, r)
+	case strings.HasPrefix(path, "/wallet/freeze"):
+		i.POSTFreezeUtxos(w, r)
+	case strings.HasPrefix(path, "/wallet/unfreeze"):
+		i.POSTUnfreezeUtxos(w, r)
+	case strings.HasPrefix(path, "/wallet/signed"):
+		i.POSTSignedTransaction(w, r)
+	case strings.HasPrefix(path, "/ob/resendmessage"):
+		i.POSTResendMessage(w, r)
 	case strings.HasPrefix(path, "/ob/opendispute"):
 		i.POSTOpenDispute(w, r)
 	case strings.HasPrefix(path, "/ob/closedispute"):
 		i.POSTCloseDispute(w, r)
 	case strings.HasPrefix(path, "/ob/releasefunds"):
 		i.POSTReleaseFunds(w, r)
+	case strings.HasPrefix(path, "/ob/releaseescrow"):
+		i.POSTReleaseEscrow(w, r)
 	case strings.HasPrefix(path, "/ob/chat"):
 		i.POSTChat(w, r)
 	case strings.HasPrefix(path, "/ob/groupchat"):
@@ -88,16 +106,25 @@ func post(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request
 		i.POSTPurchases(w, r)
 	case strings.HasPrefix(path, "/ob/purchase"):
 		i.POSTPurchase(w, r)
+	case strings.HasPrefix(path, "/ob/bid"):
+		i.POSTBid(w, r)
+	case strings.HasPrefix(path, "/ob/post"):
+		i.POSTPost(w, r)
 	case strings.HasPrefix(path, "/ob/cases"):
 		i.POSTCases(w, r)
 	case strings.HasPrefix(path, "/ob/importlistings"):
 		i.POSTImportListings(w, r)
+	case strings.HasPrefix(path, "/ob/apitokens"):
+		i.POSTAPIToken(w, r)
 	default:
 		ErrorResponse(w, http.StatusNotFound, "Not Found")
 	}
 }
 
 func get(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, "GET", path) {
+		return
+	}
 	switch {
 	case strings.HasPrefix(path, "/ob/status"):
 		i.GETStatus(w, r)
@@ -113,12 +140,22 @@ func get(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request)
 		i.GETBalance(w, r)
 	case strings.HasPrefix(path, "/wallet/transactions"):
 		i.GETTransactions(w, r)
+	case strings.HasPrefix(path, "/wallet/utxos"):
+		i.GETUtxos(w, r)
+	case strings.HasPrefix(path, "/wallet/unsigned"):
+		i.GETUnsignedTransactions(w, r)
+	case strings.HasPrefix(path, "/wallet/coldstoragesweep"):
+		i.GETColdStorageSweep(w, r)
 	case strings.HasPrefix(path, "/ob/settings"):
 		i.GETSettings(w, r)
 	case strings.HasPrefix(path, "/ob/closestpeers"):
 		i.GETClosestPeers(w, r)
 	case strings.HasPrefix(path, "/ob/exchangerate"):
 		i.GETExchangeRate(w, r)
+	case strings.HasPrefix(path, "/ob/historicalexchangerate"):
+		i.GETHistoricalExchangeRate(w, r)
+	case strings.HasPrefix(path, "/ob/outbox"):
+		i.GETOutbox(w, r)
 	case strings.HasPrefix(path, "/ob/followers"):
 		i.GETFollowers(w, r)
 	case strings.HasPrefix(path, "/ob/following"):
@@ -165,12 +202,35 @@ func get(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request)
 		i.GETRatings(w, r)
 	case strings.HasPrefix(path, "/ob/rating"):
 		i.GETRating(w, r)
+	case strings.HasPrefix(path, "/ob/bids"):
+		i.GETBids(w, r)
+	case strings.HasPrefix(path, "/ob/crowdfund"):
+		i.GETCrowdFund(w, r)
+	case strings.HasPrefix(path, "/ob/posts"):
+		i.GETPosts(w, r)
+	case strings.HasPrefix(path, "/ob/post"):
+		i.GETPost(w, r)
+	case strings.HasPrefix(path, "/ob/timeline"):
+		i.GETTimeline(w, r)
+	case strings.HasPrefix(path, "/ob/search/tag"):
+		i.GETSearchTag(w, r)
+	case strings.HasPrefix(path, "/ob/apitokens"):
+		i.GETAPITokens(w, r)
+	case strings.HasPrefix(path, "/ob/auditlog/export"):
+		i.GETAuditLogExport(w, r)
+	case strings.HasPrefix(path, "/ob/auditlog/verify"):
+		i.GETAuditLogVerify(w, r)
+	case strings.HasPrefix(path, "/ob/auditlog"):
+		i.GETAuditLog(w, r)
 	default:
 		ErrorResponse(w, http.StatusNotFound, "Not Found")
 	}
 }
 
 func patch(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, "PATCH", path) {
+		return
+	}
 	switch {
 	case strings.HasPrefix(path, "/ob/settings"):
 		i.PATCHSettings(w, r)
@@ -182,11 +242,16 @@ func patch(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Reques
 }
 
 func deleter(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, "DELETE", path) {
+		return
+	}
 	switch {
 	case strings.HasPrefix(path, "/ob/moderator"):
 		i.DELETEModerator(w, r)
 	case strings.HasPrefix(path, "/ob/listing"):
 		i.DELETEListing(w, r)
+	case strings.HasPrefix(path, "/ob/post"):
+		i.DELETEPost(w, r)
 	case strings.HasPrefix(path, "/ob/chatmessage"):
 		i.DELETEChatMessage(w, r)
 	case strings.HasPrefix(path, "/ob/chatconversation"):
@@ -195,13 +260,17 @@ func deleter(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Requ
 		i.DELETENotification(w, r)
 	case strings.HasPrefix(path, "/ob/blocknode"):
 		i.DELETEBlockNode(w, r)
+	case strings.HasPrefix(path, "/ob/apitokens"):
+		i.DELETEAPIToken(w, r)
+	case strings.HasPrefix(path, "/wallet/unsigned"):
+		i.DELETEUnsignedTransaction(w, r)
 	default:
 		ErrorResponse(w, http.StatusNotFound, "Not Found")
 	}
 }
 
 func gatewayAllowedPath(path, method string) bool {
-	allowedGets := []string{"/ob/followers", "/ob/following", "/ob/profile", "/ob/listing", "/ob/listings", "/ob/image", "/ob/avatar", "/ob/header", "/ob/rating", "/ob/ratings"}
+	allowedGets := []string{"/ob/followers", "/ob/following", "/ob/profile", "/ob/listing", "/ob/listings", "/ob/image", "/ob/avatar", "/ob/header", "/ob/rating", "/ob/ratings", "/ob/post", "/ob/posts"}
 	allowedPosts := []string{"/ob/fetchprofiles", "/ob/fetchratings"}
 	if method == "GET" {
 		for _, p := range allowedGets {
@@ -218,3 +287,101 @@ func gatewayAllowedPath(path, method string) bool {
 	}
 	return false
 }
+
+type scopedPath struct {
+	prefix string
+	scope  string
+}
+
+// The scope an API token needs for each endpoint. Like the dispatch switches the first matching
+// prefix wins so longer prefixes must come first. Anything not listed needs the admin scope.
+var scopedPaths = map[string][]scopedPath{
+	"GET": {
+		{"/ob/status", ScopeReadOnly},
+		{"/ob/peers", ScopeReadOnly},
+		{"/ob/closestpeers", ScopeReadOnly},
+		{"/wallet/address", ScopeReadOnly},
+		{"/wallet/balance", ScopeReadOnly},
+		{"/wallet/transactions", ScopeReadOnly},
+		{"/wallet/estimatefee", ScopeReadOnly},
+		{"/ob/exchangerate", ScopeReadOnly},
+		{"/ob/historicalexchangerate", ScopeReadOnly},
+		{"/ob/followers", ScopeReadOnly},
+		{"/ob/following", ScopeReadOnly},
+		{"/ob/followsme", ScopeReadOnly},
+		{"/ob/isfollowing", ScopeReadOnly},
+		{"/ob/inventory", ScopeReadOnly},
+		{"/ob/profile", ScopeReadOnly},
+		{"/ob/listing", ScopeReadOnly},
+		{"/ob/order", ScopeReadOnly},
+		{"/ob/moderators", ScopeReadOnly},
+		{"/ob/chatmessages", ScopeReadOnly},
+		{"/ob/chatconversations", ScopeReadOnly},
+		{"/ob/notifications", ScopeReadOnly},
+		{"/ob/image", ScopeReadOnly},
+		{"/ob/avatar", ScopeReadOnly},
+		{"/ob/header", ScopeReadOnly},
+		{"/ob/purchases", ScopeReadOnly},
+		{"/ob/sales", ScopeReadOnly},
+		{"/ob/case", ScopeReadOnly},
+		{"/ob/rating", ScopeReadOnly},
+		{"/ob/bids", ScopeReadOnly},
+		{"/ob/crowdfund", ScopeReadOnly},
+		{"/ob/post", ScopeReadOnly},
+		{"/ob/timeline", ScopeReadOnly},
+		{"/ob/search/tag", ScopeReadOnly},
+	},
+	"POST": {
+		{"/ob/listing", ScopeListingsWrite},
+		{"/ob/importlistings", ScopeListingsWrite},
+		{"/ob/inventory", ScopeListingsWrite},
+		{"/ob/images", ScopeListingsWrite},
+		{"/ob/post", ScopeListingsWrite},
+		{"/ob/orderconfirmation", ScopeOrders},
+		{"/ob/ordercancel", ScopeOrders},
+		{"/ob/orderfulfillment", ScopeOrders},
+		{"/ob/ordercompletion", ScopeOrders},
+		{"/ob/refund", ScopeOrders},
+		{"/ob/opendispute", ScopeOrders},
+		{"/ob/closedispute", ScopeOrders},
+		{"/ob/releasefunds", ScopeOrders},
+		{"/ob/releaseescrow", ScopeOrders},
+		{"/ob/chat", ScopeOrders},
+		{"/ob/groupchat", ScopeOrders},
+		{"/ob/markchatasread", ScopeOrders},
+		{"/ob/resendmessage", ScopeOrders},
+		{"/wallet/spend", ScopeWalletSpend},
+		{"/wallet/bumpfee", ScopeWalletSpend},
+		{"/wallet/freeze", ScopeWalletSpend},
+		{"/wallet/unfreeze", ScopeWalletSpend},
+		{"/wallet/signed", ScopeWalletSpend},
+		{"/ob/purchases", ScopeReadOnly},
+		{"/ob/purchase", ScopeWalletSpend},
+		{"/ob/bid", ScopeWalletSpend},
+		{"/ob/sales", ScopeReadOnly},
+		{"/ob/cases", ScopeReadOnly},
+		{"/ob/estimatetotal", ScopeReadOnly},
+		{"/ob/fetchprofiles", ScopeReadOnly},
+		{"/ob/fetchratings", ScopeReadOnly},
+	},
+	"PUT": {
+		{"/ob/listing", ScopeListingsWrite},
+		{"/ob/post", ScopeListingsWrite},
+	},
+	"DELETE": {
+		{"/ob/listing", ScopeListingsWrite},
+		{"/ob/post", ScopeListingsWrite},
+		{"/ob/chatmessage", ScopeOrders},
+		{"/ob/chatconversation", ScopeOrders},
+		{"/wallet/unsigned", ScopeWalletSpend},
+	},
+}
+
+func requiredScope(method, path string) string {
+	for _, p := range scopedPaths[method] {
+		if strings.HasPrefix(path, p.prefix) {
+			return p.scope
+		}
+	}
+	return ScopeAdmin
+}
@@ -4,21 +4,35 @@ type hub struct {
 	// Registered connections
 	connections map[*connection]bool
 
-	// Inbound messages from the connections
-	Broadcast chan []byte
+	// Messages to send to every connection subscribed to the topic
+	Broadcast chan wsMessage
 
 	// Register requests from the connections
 	register chan *connection
 
 	// Unregister requests from connections
 	unregister chan *connection
+
+	// Responses to requests made by a single connection
+	reply chan wsReply
+}
+
+type wsMessage struct {
+	topic   string
+	message []byte
+}
+
+type wsReply struct {
+	c       *connection
+	message []byte
 }
 
 func newHub() *hub {
 	return &hub{
-		Broadcast:   make(chan []byte),
+		Broadcast:   make(chan wsMessage),
 		register:    make(chan *connection),
 		unregister:  make(chan *connection),
+		reply:       make(chan wsReply),
 		connections: make(map[*connection]bool),
 	}
 }
@@ -35,10 +49,22 @@ func (h *hub) run() {
 				close(c.send)
 			}
 			log.Debug("Unregistered websocket connection")
+		case r := <-h.reply:
+			if _, ok := h.connections[r.c]; ok {
+				select {
+				case r.c.send <- r.message:
+				default:
+					delete(h.connections, r.c)
+					close(r.c.send)
+				}
+			}
 		case m := <-h.Broadcast:
 			for c := range h.connections {
+				if !c.subscribed(m.topic) {
+					continue
+				}
 				select {
-				case c.send <- m:
+				case c.send <- m.message:
 				default:
 					delete(h.connections, c)
 					close(c.send)
@@ -2,7 +2,9 @@ package api
 
 import (
 	"crypto/rand"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	mh "gx/ipfs/QmVGtdTZdTFaLsaj2RwdVG8jcjNNcp1DE914DKZ2kHmXHw/go-multihash"
 	"net/http"
@@ -26,6 +28,7 @@ import (
 	"bytes"
 	"github.com/OpenBazaar/jsonpb"
 	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
 	"github.com/OpenBazaar/openbazaar-go/core"
 	"github.com/OpenBazaar/openbazaar-go/ipfs"
 	"github.com/OpenBazaar/openbazaar-go/pb"
@@ -33,6 +36,7 @@ import (
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -111,8 +115,17 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Header()[k] = v.([]string)
 	}
 
+	caller := apiCaller{"unauthenticated", []string{ScopeAdmin}}
 	if i.config.Authenticated {
-		if i.config.Username == "" || i.config.Password == "" {
+		if token := bearerToken(r); token != "" {
+			apiToken, err := i.node.Datastore.APITokens().GetByHash(hashAPIToken(token))
+			if err != nil {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, "403 - Forbidden")
+				return
+			}
+			caller = apiCaller{"token:" + apiToken.Name, apiToken.Scopes}
+		} else if i.config.Username == "" || i.config.Password == "" {
 			cookie, err := r.Cookie("OpenBazaar_Auth_Cookie")
 			if err != nil {
 				w.WriteHeader(http.StatusForbidden)
@@ -124,6 +137,7 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			caller.principal = "cookie"
 		} else {
 			username, password, ok := r.BasicAuth()
 			h := sha256.Sum256([]byte(password))
@@ -133,6 +147,7 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			caller.principal = "user:" + username
 		}
 	}
 
@@ -154,6 +169,12 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	w.Header().Add("Content-Type", "application/json")
+	r = withCaller(r, caller)
+	var audit *auditRecorder
+	if r.Method == "POST" || r.Method == "PUT" || r.Method == "DELETE" || r.Method == "PATCH" {
+		audit = newAuditRecorder(w, r)
+		w = audit
+	}
 	switch r.Method {
 	case "GET":
 		get(i, u.String(), w, r)
@@ -166,6 +187,9 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case "PATCH":
 		patch(i, u.String(), w, r)
 	}
+	if audit != nil {
+		audit.save(i.node.Datastore.AuditLog(), r.Method, u.String(), caller.principal)
+	}
 }
 
 func ErrorResponse(w http.ResponseWriter, errorCode int, reason string) {
@@ -180,6 +204,24 @@ func ErrorResponse(w http.ResponseWriter, errorCode int, reason string) {
 	fmt.Fprint(w, string(resp))
 }
 
+/* Respond to an error from an action which spends coins. A watch-only node exports the transaction
+   instead of sending it, in which case the client gets its ID so it can be fetched for signing. */
+func walletErrorResponse(w http.ResponseWriter, err error) {
+	e, ok := err.(*core.SigningRequiredError)
+	if !ok {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	type signingRequired struct {
+		Success             bool   `json:"success"`
+		Reason              string `json:"reason"`
+		UnsignedTransaction string `json:"unsignedTransaction"`
+	}
+	resp, _ := json.MarshalIndent(signingRequired{false, e.Error(), e.ID}, "", "    ")
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprint(w, string(resp))
+}
+
 func SanitizedResponse(w http.ResponseWriter, response string) {
 	ret, err := SanitizeJSON([]byte(response))
 	if err != nil {
@@ -687,6 +729,95 @@ func (i *jsonAPIHandler) POSTPurchase(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+func (i *jsonAPIHandler) POSTBid(w http.ResponseWriter, r *http.Request) {
+	type bidRequest struct {
+		ListingHash string `json:"listingHash"`
+		Amount      uint64 `json:"amount"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req bidRequest
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	bid, err := i.node.PlaceBid(req.ListingHash, req.Amount)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(bid)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, out)
+	return
+}
+
+func (i *jsonAPIHandler) GETBids(w http.ResponseWriter, r *http.Request) {
+	// Either /ob/bids/<slug> for our own listings or /ob/bids/<peerId>/<slug>
+	var peerId, slug string
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/ob/bids"), "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		peerId, slug = i.node.IpfsNode.Identity.Pretty(), parts[0]
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		if _, err := peer.IDB58Decode(parts[0]); err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		peerId, slug = parts[0], parts[1]
+	default:
+		ErrorResponse(w, http.StatusBadRequest, "A listing slug must be specified")
+		return
+	}
+	bids, err := i.node.Datastore.Bids().GetAll(peerId, slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	var ret []string
+	for _, bid := range bids {
+		out, err := m.MarshalToString(bid)
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		ret = append(ret, out)
+	}
+	SanitizedResponse(w, "["+strings.Join(ret, ",")+"]")
+	return
+}
+
+func (i *jsonAPIHandler) GETCrowdFund(w http.ResponseWriter, r *http.Request) {
+	_, slug := path.Split(r.URL.Path)
+	status, err := i.node.GetCrowdFundStatus(slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(status, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
 func (i *jsonAPIHandler) GETStatus(w http.ResponseWriter, r *http.Request) {
 	_, peerId := path.Split(r.URL.Path)
 	status, err := i.node.GetPeerStatus(peerId)
@@ -694,7 +825,20 @@ func (i *jsonAPIHandler) GETStatus(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	SanitizedResponse(w, fmt.Sprintf(`{"status": "%s"}`, status))
+	// Include what the peer reported the last time we spoke to it
+	ret := struct {
+		Status string `json:"status"`
+		*repo.PeerCapabilities
+	}{Status: status}
+	if c, err := i.node.Datastore.Capabilities().Get(peerId); err == nil {
+		ret.PeerCapabilities = &c
+	}
+	out, err := json.MarshalIndent(ret, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(out))
 }
 
 func (i *jsonAPIHandler) GETPeers(w http.ResponseWriter, r *http.Request) {
@@ -771,11 +915,19 @@ func (i *jsonAPIHandler) GETBalance(w http.ResponseWriter, r *http.Request) {
 }
 
 func (i *jsonAPIHandler) POSTSpendCoins(w http.ResponseWriter, r *http.Request) {
+	type Output struct {
+		Address string `json:"address"`
+		Amount  int64  `json:"amount"`
+		Label   string `json:"label"`
+	}
 	type Send struct {
-		Address  string `json:"address"`
-		Amount   int64  `json:"amount"`
-		FeeLevel string `json:"feeLevel"`
-		Memo     string `json:"memo"`
+		Address  string   `json:"address"`
+		Amount   int64    `json:"amount"`
+		Outputs  []Output `json:"outputs"`
+		FeeLevel string   `json:"feeLevel"`
+		Memo     string   `json:"memo"`
+		Include  []string `json:"include"`
+		Exclude  []string `json:"exclude"`
 	}
 	decoder := json.NewDecoder(r.Body)
 	var snd Send
@@ -795,42 +947,112 @@ func (i *jsonAPIHandler) POSTSpendCoins(w http.ResponseWriter, r *http.Request)
 	default:
 		feeLevel = spvwallet.NORMAL
 	}
-	addr, err := i.node.Wallet.DecodeAddress(snd.Address)
+	allow, err := i.coinFilter(snd.Include, snd.Exclude)
 	if err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	txid, err := i.node.Wallet.Spend(snd.Amount, addr, feeLevel)
-	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+
+	if len(snd.Outputs) > 0 && (snd.Address != "" || snd.Amount != 0) {
+		ErrorResponse(w, http.StatusBadRequest, "Specify either an address and amount or outputs, not both")
 		return
 	}
 
+	var txid *chainhash.Hash
 	var orderId string
 	var thumbnail string
 	var memo string
-	var title string
-	contract, _, _, _, err := i.node.Datastore.Purchases().GetByPaymentAddress(addr)
-	if contract != nil && err == nil {
-		orderId, _ = i.node.CalcOrderId(contract.BuyerOrder)
-		if contract.VendorListings[0].Item != nil && len(contract.VendorListings[0].Item.Images) > 0 {
-			thumbnail = contract.VendorListings[0].Item.Images[0].Tiny
-			title = contract.VendorListings[0].Item.Title
+	var address string
+	var labels map[string]string
+	if len(snd.Outputs) > 0 {
+		// Pay every output in one transaction and save the label of each output
+		var outs []spvwallet.TransactionOutput
+		var addresses []string
+		labels = make(map[string]string)
+		seen := make(map[string]bool)
+		for _, o := range snd.Outputs {
+			addr, err := i.node.Wallet.DecodeAddress(o.Address)
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if seen[addr.EncodeAddress()] {
+				ErrorResponse(w, http.StatusBadRequest, "Each output must pay a different address")
+				return
+			}
+			seen[addr.EncodeAddress()] = true
+			script, err := i.node.Wallet.AddressToScript(addr)
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if o.Amount <= 0 {
+				ErrorResponse(w, http.StatusBadRequest, "Output amounts must be positive")
+				return
+			}
+			outs = append(outs, spvwallet.TransactionOutput{ScriptPubKey: script, Value: o.Amount})
+			addresses = append(addresses, o.Address)
+			if o.Label != "" {
+				labels[addr.EncodeAddress()] = o.Label
+			}
 		}
-	}
-	if title == "" {
+		if allow != nil {
+			txid, err = i.node.WalletFor(r.Context()).SpendCoins(outs, allow, feeLevel)
+		} else {
+			txid, err = i.node.WalletFor(r.Context()).SpendMany(outs, feeLevel)
+		}
+		if err != nil {
+			walletErrorResponse(w, err)
+			return
+		}
+		address = strings.Join(addresses, ", ")
 		memo = snd.Memo
 	} else {
-		memo = title
+		addr, err := i.node.Wallet.DecodeAddress(snd.Address)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if allow != nil {
+			script, err := i.node.Wallet.AddressToScript(addr)
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			txid, err = i.node.WalletFor(r.Context()).SpendCoins([]spvwallet.TransactionOutput{{ScriptPubKey: script, Value: snd.Amount}}, allow, feeLevel)
+		} else {
+			txid, err = i.node.WalletFor(r.Context()).Spend(snd.Amount, addr, feeLevel)
+		}
+		if err != nil {
+			walletErrorResponse(w, err)
+			return
+		}
+
+		var title string
+		contract, _, _, _, err := i.node.Datastore.Purchases().GetByPaymentAddress(addr)
+		if contract != nil && err == nil {
+			orderId, _ = i.node.CalcOrderId(contract.BuyerOrder)
+			if contract.VendorListings[0].Item != nil && len(contract.VendorListings[0].Item.Images) > 0 {
+				thumbnail = contract.VendorListings[0].Item.Images[0].Tiny
+				title = contract.VendorListings[0].Item.Title
+			}
+		}
+		if title == "" {
+			memo = snd.Memo
+		} else {
+			memo = title
+		}
+		address = snd.Address
 	}
 
 	if err := i.node.Datastore.TxMetadata().Put(repo.Metadata{
 		Txid:       txid.String(),
-		Address:    snd.Address,
+		Address:    address,
 		Memo:       memo,
 		OrderId:    orderId,
 		Thumbnail:  thumbnail,
 		CanBumpFee: false,
+		Labels:     labels,
 	}); err != nil {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -866,6 +1088,190 @@ func (i *jsonAPIHandler) POSTSpendCoins(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+/* Build the coin filter for a spend. When coins are included only those are used, frozen or
+   not. Otherwise every coin which is neither excluded nor frozen may be used. */
+func (i *jsonAPIHandler) coinFilter(include, exclude []string) (func(op wire.OutPoint) bool, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+	if len(include) > 0 {
+		included := make(map[string]bool)
+		for _, o := range include {
+			op, err := core.ParseOutpoint(o)
+			if err != nil {
+				return nil, err
+			}
+			included[op.String()] = true
+		}
+		return func(op wire.OutPoint) bool {
+			return included[op.String()]
+		}, nil
+	}
+	frozen, err := i.node.Datastore.FrozenCoins().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[string]bool)
+	for _, o := range frozen {
+		excluded[o] = true
+	}
+	for _, o := range exclude {
+		op, err := core.ParseOutpoint(o)
+		if err != nil {
+			return nil, err
+		}
+		excluded[op.String()] = true
+	}
+	return func(op wire.OutPoint) bool {
+		return !excluded[op.String()]
+	}, nil
+}
+
+func (i *jsonAPIHandler) GETUtxos(w http.ResponseWriter, r *http.Request) {
+	coins, err := i.node.GetWalletCoins()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ser, err := json.MarshalIndent(coins, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ser))
+}
+
+func (i *jsonAPIHandler) GETColdStorageSweep(w http.ResponseWriter, r *http.Request) {
+	sweep, err := i.node.PlanColdStorageSweep()
+	if err == core.ErrColdStorageNotConfigured {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ser, err := json.MarshalIndent(sweep, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ser))
+}
+
+func (i *jsonAPIHandler) GETUnsignedTransactions(w http.ResponseWriter, r *http.Request) {
+	_, id := path.Split(r.URL.Path)
+	if id != "" && id != "unsigned" {
+		utx, err := i.node.Datastore.UnsignedTransactions().Get(id)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, "Transaction not found")
+			return
+		}
+		SanitizedResponse(w, string(utx.Package))
+		return
+	}
+	utxs, err := i.node.Datastore.UnsignedTransactions().GetAll()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	type unsignedTx struct {
+		ID        string    `json:"id"`
+		Type      string    `json:"type"`
+		Timestamp time.Time `json:"timestamp"`
+		Signed    bool      `json:"signed"`
+		Txid      string    `json:"txid"`
+	}
+	ret := []unsignedTx{}
+	for _, utx := range utxs {
+		var pkg bitcoin.UnsignedTransaction
+		if err := json.Unmarshal(utx.Package, &pkg); err != nil {
+			continue
+		}
+		ret = append(ret, unsignedTx{utx.ID, pkg.Type, utx.Timestamp, utx.Signed != nil, utx.Txid})
+	}
+	ser, err := json.MarshalIndent(ret, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ser))
+}
+
+func (i *jsonAPIHandler) POSTSignedTransaction(w http.ResponseWriter, r *http.Request) {
+	var signed bitcoin.SignedTransaction
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&signed)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	txid, err := i.node.ImportSignedTransaction(r.Context(), &signed)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if txid == nil {
+		SanitizedResponse(w, `{}`)
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"txid": "%s"}`, txid.String()))
+}
+
+func (i *jsonAPIHandler) DELETEUnsignedTransaction(w http.ResponseWriter, r *http.Request) {
+	_, id := path.Split(r.URL.Path)
+	if _, err := i.node.Datastore.UnsignedTransactions().Get(id); err != nil {
+		ErrorResponse(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+	if err := i.node.Datastore.UnsignedTransactions().Delete(id); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+}
+
+func (i *jsonAPIHandler) POSTFreezeUtxos(w http.ResponseWriter, r *http.Request) {
+	i.setUtxosFrozen(w, r, true)
+}
+
+func (i *jsonAPIHandler) POSTUnfreezeUtxos(w http.ResponseWriter, r *http.Request) {
+	i.setUtxosFrozen(w, r, false)
+}
+
+func (i *jsonAPIHandler) setUtxosFrozen(w http.ResponseWriter, r *http.Request, frozen bool) {
+	type coins struct {
+		Outpoints []string `json:"outpoints"`
+	}
+	var c coins
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&c)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var outpoints []string
+	for _, o := range c.Outpoints {
+		op, err := core.ParseOutpoint(o)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		outpoints = append(outpoints, op.String())
+	}
+	for _, o := range outpoints {
+		if frozen {
+			err = i.node.Datastore.FrozenCoins().Put(o)
+		} else {
+			err = i.node.Datastore.FrozenCoins().Delete(o)
+		}
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	SanitizedResponse(w, `{}`)
+}
+
 func (i *jsonAPIHandler) GETConfig(w http.ResponseWriter, r *http.Request) {
 	type cfg struct {
 		PeerId         string `json:"peerID"`
@@ -899,7 +1305,15 @@ func (i *jsonAPIHandler) POSTSettings(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err = validateSMTPSettings(settings); err != nil {
+	if err = validateNotifierSettings(settings); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = core.ValidateOrderCompletionSettings(settings.OrderCompletion); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = core.ValidateColdStorageSettings(settings.ColdStorage, i.node.Wallet.Params()); err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -950,7 +1364,15 @@ func (i *jsonAPIHandler) PUTSettings(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err = validateSMTPSettings(settings); err != nil {
+	if err = validateNotifierSettings(settings); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = core.ValidateOrderCompletionSettings(settings.OrderCompletion); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = core.ValidateColdStorageSettings(settings.ColdStorage, i.node.Wallet.Params()); err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -1017,7 +1439,15 @@ func (i *jsonAPIHandler) PATCHSettings(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	if err = validateSMTPSettings(settings); err != nil {
+	if err = validateNotifierSettings(settings); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = core.ValidateOrderCompletionSettings(settings.OrderCompletion); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = core.ValidateColdStorageSettings(settings.ColdStorage, i.node.Wallet.Params()); err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -1090,6 +1520,92 @@ func (i *jsonAPIHandler) GETExchangeRate(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (i *jsonAPIHandler) GETHistoricalExchangeRate(w http.ResponseWriter, r *http.Request) {
+	_, currencyCode := path.Split(r.URL.Path)
+	if currencyCode == "" || strings.ToLower(currencyCode) == "historicalexchangerate" {
+		currencyCode = i.node.LocalCurrency()
+	}
+	timestamp := time.Now()
+	if t := r.URL.Query().Get("timestamp"); t != "" {
+		var err error
+		timestamp, err = time.Parse(time.RFC3339, t)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	rate, rateTimestamp, err := i.node.Datastore.ExchangeRateHistory().GetAt(strings.ToUpper(currencyCode), timestamp)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	type historicalRate struct {
+		CurrencyCode string    `json:"currencyCode"`
+		Rate         float64   `json:"rate"`
+		Timestamp    time.Time `json:"timestamp"`
+	}
+	ret, err := json.MarshalIndent(historicalRate{strings.ToUpper(currencyCode), rate, rateTimestamp}, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) GETOutbox(w http.ResponseWriter, r *http.Request) {
+	var messages []repo.OutboxMessage
+	var err error
+	if orderId := r.URL.Query().Get("orderId"); orderId != "" {
+		messages, err = i.node.Datastore.Outbox().GetByOrderId(orderId)
+	} else {
+		messages, err = i.node.Datastore.Outbox().GetPending()
+	}
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if messages == nil {
+		messages = []repo.OutboxMessage{}
+	}
+	ret, err := json.MarshalIndent(messages, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) POSTResendMessage(w http.ResponseWriter, r *http.Request) {
+	type resend struct {
+		PointerID string `json:"pointerId"`
+	}
+	var msg resend
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&msg)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	m, err := i.node.Datastore.Outbox().Get(msg.PointerID)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, "Message not found")
+		return
+	}
+	if m.Status != repo.OutboxPending {
+		ErrorResponse(w, http.StatusConflict, "Message is already "+strings.ToLower(m.Status))
+		return
+	}
+	err = i.node.ResendOutboxMessage(msg.PointerID)
+	if err == core.ErrRecipientOffline {
+		ErrorResponse(w, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+}
+
 func (i *jsonAPIHandler) GETFollowers(w http.ResponseWriter, r *http.Request) {
 	_, peerId := path.Split(r.URL.Path)
 	var err error
@@ -1508,7 +2024,7 @@ func (i *jsonAPIHandler) POSTOrderConfirmation(w http.ResponseWriter, r *http.Re
 		return
 	}
 	if !conf.Reject {
-		err := i.node.ConfirmOfflineOrder(contract, records)
+		err := i.node.ConfirmOfflineOrder(r.Context(), contract, records)
 		if err != nil {
 			ErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -1545,9 +2061,9 @@ func (i *jsonAPIHandler) POSTOrderCancel(w http.ResponseWriter, r *http.Request)
 		ErrorResponse(w, http.StatusBadRequest, "order must be PENDING or partially funded and only a direct payment to cancel")
 		return
 	}
-	err = i.node.CancelOfflineOrder(contract, records)
+	err = i.node.CancelOfflineOrder(r.Context(), contract, records)
 	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		walletErrorResponse(w, err)
 		return
 	}
 	SanitizedResponse(w, `{}`)
@@ -1636,29 +2152,42 @@ func (i *jsonAPIHandler) POSTShutdown(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+/* Refund an order in full, or in part if an amount or items are given. A partial refund of a
+   moderated order returns the remainder to escrow which restarts the escrow timeout. */
 func (i *jsonAPIHandler) POSTRefund(w http.ResponseWriter, r *http.Request) {
-	type orderCancel struct {
-		OrderId string `json:"orderId"`
+	type orderRefund struct {
+		OrderId string            `json:"orderId"`
+		Amount  uint64            `json:"amount"`
+		Items   []*pb.Refund_Item `json:"items"`
 	}
 	decoder := json.NewDecoder(r.Body)
-	var can orderCancel
-	err := decoder.Decode(&can)
+	var ref orderRefund
+	err := decoder.Decode(&ref)
 	if err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	contract, state, _, records, _, err := i.node.Datastore.Sales().GetByOrderId(can.OrderId)
+	contract, state, _, records, _, err := i.node.Datastore.Sales().GetByOrderId(ref.OrderId)
 	if err != nil {
 		ErrorResponse(w, http.StatusNotFound, "order not found")
 		return
 	}
-	if state != pb.OrderState_AWAITING_FULFILLMENT && state != pb.OrderState_PARTIALLY_FULFILLED && state != pb.OrderState_DISPUTED {
-		ErrorResponse(w, http.StatusBadRequest, "order must be AWAITING_FULFILLMENT, PARTIALLY_FULFILLED, or DISPUTED to refund")
+	partial := ref.Amount > 0 || len(ref.Items) > 0
+	if partial && state == pb.OrderState_DISPUTED {
+		ErrorResponse(w, http.StatusBadRequest, "disputed orders can only be refunded in full")
 		return
 	}
-	err = i.node.RefundOrder(contract, records)
+	if state != pb.OrderState_AWAITING_FULFILLMENT && state != pb.OrderState_PARTIALLY_FULFILLED && state != pb.OrderState_PARTIALLY_REFUNDED && state != pb.OrderState_DISPUTED {
+		ErrorResponse(w, http.StatusBadRequest, "order must be AWAITING_FULFILLMENT, PARTIALLY_FULFILLED, PARTIALLY_REFUNDED, or DISPUTED to refund")
+		return
+	}
+	if partial {
+		err = i.node.PartialRefundOrder(r.Context(), contract, records, ref.Amount, ref.Items)
+	} else {
+		err = i.node.RefundOrder(r.Context(), contract, records)
+	}
 	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		walletErrorResponse(w, err)
 		return
 	}
 	SanitizedResponse(w, `{}`)
@@ -1819,6 +2348,66 @@ func (i *jsonAPIHandler) GETModerators(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (i *jsonAPIHandler) GETSearchTag(w http.ResponseWriter, r *http.Request) {
+	_, tag := path.Split(r.URL.Path)
+	if tag == "" || tag == "tag" {
+		ErrorResponse(w, http.StatusBadRequest, "a tag must be specified")
+		return
+	}
+	pointerID, err := core.TagPointerID(tag)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	idBytes := make([]byte, 16)
+	rand.Read(idBytes)
+	id := base58.Encode(idBytes)
+
+	type resp struct {
+		Id string `json:"id"`
+	}
+	response := resp{id}
+	respJson, _ := json.MarshalIndent(response, "", "    ")
+	w.WriteHeader(http.StatusAccepted)
+	SanitizedResponse(w, string(respJson))
+	go func() {
+		type wsResp struct {
+			Id       string          `json:"id"`
+			PeerId   string          `json:"peerId"`
+			Listings json.RawMessage `json:"listings"`
+		}
+		peerChan := ipfs.FindPointersAsync(i.node.IpfsNode.Routing.(*routing.IpfsDHT), context.Background(), pointerID, core.TagPointerPrefixLength)
+
+		var lock sync.Mutex
+		found := make(map[string]bool)
+		for p := range peerChan {
+			go func(pi ps.PeerInfo) {
+				pid, err := core.ExtractIDFromPointer(pi)
+				if err != nil {
+					return
+				}
+				lock.Lock()
+				if found[pid] {
+					lock.Unlock()
+					return
+				}
+				found[pid] = true
+				lock.Unlock()
+
+				listings, err := i.node.FetchListingsWithTag(pid, tag)
+				if err != nil {
+					return
+				}
+				respJson, err := json.MarshalIndent(wsResp{id, pid, listings}, "", "    ")
+				if err != nil {
+					return
+				}
+				i.node.Broadcast <- []byte(respJson)
+			}(p)
+		}
+	}()
+}
+
 func (i *jsonAPIHandler) POSTOrderFulfill(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	var fulfill pb.OrderFulfillment
@@ -1832,13 +2421,13 @@ func (i *jsonAPIHandler) POSTOrderFulfill(w http.ResponseWriter, r *http.Request
 		ErrorResponse(w, http.StatusNotFound, "order not found")
 		return
 	}
-	if state != pb.OrderState_AWAITING_FULFILLMENT && state != pb.OrderState_PARTIALLY_FULFILLED {
-		ErrorResponse(w, http.StatusBadRequest, "order must be in state AWAITING_FULFILLMENT or PARTIALLY_FULFILLED to fulfill")
+	if state != pb.OrderState_AWAITING_FULFILLMENT && state != pb.OrderState_PARTIALLY_FULFILLED && state != pb.OrderState_PARTIALLY_REFUNDED {
+		ErrorResponse(w, http.StatusBadRequest, "order must be in state AWAITING_FULFILLMENT, PARTIALLY_FULFILLED or PARTIALLY_REFUNDED to fulfill")
 		return
 	}
 	err = i.node.FulfillOrder(&fulfill, contract, records)
 	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		walletErrorResponse(w, err)
 		return
 	}
 	SanitizedResponse(w, `{}`)
@@ -1895,9 +2484,9 @@ func (i *jsonAPIHandler) POSTOrderComplete(w http.ResponseWriter, r *http.Reques
 		ErrorResponse(w, http.StatusBadRequest, "order must be either fulfilled or in closed dispute state to leave the rating")
 		return
 	}
-	err = i.node.CompleteOrder(&or, contract, records)
+	err = i.node.CompleteOrder(r.Context(), &or, contract, records)
 	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		walletErrorResponse(w, err)
 		return
 	}
 	SanitizedResponse(w, `{}`)
@@ -2055,77 +2644,62 @@ func (i *jsonAPIHandler) POSTReleaseFunds(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = i.node.ReleaseFunds(contract, records)
+	err = i.node.ReleaseFunds(r.Context(), contract, records)
 	if err != nil {
-		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		walletErrorResponse(w, err)
 		return
 	}
 	SanitizedResponse(w, `{}`)
 	return
 }
 
-func (i *jsonAPIHandler) POSTChat(w http.ResponseWriter, r *http.Request) {
+func (i *jsonAPIHandler) POSTReleaseEscrow(w http.ResponseWriter, r *http.Request) {
+	type release struct {
+		OrderID string `json:"orderId"`
+	}
 	decoder := json.NewDecoder(r.Body)
-	var chat repo.ChatMessage
-	err := decoder.Decode(&chat)
+	var rel release
+	err := decoder.Decode(&rel)
 	if err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if len(chat.Subject) > 500 {
-		ErrorResponse(w, http.StatusBadRequest, "Subject line is too long")
+	contract, state, _, records, _, err := i.node.Datastore.Sales().GetByOrderId(rel.OrderID)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, "Order not found")
 		return
 	}
-	if len(chat.Message) > 20000 {
-		ErrorResponse(w, http.StatusBadRequest, "Message is too long")
+	if state != pb.OrderState_FULFILLED {
+		ErrorResponse(w, http.StatusBadRequest, "Order must be in FULFILLED state to release the escrow")
 		return
 	}
-
-	t := time.Now()
-	ts, err := ptypes.TimestampProto(t)
+	err = i.node.ReleaseEscrowAfterTimeout(r.Context(), contract, records)
 	if err != nil {
-		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		walletErrorResponse(w, err)
 		return
 	}
-	var flag pb.Chat_Flag
-	if chat.Message == "" {
-		flag = pb.Chat_TYPING
-	} else {
-		flag = pb.Chat_MESSAGE
-	}
-	h := sha256.Sum256([]byte(chat.Message + chat.Subject + ptypes.TimestampString(ts)))
-	encoded, err := mh.Encode(h[:], mh.SHA2_256)
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) POSTChat(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var chat repo.ChatMessage
+	err := decoder.Decode(&chat)
 	if err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	msgId, err := mh.Cast(encoded)
-	if err != nil {
+	if err := validateChatMessage(chat.Subject, chat.Message); err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
-	chatPb := &pb.Chat{
-		MessageId: msgId.B58String(),
-		Subject:   chat.Subject,
-		Message:   chat.Message,
-		Timestamp: ts,
-		Flag:      flag,
-	}
-	err = i.node.SendChat(chat.PeerId, chatPb)
+	msgId, err := sendChatMessage(i.node, chat)
 	if err != nil {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	// Put to database
-	if chatPb.Flag == pb.Chat_MESSAGE {
-		err = i.node.Datastore.Chat().Put(msgId.B58String(), chat.PeerId, chat.Subject, chat.Message, t, false, true)
-		if err != nil {
-			ErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-	}
-	SanitizedResponse(w, fmt.Sprintf(`{"messageId": "%s"}`, msgId.B58String()))
+	SanitizedResponse(w, fmt.Sprintf(`{"messageId": "%s"}`, msgId))
 	return
 }
 
@@ -2137,67 +2711,72 @@ func (i *jsonAPIHandler) POSTGroupChat(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if len(chat.Subject) > 500 {
-		ErrorResponse(w, http.StatusBadRequest, "Subject line is too long")
-		return
-	}
 	if len(chat.Subject) <= 0 {
 		ErrorResponse(w, http.StatusBadRequest, "Group chats must include a unquie subject to be used as the groupd chat ID")
 		return
 	}
-	if len(chat.Message) > 20000 {
-		ErrorResponse(w, http.StatusBadRequest, "Message is too long")
+	if err := validateChatMessage(chat.Subject, chat.Message); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
-	t := time.Now()
-	ts, err := ptypes.TimestampProto(t)
+	msgId, err := sendGroupChatMessage(i.node, chat)
 	if err != nil {
-		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	var flag pb.Chat_Flag
-	if chat.Message == "" {
-		flag = pb.Chat_TYPING
-	} else {
-		flag = pb.Chat_MESSAGE
+	SanitizedResponse(w, fmt.Sprintf(`{"messageId": "%s"}`, msgId))
+	return
+}
+
+func validateChatMessage(subject, message string) error {
+	if len(subject) > 500 {
+		return errors.New("Subject line is too long")
+	}
+	if len(message) > 20000 {
+		return errors.New("Message is too long")
 	}
-	h := sha256.Sum256([]byte(chat.Message + chat.Subject + ptypes.TimestampString(ts)))
-	encoded, err := mh.Encode(h[:], mh.SHA2_256)
+	return nil
+}
+
+// Build a chat message. An empty message is sent as a typing indicator.
+func sendChatMessage(n *core.OpenBazaarNode, chat repo.ChatMessage) (string, error) {
+	chatPb, t, err := core.NewChatMessage(chat.Subject, chat.Message)
 	if err != nil {
-		ErrorResponse(w, http.StatusBadRequest, err.Error())
-		return
+		return "", err
 	}
-	msgId, err := mh.Cast(encoded)
+	err = n.SendChat(chat.PeerId, chatPb)
 	if err != nil {
-		ErrorResponse(w, http.StatusBadRequest, err.Error())
-		return
+		return "", err
 	}
+	// Put to database
+	if chatPb.Flag == pb.Chat_MESSAGE {
+		err = n.Datastore.Chat().Put(chatPb.MessageId, chat.PeerId, chat.Subject, chat.Message, t, false, true)
+		if err != nil {
+			return "", err
+		}
+	}
+	return chatPb.MessageId, nil
+}
 
-	chatPb := &pb.Chat{
-		MessageId: msgId.B58String(),
-		Subject:   chat.Subject,
-		Message:   chat.Message,
-		Timestamp: ts,
-		Flag:      flag,
+func sendGroupChatMessage(n *core.OpenBazaarNode, chat repo.GroupChatMessage) (string, error) {
+	chatPb, t, err := core.NewChatMessage(chat.Subject, chat.Message)
+	if err != nil {
+		return "", err
 	}
 	for _, pid := range chat.PeerIds {
-		err = i.node.SendChat(pid, chatPb)
+		err = n.SendChat(pid, chatPb)
 		if err != nil {
-			ErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
+			return "", err
 		}
 	}
 	// Put to database
 	if chatPb.Flag == pb.Chat_MESSAGE {
-		err = i.node.Datastore.Chat().Put(msgId.B58String(), "", chat.Subject, chat.Message, t, false, true)
+		err = n.Datastore.Chat().Put(chatPb.MessageId, "", chat.Subject, chat.Message, t, false, true)
 		if err != nil {
-			ErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
+			return "", err
 		}
 	}
-	SanitizedResponse(w, fmt.Sprintf(`{"messageId": "%s"}`, msgId.B58String()))
-	return
+	return chatPb.MessageId, nil
 }
 
 func (i *jsonAPIHandler) GETChatMessages(w http.ResponseWriter, r *http.Request) {
@@ -2248,32 +2827,39 @@ func (i *jsonAPIHandler) POSTMarkChatAsRead(w http.ResponseWriter, r *http.Reque
 	if strings.ToLower(peerId) == "markchatasread" {
 		peerId = ""
 	}
-	subject := r.URL.Query().Get("subject")
-	lastId, updated, err := i.node.Datastore.Chat().MarkAsRead(peerId, subject, false, "")
+	err := markChatAsRead(i.node, peerId, r.URL.Query().Get("subject"))
 	if err != nil {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	SanitizedResponse(w, `{}`)
+}
+
+// Mark the conversation as read and let the other party know we've read it
+func markChatAsRead(n *core.OpenBazaarNode, peerId, subject string) error {
+	lastId, updated, err := n.Datastore.Chat().MarkAsRead(peerId, subject, false, "")
+	if err != nil {
+		return err
+	}
 	if updated && peerId != "" {
 		chatPb := &pb.Chat{
 			MessageId: lastId,
 			Subject:   subject,
 			Flag:      pb.Chat_READ,
 		}
-		err = i.node.SendChat(peerId, chatPb)
+		err = n.SendChat(peerId, chatPb)
 		if err != nil {
-			ErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
+			return err
 		}
 	}
 	if subject != "" {
 		go func() {
-			i.node.Datastore.Purchases().MarkAsRead(subject)
-			i.node.Datastore.Sales().MarkAsRead(subject)
-			i.node.Datastore.Cases().MarkAsRead(subject)
+			n.Datastore.Purchases().MarkAsRead(subject)
+			n.Datastore.Sales().MarkAsRead(subject)
+			n.Datastore.Cases().MarkAsRead(subject)
 		}()
 	}
-	SanitizedResponse(w, `{}`)
+	return nil
 }
 
 func (i *jsonAPIHandler) DELETEChatMessage(w http.ResponseWriter, r *http.Request) {
@@ -2559,17 +3145,19 @@ func (i *jsonAPIHandler) GETTransactions(w http.ResponseWriter, r *http.Request)
 	}
 	offsetID := r.URL.Query().Get("offsetId")
 	type Tx struct {
-		Txid          string    `json:"txid"`
-		Value         int64     `json:"value"`
-		Address       string    `json:"address"`
-		Status        string    `json:"status"`
-		Memo          string    `json:"memo"`
-		Timestamp     time.Time `json:"timestamp"`
-		Confirmations int32     `json:"confirmations"`
-		Height        int32     `json:"height"`
-		OrderId       string    `json:"orderId"`
-		Thumbnail     string    `json:"thumbnail"`
-		CanBumpFee    bool      `json:"canBumpFee"`
+		Txid          string            `json:"txid"`
+		Value         int64             `json:"value"`
+		Address       string            `json:"address"`
+		Status        string            `json:"status"`
+		Memo          string            `json:"memo"`
+		Timestamp     time.Time         `json:"timestamp"`
+		Confirmations int32             `json:"confirmations"`
+		Height        int32             `json:"height"`
+		OrderId       string            `json:"orderId"`
+		Thumbnail     string            `json:"thumbnail"`
+		CanBumpFee    bool              `json:"canBumpFee"`
+		Labels        map[string]string `json:"labels,omitempty"`
+		FiatValue     *repo.FiatValue   `json:"fiatValue,omitempty"`
 	}
 	transactions, err := i.node.Wallet.Transactions()
 	if err != nil {
@@ -2622,6 +3210,7 @@ func (i *jsonAPIHandler) GETTransactions(w http.ResponseWriter, r *http.Request)
 			tx.OrderId = m.OrderId
 			tx.Thumbnail = m.Thumbnail
 			tx.CanBumpFee = m.CanBumpFee
+			tx.Labels = m.Labels
 		}
 		if status == "DEAD" {
 			tx.CanBumpFee = false
@@ -2636,6 +3225,9 @@ func (i *jsonAPIHandler) GETTransactions(w http.ResponseWriter, r *http.Request)
 			break
 		}
 	}
+	for n, tx := range txs {
+		txs[n].FiatValue, _ = i.node.GetFiatValue(tx.Value, tx.Timestamp)
+	}
 	type txWithCount struct {
 		Transactions []Tx `json:"transactions"`
 		Count        int  `json:"count"`
@@ -2661,6 +3253,7 @@ func (i *jsonAPIHandler) GETPurchases(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	for n, p := range purchases {
+		purchases[n].FiatValue, _ = i.node.GetFiatValue(int64(p.Total), p.Timestamp)
 		unread, err := i.node.Datastore.Chat().GetUnreadCount(p.OrderId)
 		if err != nil {
 			continue
@@ -2696,6 +3289,7 @@ func (i *jsonAPIHandler) GETSales(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	for n, s := range sales {
+		sales[n].FiatValue, _ = i.node.GetFiatValue(int64(s.Total), s.Timestamp)
 		unread, err := i.node.Datastore.Chat().GetUnreadCount(s.OrderId)
 		if err != nil {
 			continue
@@ -2769,6 +3363,7 @@ func (i *jsonAPIHandler) POSTPurchases(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	for n, p := range purchases {
+		purchases[n].FiatValue, _ = i.node.GetFiatValue(int64(p.Total), p.Timestamp)
 		unread, err := i.node.Datastore.Chat().GetUnreadCount(p.OrderId)
 		if err != nil {
 			continue
@@ -2806,6 +3401,7 @@ func (i *jsonAPIHandler) POSTSales(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	for n, s := range sales {
+		sales[n].FiatValue, _ = i.node.GetFiatValue(int64(s.Total), s.Timestamp)
 		unread, err := i.node.Datastore.Chat().GetUnreadCount(s.OrderId)
 		if err != nil {
 			continue
@@ -2935,7 +3531,7 @@ func (i *jsonAPIHandler) POSTBumpFee(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	newTxid, err := i.node.Wallet.BumpFee(*txHash)
+	newTxid, err := i.node.WalletFor(r.Context()).BumpFee(*txHash)
 	if err != nil {
 		if err == spvwallet.BumpFeeAlreadyConfirmedError {
 			ErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -2944,7 +3540,7 @@ func (i *jsonAPIHandler) POSTBumpFee(w http.ResponseWriter, r *http.Request) {
 		} else if err == spvwallet.BumpFeeNotFoundError {
 			ErrorResponse(w, http.StatusNotFound, err.Error())
 		} else {
-			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			walletErrorResponse(w, err)
 		}
 		return
 	}
@@ -3254,3 +3850,345 @@ func (i *jsonAPIHandler) POSTImportListings(w http.ResponseWriter, r *http.Reque
 	}
 	SanitizedResponse(w, "{}")
 }
+
+func (i *jsonAPIHandler) POSTPost(w http.ResponseWriter, r *http.Request) {
+	post := new(pb.Post)
+	err := jsonpb.Unmarshal(r.Body, post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if post.Slug != "" {
+		_, ferr := os.Stat(path.Join(i.node.RepoPath, "root", "feed", post.Slug+".json"))
+		if !os.IsNotExist(ferr) {
+			ErrorResponse(w, http.StatusConflict, "Post already exists. Use PUT.")
+			return
+		}
+	} else {
+		post.Slug, err = i.node.GeneratePostSlug(post.Status)
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	signedPost, err := i.node.SignPost(post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	err = i.node.SavePost(signedPost)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"slug": "%s"}`, signedPost.Post.Slug))
+	return
+}
+
+func (i *jsonAPIHandler) PUTPost(w http.ResponseWriter, r *http.Request) {
+	post := new(pb.Post)
+	err := jsonpb.Unmarshal(r.Body, post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	_, ferr := os.Stat(path.Join(i.node.RepoPath, "root", "feed", post.Slug+".json"))
+	if os.IsNotExist(ferr) {
+		ErrorResponse(w, http.StatusNotFound, "Post not found.")
+		return
+	}
+	signedPost, err := i.node.SignPost(post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	err = i.node.SavePost(signedPost)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) DELETEPost(w http.ResponseWriter, r *http.Request) {
+	_, slug := path.Split(r.URL.Path)
+	if _, err := i.node.GetPostFromSlug(slug); err != nil {
+		ErrorResponse(w, http.StatusNotFound, "Post not found.")
+		return
+	}
+	err := i.node.DeletePost(slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) GETPosts(w http.ResponseWriter, r *http.Request) {
+	_, peerId := path.Split(r.URL.Path)
+	var err error
+	if peerId == "" || strings.ToLower(peerId) == "posts" || peerId == i.node.IpfsNode.Identity.Pretty() {
+		postsBytes, err := i.node.GetPosts()
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		SanitizedResponse(w, string(postsBytes))
+	} else {
+		if strings.HasPrefix(peerId, "@") {
+			peerId, err = i.node.Resolver.Resolve(peerId)
+			if err != nil {
+				ErrorResponse(w, http.StatusNotFound, err.Error())
+				return
+			}
+		}
+		postsBytes, err := ipfs.ResolveThenCat(i.node.Context, ipnspath.FromString(path.Join(peerId, "feed", "index.json")))
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		SanitizedResponse(w, string(postsBytes))
+		w.Header().Set("Cache-Control", "public, max-age=600, immutable")
+	}
+}
+
+func (i *jsonAPIHandler) GETPost(w http.ResponseWriter, r *http.Request) {
+	urlPath, slug := path.Split(r.URL.Path)
+	_, peerId := path.Split(urlPath[:len(urlPath)-1])
+	var sp *pb.SignedPost
+	var err error
+	if peerId == "" || strings.ToLower(peerId) == "post" || peerId == i.node.IpfsNode.Identity.Pretty() {
+		sp, err = i.node.GetPostFromSlug(slug)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, "Post not found.")
+			return
+		}
+	} else {
+		if strings.HasPrefix(peerId, "@") {
+			peerId, err = i.node.Resolver.Resolve(peerId)
+			if err != nil {
+				ErrorResponse(w, http.StatusNotFound, err.Error())
+				return
+			}
+		}
+		sp, err = i.node.FetchPost(peerId, slug)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(sp)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, out)
+}
+
+func (i *jsonAPIHandler) GETTimeline(w http.ResponseWriter, r *http.Request) {
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		limit = "-1"
+	}
+	l, err := strconv.Atoi(limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	timeline, err := i.node.GetTimeline(l)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(timeline))
+}
+
+func (i *jsonAPIHandler) POSTAPIToken(w http.ResponseWriter, r *http.Request) {
+	type tokenRequest struct {
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+	}
+	var req tokenRequest
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Name == "" || strings.Contains(req.Name, "/") {
+		ErrorResponse(w, http.StatusBadRequest, "API token name must not be empty or contain file separators")
+		return
+	}
+	if len(req.Scopes) == 0 {
+		ErrorResponse(w, http.StatusBadRequest, "API token must have at least one scope")
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !validScope(scope) {
+			ErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Unknown scope %s", scope))
+			return
+		}
+	}
+	tokens, err := i.node.Datastore.APITokens().GetAll()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for _, t := range tokens {
+		if t.Name == req.Name {
+			ErrorResponse(w, http.StatusConflict, "API token already exists. Revoke it first.")
+			return
+		}
+	}
+	token, err := newAPIToken()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = i.node.Datastore.APITokens().Put(repo.APIToken{
+		Name:      req.Name,
+		TokenHash: hashAPIToken(token),
+		Scopes:    req.Scopes,
+		Created:   time.Now(),
+	})
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The token is only returned here. We only keep its hash.
+	type tokenResponse struct {
+		Name   string   `json:"name"`
+		Token  string   `json:"token"`
+		Scopes []string `json:"scopes"`
+	}
+	ret, err := json.MarshalIndent(tokenResponse{req.Name, token, req.Scopes}, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) GETAPITokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := i.node.Datastore.APITokens().GetAll()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(tokens, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if string(ret) == "null" {
+		ret = []byte("[]")
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) DELETEAPIToken(w http.ResponseWriter, r *http.Request) {
+	_, name := path.Split(r.URL.Path)
+	err := i.node.Datastore.APITokens().Delete(name)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, "API token not found.")
+		return
+	}
+	SanitizedResponse(w, `{}`)
+}
+
+func (i *jsonAPIHandler) GETAuditLog(w http.ResponseWriter, r *http.Request) {
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		limit = "-1"
+	}
+	l, err := strconv.Atoi(limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset := r.URL.Query().Get("offsetId")
+	if offset == "" {
+		offset = "0"
+	}
+	offsetId, err := strconv.Atoi(offset)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	entries, err := i.node.Datastore.AuditLog().Get(offsetId, l)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(entries, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if string(ret) == "null" {
+		ret = []byte("[]")
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+// Export the whole audit log, oldest first, as a CSV file
+func (i *jsonAPIHandler) GETAuditLogExport(w http.ResponseWriter, r *http.Request) {
+	entries, err := i.node.Datastore.AuditLog().GetAll()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=auditlog.csv")
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "timestamp", "method", "path", "principal", "request", "status", "result", "prevHash", "hash"})
+	for _, e := range entries {
+		cw.Write([]string{
+			strconv.Itoa(e.ID),
+			e.Timestamp.UTC().Format(time.RFC3339),
+			e.Method,
+			e.Path,
+			e.Principal,
+			e.Request,
+			strconv.Itoa(e.Status),
+			e.Result,
+			e.PrevHash,
+			e.Hash,
+		})
+	}
+	cw.Flush()
+}
+
+func (i *jsonAPIHandler) GETAuditLogVerify(w http.ResponseWriter, r *http.Request) {
+	id, err := i.node.Datastore.AuditLog().Verify()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if id != 0 {
+		SanitizedResponse(w, fmt.Sprintf(`{"valid": false, "firstInvalidId": %d}`, id))
+		return
+	}
+	SanitizedResponse(w, `{"valid": true}`)
+}
@@ -147,6 +147,91 @@ const settingsMalformedJSONResponse = `{
     "reason": "invalid character '/' looking for beginning of object key string"
 }`
 
+const settingsWebhookJSON = `{
+    "webhookSettings": {
+        "notifications": true,
+        "webhooks": [
+            {
+                "url": "https://fulfillment.urbanart.com/hooks",
+                "secret": "letmein",
+                "eventTypes": ["order", "payment"]
+            }
+        ]
+    }
+}`
+
+const settingsWebhookPatchedJSON = `{
+    "version": "",
+    "language": "Klingon",
+    "mispaymentBuffer": 1,
+    "webhookSettings": {
+        "notifications": true,
+        "webhooks": [
+            {
+                "url": "https://fulfillment.urbanart.com/hooks",
+                "secret": "letmein",
+                "eventTypes": ["order", "payment"]
+            }
+        ]
+    }
+}`
+
+const settingsInvalidWebhookJSON = `{
+    "webhookSettings": {
+        "notifications": true,
+        "webhooks": [
+            {
+                "url": "ftp://fulfillment.urbanart.com/hooks",
+                "secret": "letmein",
+                "eventTypes": ["order", "payment"]
+            }
+        ]
+    }
+}`
+
+const settingsInvalidWebhookJSONResponse = `{
+    "success": false,
+    "reason": "Webhook URLs must be valid http or https URLs"
+}`
+
+const settingsInvalidCommandJSON = `{
+    "commandSettings": {
+        "notifications": true,
+        "command": "../../bin/sh"
+    }
+}`
+
+const settingsInvalidCommandJSONResponse = `{
+    "success": false,
+    "reason": "Command must be the name of a file in the scripts directory"
+}`
+
+const settingsInvalidOrderCompletionJSON = `{
+    "orderCompletion": {
+        "autoComplete": true,
+        "autoCompleteDays": 14,
+        "defaultRating": 6
+    }
+}`
+
+const settingsInvalidOrderCompletionJSONResponse = `{
+    "success": false,
+    "reason": "Default rating must be between 1 and 5, or 0 to leave no rating"
+}`
+
+const settingsInvalidColdStorageJSON = `{
+    "coldStorage": {
+        "autoSweep": true,
+        "xpub": "notakey",
+        "threshold": 5000000
+    }
+}`
+
+const settingsInvalidColdStorageJSONResponse = `{
+    "success": false,
+    "reason": "Invalid cold storage xpub"
+}`
+
 const settingsAlreadyExistsJSON = `{
     "success": false,
     "reason": "Settings is already set. Use PUT."
@@ -733,6 +818,29 @@ const moderatorUpdatedValidJSON = `{
 	}
 }`
 
+//
+// Posts
+//
+
+const postJSON = `{
+    "slug": "back-in-stock",
+    "status": "The Ron Swanson tshirt is back in stock",
+    "longForm": "We restocked every size this morning.",
+    "tags": ["restock"],
+    "listings": [{
+        "peerID": "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG",
+        "slug": "ron-swanson-tshirt"
+    }]
+}`
+
+const postJSONResponse = `{"slug": "back-in-stock"}`
+
+const postUpdateJSON = `{
+    "slug": "back-in-stock",
+    "status": "The Ron Swanson tshirt is back in stock in every size",
+    "tags": ["restock"]
+}`
+
 //
 // Status
 //
@@ -759,11 +867,85 @@ const spendJSON = `{
 	"feeLevel": "NORMAL"
 }`
 
+const spendAddressAndOutputsJSON = `{
+	"address": "1HYhu8e2wv19LZ2umXoo1pMiwzy2rL32UQ",
+	"amount": 1700000,
+	"outputs": [{"address": "1HYhu8e2wv19LZ2umXoo1pMiwzy2rL32UQ", "amount": 1700000}],
+	"feeLevel": "NORMAL"
+}`
+
+const spendAddressAndOutputsErrorJSON = `{
+    "success": false,
+    "reason": "Specify either an address and amount or outputs, not both"
+}`
+
+const spendDuplicateOutputsJSON = `{
+	"outputs": [
+		{"address": "1HYhu8e2wv19LZ2umXoo1pMiwzy2rL32UQ", "amount": 1700000, "label": "Rent"},
+		{"address": "1HYhu8e2wv19LZ2umXoo1pMiwzy2rL32UQ", "amount": 1700000, "label": "Deposit"}
+	],
+	"feeLevel": "NORMAL"
+}`
+
+const spendDuplicateOutputsErrorJSON = `{
+    "success": false,
+    "reason": "Each output must pay a different address"
+}`
+
 const insuffientFundsJSON = `{
     "success": false,
     "reason": "insuffient funds"
 }`
 
+const coldStorageNotConfiguredJSON = `{
+    "success": false,
+    "reason": "Cold storage is not configured"
+}`
+
+const unknownSignedTransactionJSON = `{
+    "success": false,
+    "reason": "Unknown transaction"
+}`
+
+const noHistoricalExchangeRateJSON = `{
+    "success": false,
+    "reason": "No exchange rate recorded for USD"
+}`
+
+const bidsSlugRequiredJSON = `{
+    "success": false,
+    "reason": "A listing slug must be specified"
+}`
+
+const outboxMessageNotFoundJSON = `{
+    "success": false,
+    "reason": "Message not found"
+}`
+
+//
+// API tokens
+//
+
+const apiTokenJSON = `{
+    "name": "inventory-sync",
+    "scopes": ["read-only", "listings-write"]
+}`
+
+const apiTokenInvalidScopeJSON = `{
+    "name": "inventory-sync",
+    "scopes": ["everything"]
+}`
+
+const apiTokenInvalidScopeJSONResponse = `{
+    "success": false,
+    "reason": "Unknown scope everything"
+}`
+
+const apiTokenAlreadyExistsJSON = `{
+    "success": false,
+    "reason": "API token already exists. Revoke it first."
+}`
+
 //
 // Peers
 //
@@ -57,6 +57,33 @@ func TestSettings(t *testing.T) {
 		{"GET", "/ob/settings", "", 200, settingsJSON},
 		{"PUT", "/ob/settings", settingsMalformedJSON, 400, settingsMalformedJSONResponse},
 	})
+
+	// Invalid webhook URL
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", settingsInvalidWebhookJSON, 400, settingsInvalidWebhookJSONResponse},
+	})
+
+	// Patching other settings keeps the webhooks
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", settingsWebhookJSON, 200, "{}"},
+		{"PATCH", "/ob/settings", `{"language": "Klingon"}`, 200, "{}"},
+		{"GET", "/ob/settings", "", 200, settingsWebhookPatchedJSON},
+	})
+
+	// Notification commands must be in the scripts directory
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", settingsInvalidCommandJSON, 400, settingsInvalidCommandJSONResponse},
+	})
+
+	// Default ratings must be in range
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", settingsInvalidOrderCompletionJSON, 400, settingsInvalidOrderCompletionJSONResponse},
+	})
+
+	// Cold storage sweeps need a valid xpub
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", settingsInvalidColdStorageJSON, 400, settingsInvalidColdStorageJSONResponse},
+	})
 }
 
 func TestProfile(t *testing.T) {
@@ -178,6 +205,76 @@ func TestListings(t *testing.T) {
 	})
 }
 
+func TestPosts(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/posts", "", 200, `[]`},
+
+		// Invalid creates
+		{"POST", "/ob/post", `{`, 400, jsonUnexpectedEOF},
+
+		// Create/Get
+		{"GET", "/ob/post/back-in-stock", "", 404, NotFoundJSON("Post")},
+		{"POST", "/ob/post", postJSON, 200, postJSONResponse},
+		{"GET", "/ob/post/back-in-stock", "", 200, anyResponseJSON},
+		{"POST", "/ob/post", postUpdateJSON, 409, AlreadyExistsUsePUTJSON("Post")},
+		{"GET", "/ob/posts", "", 200, anyResponseJSON},
+		{"GET", "/ob/timeline", "", 200, anyResponseJSON},
+
+		// Update/Get
+		{"PUT", "/ob/post", postUpdateJSON, 200, `{}`},
+		{"GET", "/ob/post/back-in-stock", "", 200, anyResponseJSON},
+
+		// Delete/Get
+		{"DELETE", "/ob/post/back-in-stock", "", 200, `{}`},
+		{"DELETE", "/ob/post/back-in-stock", "", 404, NotFoundJSON("Post")},
+		{"GET", "/ob/post/back-in-stock", "", 404, NotFoundJSON("Post")},
+		{"GET", "/ob/posts", "", 200, `[]`},
+
+		// Mutate non-existing posts
+		{"PUT", "/ob/post", postUpdateJSON, 404, NotFoundJSON("Post")},
+	})
+}
+
+func TestAPITokens(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/apitokens", "", 200, `[]`},
+
+		// Invalid creates
+		{"POST", "/ob/apitokens", `{`, 400, jsonUnexpectedEOF},
+		{"POST", "/ob/apitokens", apiTokenInvalidScopeJSON, 400, apiTokenInvalidScopeJSONResponse},
+
+		// Create/Get
+		{"POST", "/ob/apitokens", apiTokenJSON, 200, anyResponseJSON},
+		{"POST", "/ob/apitokens", apiTokenJSON, 409, apiTokenAlreadyExistsJSON},
+		{"GET", "/ob/apitokens", "", 200, anyResponseJSON},
+
+		// Revoke
+		{"DELETE", "/ob/apitokens/inventory-sync", "", 200, `{}`},
+		{"DELETE", "/ob/apitokens/inventory-sync", "", 404, NotFoundJSON("API token")},
+		{"GET", "/ob/apitokens", "", 200, `[]`},
+	})
+}
+
+func TestAuditLog(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", settingsJSON, 200, "{}"},
+		{"PATCH", "/ob/settings", settingsPatchJSON, 200, "{}"},
+		{"GET", "/ob/auditlog?limit=2", "", 200, anyResponseJSON},
+		{"GET", "/ob/auditlog?limit=x", "", 400, anyResponseJSON},
+		{"GET", "/ob/auditlog/verify", "", 200, `{"valid": true}`},
+	})
+}
+
+func TestBids(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/bids", "", 400, bidsSlugRequiredJSON},
+		{"GET", "/ob/bids/", "", 400, bidsSlugRequiredJSON},
+		{"GET", "/ob/bids/ron-swanson-tshirt", "", 200, `[]`},
+		{"GET", "/ob/bids/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG/ron-swanson-tshirt", "", 200, `[]`},
+		{"GET", "/ob/bids/notapeer/ron-swanson-tshirt", "", 400, anyResponseJSON},
+	})
+}
+
 func TestStatus(t *testing.T) {
 	runAPITests(t, apiTests{
 		{"GET", "/ob/status", "", 400, anyResponseJSON},
@@ -191,10 +288,31 @@ func TestWallet(t *testing.T) {
 		{"GET", "/wallet/balance", "", 200, walletBalanceJSONResponse},
 		{"GET", "/wallet/mnemonic", "", 200, walletMneumonicJSONResponse},
 		{"POST", "/wallet/spend", spendJSON, 500, insuffientFundsJSON},
+		{"POST", "/wallet/spend", spendAddressAndOutputsJSON, 400, spendAddressAndOutputsErrorJSON},
+		{"POST", "/wallet/spend", spendDuplicateOutputsJSON, 400, spendDuplicateOutputsErrorJSON},
+		{"GET", "/wallet/unsigned", "", 200, `[]`},
+		{"POST", "/wallet/signed", `{"id": "QmUnknown"}`, 400, unknownSignedTransactionJSON},
+		{"GET", "/wallet/coldstoragesweep", "", 400, coldStorageNotConfiguredJSON},
 		// TODO: Test successful spend on regnet with coins
 	})
 }
 
+func TestHistoricalExchangeRate(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/historicalexchangerate/USD", "", 404, noHistoricalExchangeRateJSON},
+		{"GET", "/ob/historicalexchangerate/USD?timestamp=2017-07-14T02:40:00Z", "", 404, noHistoricalExchangeRateJSON},
+		{"GET", "/ob/historicalexchangerate/USD?timestamp=yesterday", "", 400, anyResponseJSON},
+	})
+}
+
+func TestOutbox(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/outbox", "", 200, `[]`},
+		{"GET", "/ob/outbox?orderId=QmUnknown", "", 200, `[]`},
+		{"POST", "/ob/resendmessage", `{"pointerId": "QmUnknown"}`, 404, outboxMessageNotFoundJSON},
+	})
+}
+
 func TestConfig(t *testing.T) {
 	runAPITests(t, apiTests{
 		// TODO: Need better JSON matching
@@ -27,24 +27,57 @@ type Data interface {
 	// Describe() (string, string)
 }
 
+// Websocket clients can subscribe to a topic root or to a single topic such as "orders/<orderId>"
+const (
+	TopicWallet     = "wallet"
+	TopicOrders     = "orders"
+	TopicCases      = "cases"
+	TopicChat       = "chat"
+	TopicStatus     = "status"
+	TopicListings   = "listings"
+	TopicFollows    = "follows"
+	TopicModerators = "moderators"
+)
+
+var Topics = []string{
+	TopicWallet,
+	TopicOrders,
+	TopicCases,
+	TopicChat,
+	TopicStatus,
+	TopicListings,
+	TopicFollows,
+	TopicModerators,
+}
+
 type notificationWrapper struct {
-	Notification Data `json:"notification"`
+	Notification Data   `json:"notification"`
+	Topic        string `json:"topic"`
 }
 
 type messageWrapper struct {
-	Message Data `json:"message"`
+	Message Data   `json:"message"`
+	Topic   string `json:"topic"`
 }
 
 type walletWrapper struct {
-	Message Data `json:"wallet"`
+	Message Data   `json:"wallet"`
+	Topic   string `json:"topic"`
+}
+
+type walletUpdateWrapper struct {
+	WalletUpdate Data   `json:"walletUpdate"`
+	Topic        string `json:"topic"`
 }
 
 type messageReadWrapper struct {
-	MessageRead Data `json:"messageRead"`
+	MessageRead Data   `json:"messageRead"`
+	Topic       string `json:"topic"`
 }
 
 type messageTypingWrapper struct {
-	MessageRead Data `json:"messageTyping"`
+	MessageRead Data   `json:"messageTyping"`
+	Topic       string `json:"topic"`
 }
 
 type OrderNotification struct {
@@ -137,6 +170,33 @@ type ModeratorRemoveNotification struct {
 	PeerId string `json:"peerId"`
 }
 
+type BidNotification struct {
+	Type      string    `json:"type"`
+	Slug      string    `json:"slug"`
+	Title     string    `json:"title"`
+	BuyerId   string    `json:"buyerId"`
+	Amount    uint64    `json:"amount"`
+	Thumbnail Thumbnail `json:"thumbnail"`
+}
+
+type CrowdFundNotification struct {
+	Type      string    `json:"type"`
+	Slug      string    `json:"slug"`
+	Title     string    `json:"title"`
+	GoalMet   bool      `json:"goalMet"`
+	Pledged   uint64    `json:"pledged"`
+	Goal      uint64    `json:"goal"`
+	Thumbnail Thumbnail `json:"thumbnail"`
+}
+
+type EscrowReleasableNotification struct {
+	Type      string    `json:"type"`
+	OrderId   string    `json:"orderId"`
+	Title     string    `json:"title"`
+	Amount    int64     `json:"amount"`
+	Thumbnail Thumbnail `json:"thumbnail"`
+}
+
 type StatusNotification struct {
 	Status string `json:"status"`
 }
@@ -160,6 +220,13 @@ type ChatTyping struct {
 	Subject string `json:"subject"`
 }
 
+// Periodic update of the wallet balance and chain height
+type WalletUpdate struct {
+	Height      uint32 `json:"height"`
+	Unconfirmed int64  `json:"unconfirmed"`
+	Confirmed   int64  `json:"confirmed"`
+}
+
 type IncomingTransaction struct {
 	Txid          string    `json:"txid"`
 	Value         int64     `json:"value"`
@@ -175,76 +242,141 @@ type IncomingTransaction struct {
 }
 
 func wrap(i interface{}) interface{} {
+	topic := Topic(i)
 	switch i.(type) {
 	case OrderNotification:
 		n := i.(OrderNotification)
 		n.Type = "order"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case PaymentNotification:
 		n := i.(PaymentNotification)
 		n.Type = "payment"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case OrderConfirmationNotification:
 		n := i.(OrderConfirmationNotification)
 		n.Type = "orderConfirmation"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case OrderCancelNotification:
 		n := i.(OrderCancelNotification)
 		n.Type = "cancel"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case RefundNotification:
 		n := i.(RefundNotification)
 		n.Type = "refund"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case FulfillmentNotification:
 		n := i.(FulfillmentNotification)
 		n.Type = "fulfillment"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case CompletionNotification:
 		n := i.(CompletionNotification)
 		n.Type = "orderComplete"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case DisputeOpenNotification:
 		n := i.(DisputeOpenNotification)
 		n.Type = "disputeOpen"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case DisputeUpdateNotification:
 		n := i.(DisputeUpdateNotification)
 		n.Type = "disputeUpdate"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case DisputeCloseNotification:
 		n := i.(DisputeCloseNotification)
 		n.Type = "disputeClose"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case FollowNotification:
 		n := i.(FollowNotification)
 		n.Type = "follow"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case UnfollowNotification:
 		n := i.(UnfollowNotification)
 		n.Type = "unfollow"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case ModeratorAddNotification:
 		n := i.(ModeratorAddNotification)
 		n.Type = "moderatorAdd"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
 	case ModeratorRemoveNotification:
 		n := i.(ModeratorRemoveNotification)
 		n.Type = "moderatorRemove"
-		return notificationWrapper{n}
+		return notificationWrapper{n, topic}
+	case BidNotification:
+		n := i.(BidNotification)
+		n.Type = "bid"
+		return notificationWrapper{n, topic}
+	case CrowdFundNotification:
+		n := i.(CrowdFundNotification)
+		n.Type = "crowdFund"
+		return notificationWrapper{n, topic}
+	case EscrowReleasableNotification:
+		n := i.(EscrowReleasableNotification)
+		n.Type = "escrowReleasable"
+		return notificationWrapper{n, topic}
 	case ChatMessage:
-		return messageWrapper{i.(ChatMessage)}
+		return messageWrapper{i.(ChatMessage), topic}
 	case ChatRead:
-		return messageReadWrapper{i.(ChatRead)}
+		return messageReadWrapper{i.(ChatRead), topic}
 	case ChatTyping:
-		return messageTypingWrapper{i.(ChatTyping)}
+		return messageTypingWrapper{i.(ChatTyping), topic}
 	case IncomingTransaction:
-		return walletWrapper{i.(IncomingTransaction)}
+		return walletWrapper{i.(IncomingTransaction), topic}
+	case WalletUpdate:
+		return walletUpdateWrapper{i.(WalletUpdate), topic}
 	default:
 		return i
 	}
 }
 
+// Return the topic a websocket client must be subscribed to in order to receive the notification
+func Topic(i interface{}) string {
+	switch n := i.(type) {
+	case OrderNotification:
+		return TopicOrders + "/" + n.OrderId
+	case PaymentNotification:
+		return TopicOrders + "/" + n.OrderId
+	case OrderConfirmationNotification:
+		return TopicOrders + "/" + n.OrderId
+	case OrderDeclinedNotification:
+		return TopicOrders + "/" + n.OrderId
+	case OrderCancelNotification:
+		return TopicOrders + "/" + n.OrderId
+	case RefundNotification:
+		return TopicOrders + "/" + n.OrderId
+	case FulfillmentNotification:
+		return TopicOrders + "/" + n.OrderId
+	case CompletionNotification:
+		return TopicOrders + "/" + n.OrderId
+	case EscrowReleasableNotification:
+		return TopicOrders + "/" + n.OrderId
+	case DisputeOpenNotification:
+		return TopicCases + "/" + n.OrderId
+	case DisputeUpdateNotification:
+		return TopicCases + "/" + n.OrderId
+	case DisputeCloseNotification:
+		return TopicCases + "/" + n.OrderId
+	case FollowNotification, UnfollowNotification:
+		return TopicFollows
+	case ModeratorAddNotification, ModeratorRemoveNotification:
+		return TopicModerators
+	case BidNotification:
+		return TopicListings + "/" + n.Slug
+	case CrowdFundNotification:
+		return TopicListings + "/" + n.Slug
+	case StatusNotification:
+		return TopicStatus
+	case ChatMessage:
+		return TopicChat + "/" + n.PeerId
+	case ChatRead:
+		return TopicChat + "/" + n.PeerId
+	case ChatTyping:
+		return TopicChat + "/" + n.PeerId
+	case IncomingTransaction, WalletUpdate:
+		return TopicWallet
+	default:
+		return ""
+	}
+}
+
 func Serialize(i interface{}) []byte {
 	w := wrap(i)
 	if _, ok := w.([]byte); ok {
@@ -332,6 +464,32 @@ func Describe(i interface{}) (string, string) {
 		n := i.(DisputeCloseNotification)
 		form := "Dispute around order \"%s\" was closed."
 		body = fmt.Sprintf(form, n.OrderId)
+
+	case BidNotification:
+		head = "Bid received"
+
+		n := i.(BidNotification)
+		form := "You received a bid of %d on \"%s\".\n\nBuyer: %s"
+		body = fmt.Sprintf(form, n.Amount, n.Title, n.BuyerId)
+
+	case CrowdFundNotification:
+		n := i.(CrowdFundNotification)
+		if n.GoalMet {
+			head = "Crowd fund goal met"
+			form := "\"%s\" raised %d of its %d goal. The pledges have been confirmed and will be released as each order completes."
+			body = fmt.Sprintf(form, n.Title, n.Pledged, n.Goal)
+		} else {
+			head = "Crowd fund goal not met"
+			form := "\"%s\" raised %d of its %d goal. All pledges have been refunded."
+			body = fmt.Sprintf(form, n.Title, n.Pledged, n.Goal)
+		}
+
+	case EscrowReleasableNotification:
+		head = "Escrow can be released"
+
+		n := i.(EscrowReleasableNotification)
+		form := "The escrow timeout for order \"%s\" has expired. You can now release the %d held in escrow without the buyer."
+		body = fmt.Sprintf(form, n.OrderId, n.Amount)
 	}
 	return head, body
 }
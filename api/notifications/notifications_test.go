@@ -16,3 +16,36 @@ func TestSerialization(t *testing.T) {
 		t.Error("Incorrect serialization")
 	}
 }
+
+func TestTopic(t *testing.T) {
+	tests := []struct {
+		n     interface{}
+		topic string
+	}{
+		{OrderNotification{OrderId: "QmOrder"}, "orders/QmOrder"},
+		{DisputeOpenNotification{OrderId: "QmOrder"}, "cases/QmOrder"},
+		{ChatTyping{PeerId: "QmPeer"}, "chat/QmPeer"},
+		{IncomingTransaction{}, "wallet"},
+		{WalletUpdate{}, "wallet"},
+		{StatusNotification{}, "status"},
+		{[]byte("{}"), ""},
+	}
+	for _, test := range tests {
+		if topic := Topic(test.n); topic != test.topic {
+			t.Errorf("Expected topic %q, got %q", test.topic, topic)
+		}
+	}
+}
+
+func TestSerializationIncludesTopic(t *testing.T) {
+	var wrapped struct {
+		Topic string `json:"topic"`
+	}
+	err := json.Unmarshal(Serialize(PaymentNotification{OrderId: "QmOrder"}), &wrapped)
+	if err != nil {
+		t.Error(err)
+	}
+	if wrapped.Topic != "orders/QmOrder" {
+		t.Error("Serialized notification is not tagged with its topic")
+	}
+}
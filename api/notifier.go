@@ -1,8 +1,10 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/smtp"
+	"path"
 	"strings"
 
 	"errors"
@@ -17,10 +19,25 @@ import (
 // each received object.
 type notificationManager struct {
 	node *core.OpenBazaarNode
+
+	// Signalled when new webhook requests are queued
+	webhookWake chan struct{}
+
+	// Notifications waiting for the notifiers
+	queue chan interface{}
 }
 
-func manageNotifications(node *core.OpenBazaarNode, out chan []byte) chan interface{} {
-	manager := &notificationManager{node: node}
+// How many notifications may wait for slow notifiers before new ones are dropped
+const notifierQueueSize = 100
+
+func manageNotifications(node *core.OpenBazaarNode, out chan wsMessage) chan interface{} {
+	manager := &notificationManager{
+		node:        node,
+		webhookWake: make(chan struct{}, 1),
+		queue:       make(chan interface{}, notifierQueueSize),
+	}
+	go manager.processWebhookQueue()
+	go manager.processNotifierQueue()
 	nodeBroadcast := make(chan interface{})
 	go func() {
 		for {
@@ -28,13 +45,13 @@ func manageNotifications(node *core.OpenBazaarNode, out chan []byte) chan interf
 			// Fixme: right now this assumes that n is a notification but it should be agnostic
 			// enough to let us send any data to the websocket. You can technically do that by
 			// sending over a []byte as the serialize function ignores []bytes but it's kind of hacky.
-			manager.sendNotification(n)
+			manager.queueNotification(n)
 			sanitized, err := SanitizeJSON(notifications.Serialize(n))
 			if err != nil {
 				log.Error(err)
 				continue
 			}
-			out <- sanitized
+			out <- wsMessage{notifications.Topic(n), sanitized}
 		}
 	}()
 	return nodeBroadcast
@@ -44,35 +61,94 @@ type notifier interface {
 	notify(n interface{}) error
 }
 
+// A notifier which can be turned on in the settings
+type notifierRegistration struct {
+	name string
+
+	// Return the notifier or nil if it isn't turned on in the settings
+	create func(m *notificationManager, settings repo.SettingsData) notifier
+
+	// Check the notifier's fields in the settings before they are saved
+	validate func(settings repo.SettingsData) error
+}
+
+var notifierRegistry []notifierRegistration
+
+func registerNotifier(r notifierRegistration) {
+	notifierRegistry = append(notifierRegistry, r)
+}
+
+func init() {
+	registerNotifier(notifierRegistration{
+		name: "smtp",
+		create: func(m *notificationManager, settings repo.SettingsData) notifier {
+			conf := settings.SMTPSettings
+			if conf == nil || !conf.Notifications {
+				return nil
+			}
+			return &smtpNotifier{settings: conf, templates: m.templates()}
+		},
+		validate: validateSMTPSettings,
+	})
+}
+
+/* Hand the notification to the notifiers without waiting for them. A command or an email can
+   take a while and the websocket and everything sending to the broadcast channel would wait. */
+func (m *notificationManager) queueNotification(n interface{}) {
+	select {
+	case m.queue <- n:
+	default:
+		log.Error("Notifiers are not keeping up, dropping notification")
+	}
+}
+
+func (m *notificationManager) processNotifierQueue() {
+	for n := range m.queue {
+		m.sendNotification(n)
+	}
+}
+
 // Send notification via all supported notifier mechanisms
 func (m *notificationManager) sendNotification(n interface{}) {
 	for _, notifier := range m.getNotifiers() {
 		if err := notifier.notify(n); err != nil {
-			log.Errorf("Notification failed")
+			log.Errorf("Notification failed: %s", err.Error())
 		}
 	}
 }
 
-// Create list of notifiers based on settings data (currently only SMTP is available)
-// TODO: should be extended to include new notifiers in the list
+// Create list of notifiers based on settings data
 func (m *notificationManager) getNotifiers() []notifier {
 	settings, err := m.node.Datastore.Settings().Get()
 	notifiers := []notifier{}
 	if err != nil {
 		return notifiers
 	}
-
-	// SMTP notifier
-	conf := settings.SMTPSettings
-	if conf != nil && conf.Notifications {
-		notifiers = append(notifiers, &smtpNotifier{settings: conf})
+	for _, r := range notifierRegistry {
+		if n := r.create(m, settings); n != nil {
+			notifiers = append(notifiers, n)
+		}
 	}
 	return notifiers
 }
 
+func (m *notificationManager) templates() *notificationTemplates {
+	return &notificationTemplates{dir: path.Join(m.node.RepoPath, "templates", "notifications")}
+}
+
+func validateNotifierSettings(s repo.SettingsData) error {
+	for _, r := range notifierRegistry {
+		if err := r.validate(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Notifier implementations
 type smtpNotifier struct {
-	settings *repo.SMTPSettings
+	settings  *repo.SMTPSettings
+	templates *notificationTemplates
 }
 
 func (notifier *smtpNotifier) notify(n interface{}) error {
@@ -80,16 +156,16 @@ func (notifier *smtpNotifier) notify(n interface{}) error {
 		"From: %s",
 		"To: %s",
 		"MIME-Version: 1.0",
-		"Content-Type: text/html; charset=UTF-8",
-		"Subject: [OpenBazaar] %s\r\n",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Subject: %s\r\n",
 		"%s\r\n",
 	}, "\r\n")
-	head, body := notifications.Describe(n)
-	if head == "" || body == "" {
-		return nil
+	subject, body, err := notifier.templates.render(n)
+	if err != nil || subject == "" || body == "" {
+		return err
 	}
 	conf := notifier.settings
-	data := fmt.Sprintf(template, conf.SenderEmail, conf.RecipientEmail, head, body)
+	data := fmt.Sprintf(template, conf.SenderEmail, conf.RecipientEmail, subject, body)
 	return sendEmail(notifier.settings, []byte(data))
 }
 
@@ -112,3 +188,16 @@ func validateSMTPSettings(s repo.SettingsData) error {
 	}
 	return nil
 }
+
+// Return the type of a notification such as "order" or "payment". Chat and wallet messages have no type.
+func notificationType(n interface{}) string {
+	var wrapper struct {
+		Notification struct {
+			Type string `json:"type"`
+		} `json:"notification"`
+	}
+	if err := json.Unmarshal(notifications.Serialize(n), &wrapper); err != nil {
+		return ""
+	}
+	return wrapper.Notification.Type
+}
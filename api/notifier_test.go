@@ -0,0 +1,25 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueueNotificationDoesNotBlock(t *testing.T) {
+	// Nothing is draining the queue, as if a notifier were stuck
+	m := &notificationManager{queue: make(chan interface{}, 1)}
+	done := make(chan bool)
+	go func() {
+		m.queueNotification("first")
+		m.queueNotification("second")
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Queueing a notification waited for the notifiers")
+	}
+	if n := <-m.queue; n != "first" {
+		t.Errorf("Expected the first notification to be queued, got %v", n)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+)
+
+const (
+	defaultSubjectTemplate = "[OpenBazaar] {{.Subject}}"
+	defaultBodyTemplate    = "{{.Description}}"
+)
+
+type notificationTemplates struct {
+	dir string
+}
+
+type notificationTemplateData struct {
+	// The notification type such as "order" or "payment"
+	Type string
+
+	// The subject and description returned by notifications.Describe
+	Subject     string
+	Description string
+
+	// The notification itself so templates can use any of its fields
+	Notification interface{}
+}
+
+/* Render the subject and body of a notification. The templates for a notification type can be
+   overridden by placing <type>.subject.tmpl and <type>.body.tmpl in the templates directory, or
+   default.subject.tmpl and default.body.tmpl to change them for every type. Notifications
+   which can't be described, such as chat messages, render as empty strings. */
+func (t *notificationTemplates) render(n interface{}) (string, string, error) {
+	head, description := notifications.Describe(n)
+	if head == "" || description == "" {
+		return "", "", nil
+	}
+	data := notificationTemplateData{
+		Type:         notificationType(n),
+		Subject:      head,
+		Description:  description,
+		Notification: n,
+	}
+	subject, err := t.execute("subject", defaultSubjectTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := t.execute("body", defaultBodyTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	// The subject is used as a header so it must fit on one line
+	subject = strings.Join(strings.Fields(subject), " ")
+	return subject, body, nil
+}
+
+func (t *notificationTemplates) execute(part, fallback string, data notificationTemplateData) (string, error) {
+	names := []string{"default." + part + ".tmpl"}
+	if data.Type != "" {
+		names = append([]string{data.Type + "." + part + ".tmpl"}, names...)
+	}
+	text := fallback
+	for _, name := range names {
+		b, err := ioutil.ReadFile(path.Join(t.dir, name))
+		if err == nil {
+			text = string(b)
+			break
+		}
+	}
+	tmpl, err := template.New(part).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
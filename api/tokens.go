@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/OpenBazaar/openbazaar-go/core"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+const (
+	ScopeReadOnly      = "read-only"
+	ScopeListingsWrite = "listings-write"
+	ScopeOrders        = "orders"
+	ScopeWalletSpend   = "wallet-spend"
+	ScopeAdmin         = "admin"
+)
+
+var apiScopes = []string{
+	ScopeReadOnly,
+	ScopeListingsWrite,
+	ScopeOrders,
+	ScopeWalletSpend,
+	ScopeAdmin,
+}
+
+// Who made an API request and what they are allowed to do
+type apiCaller struct {
+	principal string
+	scopes    []string
+}
+
+type callerKey struct{}
+
+// Attach the authenticated caller to the request. Wallet spends made for the request are audited against the caller.
+func withCaller(r *http.Request, caller apiCaller) *http.Request {
+	ctx := context.WithValue(r.Context(), callerKey{}, caller)
+	return r.WithContext(core.WithPrincipal(ctx, caller.principal))
+}
+
+func requestCaller(r *http.Request) apiCaller {
+	caller, _ := r.Context().Value(callerKey{}).(apiCaller)
+	return caller
+}
+
+/* Check the caller was granted the scope needed for the endpoint and respond with a 403 if
+   not. The admin scope grants access to everything. Callers authenticated with the cookie
+   or the username and password are given the admin scope. */
+func authorize(w http.ResponseWriter, r *http.Request, method, path string) bool {
+	needed := requiredScope(method, path)
+	if requestCaller(r).allowed(needed) {
+		return true
+	}
+	ErrorResponse(w, http.StatusForbidden, fmt.Sprintf("API token does not have the %s scope", needed))
+	return false
+}
+
+func (c apiCaller) allowed(scope string) bool {
+	for _, s := range c.scopes {
+		if s == ScopeAdmin || s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the token from an "Authorization: Bearer <token>" header
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+}
+
+func newAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base58.Encode(b), nil
+}
+
+func hashAPIToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func validScope(scope string) bool {
+	for _, s := range apiScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
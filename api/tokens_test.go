@@ -0,0 +1,29 @@
+package api
+
+import "testing"
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		scope  string
+	}{
+		{"GET", "/ob/profile", ScopeReadOnly},
+		{"GET", "/ob/listings/QmPeer", ScopeReadOnly},
+		{"GET", "/ob/config", ScopeAdmin},
+		{"GET", "/ob/outbox", ScopeAdmin},
+		{"GET", "/wallet/utxos", ScopeAdmin},
+		{"GET", "/wallet/unsigned", ScopeAdmin},
+		{"GET", "/wallet/mnemonic", ScopeAdmin},
+		{"GET", "/ob/settings", ScopeAdmin},
+		{"GET", "/ob/unknown", ScopeAdmin},
+		{"POST", "/ob/listing", ScopeListingsWrite},
+		{"POST", "/wallet/spend", ScopeWalletSpend},
+		{"DELETE", "/ob/apitokens/foo", ScopeAdmin},
+	}
+	for _, test := range tests {
+		if scope := requiredScope(test.method, test.path); scope != test.scope {
+			t.Errorf("%s %s: expected scope %s, got %s", test.method, test.path, test.scope, scope)
+		}
+	}
+}
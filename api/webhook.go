@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+const (
+	// How often the queue is checked for requests which are due to be retried
+	webhookQueueInterval = time.Minute
+
+	// Delay before the first retry. It doubles after each failed attempt up to webhookMaxBackoff.
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+
+	webhookMaxAttempts = 12
+	webhookTimeout     = 30 * time.Second
+)
+
+func init() {
+	registerNotifier(notifierRegistration{
+		name: "webhook",
+		create: func(m *notificationManager, settings repo.SettingsData) notifier {
+			conf := settings.WebhookSettings
+			if conf == nil || !conf.Notifications {
+				return nil
+			}
+			return &webhookNotifier{
+				settings: conf,
+				queue:    m.node.Datastore.WebhookQueue(),
+				wake:     m.webhookWake,
+			}
+		},
+		validate: validateWebhookSettings,
+	})
+}
+
+/* Webhooks are written to the queue in the datastore before they are sent so requests which
+   fail, or which are still waiting when the daemon is shut down, are retried later. */
+type webhookNotifier struct {
+	settings *repo.WebhookSettings
+	queue    repo.WebhookQueue
+	wake     chan struct{}
+}
+
+func (notifier *webhookNotifier) notify(n interface{}) error {
+	eventType := notificationType(n)
+	if eventType == "" {
+		return nil
+	}
+	payload := notifications.Serialize(n)
+	queued := false
+	for _, hook := range notifier.settings.Webhooks {
+		if !webhookWantsEvent(hook, eventType) {
+			continue
+		}
+		if err := notifier.queue.Put(hook.URL, eventType, payload, time.Now()); err != nil {
+			return err
+		}
+		queued = true
+	}
+	if queued {
+		select {
+		case notifier.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Send the queued webhook requests as they become due
+func (m *notificationManager) processWebhookQueue() {
+	tick := time.NewTicker(webhookQueueInterval)
+	defer tick.Stop()
+	for {
+		m.deliverWebhooks()
+		select {
+		case <-tick.C:
+		case <-m.webhookWake:
+		}
+	}
+}
+
+func (m *notificationManager) deliverWebhooks() {
+	queue := m.node.Datastore.WebhookQueue()
+	due, err := queue.GetDue(time.Now())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+	hooks := make(map[string]repo.Webhook)
+	settings, err := m.node.Datastore.Settings().Get()
+	if err == nil && settings.WebhookSettings != nil && settings.WebhookSettings.Notifications {
+		for _, hook := range settings.WebhookSettings.Webhooks {
+			hooks[hook.URL] = hook
+		}
+	}
+	for _, d := range due {
+		hook, ok := hooks[d.URL]
+		if !ok {
+			// The webhook was removed or disabled after the request was queued
+			queue.Delete(d.ID)
+			continue
+		}
+		if err := postWebhook(hook, d); err != nil {
+			attempts := d.Attempts + 1
+			if attempts >= webhookMaxAttempts {
+				log.Errorf("Giving up on webhook to %s after %d attempts: %s", d.URL, attempts, err.Error())
+				queue.Delete(d.ID)
+				continue
+			}
+			log.Warningf("Webhook to %s failed, retrying: %s", d.URL, err.Error())
+			queue.Reschedule(d.ID, attempts, time.Now().Add(webhookBackoff(attempts)))
+			continue
+		}
+		queue.Delete(d.ID)
+	}
+}
+
+func postWebhook(hook repo.Webhook, d repo.WebhookDelivery) error {
+	req, err := http.NewRequest("POST", hook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-OpenBazaar-Event", d.EventType)
+	req.Header.Set("X-OpenBazaar-Delivery", strconv.Itoa(d.ID))
+	if hook.Secret != "" {
+		req.Header.Set("X-OpenBazaar-Signature", "sha256="+signWebhookPayload(hook.Secret, d.Payload))
+	}
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Return the hex encoded HMAC-SHA256 of the payload which receivers use to authenticate the request
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func webhookWantsEvent(hook repo.Webhook, eventType string) bool {
+	if len(hook.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range hook.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func validateWebhookSettings(s repo.SettingsData) error {
+	if s.WebhookSettings == nil {
+		return nil
+	}
+	for _, hook := range s.WebhookSettings.Webhooks {
+		u, err := url.Parse(hook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("Webhook URLs must be valid http or https URLs")
+		}
+	}
+	return nil
+}
@@ -3,13 +3,18 @@ package api
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
 	"github.com/OpenBazaar/openbazaar-go/core"
 	"github.com/OpenBazaar/openbazaar-go/repo"
 	"github.com/gorilla/websocket"
 	"github.com/ipfs/go-ipfs/commands"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 )
 
 type connection struct {
@@ -21,6 +26,73 @@ type connection struct {
 
 	// The hub
 	h *hub
+
+	node *core.OpenBazaarNode
+
+	// Who opened the connection and what they may do with it
+	caller apiCaller
+
+	// Topics the client has subscribed to. If empty the client receives everything.
+	subscriptions map[string]bool
+	subLock       sync.RWMutex
+}
+
+/* Return whether the client should receive a message with the given topic. Subscribing to a
+   topic root such as "orders" includes every order while "orders/<orderId>" only includes
+   the one order. Messages without a topic, such as search results, only go to clients which
+   haven't subscribed to anything. */
+func (c *connection) subscribed(topic string) bool {
+	c.subLock.RLock()
+	defer c.subLock.RUnlock()
+	if len(c.subscriptions) == 0 {
+		return true
+	}
+	if topic == "" {
+		return false
+	}
+	for sub := range c.subscriptions {
+		if topic == sub || strings.HasPrefix(topic, sub+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON-RPC error codes
+const (
+	wsParseError     = -32700
+	wsMethodNotFound = -32601
+	wsInvalidParams  = -32602
+	wsServerError    = -32000
+	wsForbidden      = -32001
+)
+
+/* The API endpoint each websocket method stands in for. Callers need the same scope as they would
+   for the endpoint. */
+var wsEndpoints = map[string]struct{ method, path string }{
+	"sendChat":       {"POST", "/ob/chat"},
+	"sendGroupChat":  {"POST", "/ob/groupchat"},
+	"typing":         {"POST", "/ob/chat"},
+	"markChatAsRead": {"POST", "/ob/markchatasread"},
+	"subscribe":      {"GET", "/ob/notifications"},
+	"unsubscribe":    {"GET", "/ob/notifications"},
+}
+
+type wsRequest struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+type wsResponse struct {
+	ID     *json.RawMessage `json:"id"`
+	Result interface{}      `json:"result,omitempty"`
+	Error  *wsError         `json:"error,omitempty"`
+}
+
+type wsError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
 }
 
 func (c *connection) reader() {
@@ -31,12 +103,154 @@ func (c *connection) reader() {
 		}
 		log.Debugf("Incoming websocket message: %s", string(message))
 
-		// Just echo for now until we set up the API
-		c.h.Broadcast <- message
+		resp := c.handleRequest(message)
+		out, err := json.MarshalIndent(resp, "", "    ")
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		c.h.reply <- wsReply{c, out}
 	}
 	c.ws.Close()
 }
 
+/* Requests are handled in the order they are received so chat messages and read receipts
+   are sent to the other party in the same order the client made them. The response is only
+   sent to the connection which made the request. Methods standing in for state changing
+   endpoints are audited like the endpoints. */
+func (c *connection) handleRequest(message []byte) wsResponse {
+	var req wsRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		return wsResponse{Error: &wsError{wsParseError, err.Error()}}
+	}
+	resp := c.call(req)
+	if endpoint, ok := wsEndpoints[req.Method]; ok && endpoint.method != "GET" {
+		auditWebsocketCall(c.node.Datastore.AuditLog(), c.caller.principal, req, resp)
+	}
+	return resp
+}
+
+func (c *connection) call(req wsRequest) wsResponse {
+	resp := wsResponse{ID: req.ID}
+	endpoint, ok := wsEndpoints[req.Method]
+	if !ok {
+		resp.Error = &wsError{wsMethodNotFound, fmt.Sprintf("Unknown method %q", req.Method)}
+		return resp
+	}
+	if scope := requiredScope(endpoint.method, endpoint.path); !c.caller.allowed(scope) {
+		resp.Error = &wsError{wsForbidden, fmt.Sprintf("API token does not have the %s scope", scope)}
+		return resp
+	}
+	var result interface{}
+	var err error
+	switch req.Method {
+	case "sendChat":
+		result, err = c.sendChat(req.Params)
+	case "sendGroupChat":
+		result, err = c.sendGroupChat(req.Params)
+	case "typing":
+		result, err = c.typing(req.Params)
+	case "markChatAsRead":
+		result, err = c.markChatAsRead(req.Params)
+	case "subscribe":
+		result, err = c.subscribe(req.Params)
+	case "unsubscribe":
+		result, err = c.unsubscribe(req.Params)
+	default:
+		resp.Error = &wsError{wsMethodNotFound, fmt.Sprintf("Unknown method %q", req.Method)}
+		return resp
+	}
+	if err != nil {
+		code := wsServerError
+		if _, ok := err.(invalidParamsError); ok {
+			code = wsInvalidParams
+		}
+		resp.Error = &wsError{code, err.Error()}
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+type invalidParamsError struct {
+	error
+}
+
+type wsMessageIdResult struct {
+	MessageId string `json:"messageId"`
+}
+
+func (c *connection) sendChat(params json.RawMessage) (interface{}, error) {
+	var chat repo.ChatMessage
+	if err := json.Unmarshal(params, &chat); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	if chat.PeerId == "" {
+		return nil, invalidParamsError{errors.New("A peer ID must be specified")}
+	}
+	if chat.Message == "" {
+		return nil, invalidParamsError{errors.New("Message must not be empty")}
+	}
+	if err := validateChatMessage(chat.Subject, chat.Message); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	msgId, err := sendChatMessage(c.node, chat)
+	if err != nil {
+		return nil, err
+	}
+	return wsMessageIdResult{msgId}, nil
+}
+
+func (c *connection) sendGroupChat(params json.RawMessage) (interface{}, error) {
+	var chat repo.GroupChatMessage
+	if err := json.Unmarshal(params, &chat); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	if chat.Subject == "" {
+		return nil, invalidParamsError{errors.New("Group chats must include a unique subject to be used as the group chat ID")}
+	}
+	if err := validateChatMessage(chat.Subject, chat.Message); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	msgId, err := sendGroupChatMessage(c.node, chat)
+	if err != nil {
+		return nil, err
+	}
+	return wsMessageIdResult{msgId}, nil
+}
+
+// Typing indicators are chat messages without a body
+func (c *connection) typing(params json.RawMessage) (interface{}, error) {
+	var chat repo.ChatMessage
+	if err := json.Unmarshal(params, &chat); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	if chat.PeerId == "" {
+		return nil, invalidParamsError{errors.New("A peer ID must be specified")}
+	}
+	if err := validateChatMessage(chat.Subject, ""); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	chat.Message = ""
+	if _, err := sendChatMessage(c.node, chat); err != nil {
+		return nil, err
+	}
+	return struct{}{}, nil
+}
+
+func (c *connection) markChatAsRead(params json.RawMessage) (interface{}, error) {
+	var chat repo.ChatMessage
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &chat); err != nil {
+			return nil, invalidParamsError{err}
+		}
+	}
+	if err := markChatAsRead(c.node, chat.PeerId, chat.Subject); err != nil {
+		return nil, err
+	}
+	return struct{}{}, nil
+}
+
 func (c *connection) writer() {
 	for message := range c.send {
 		err := c.ws.WriteMessage(websocket.TextMessage, message)
@@ -57,6 +271,7 @@ var handler wsHandler
 
 type wsHandler struct {
 	h             *hub
+	node          *core.OpenBazaarNode
 	path          string
 	context       commands.Context
 	enabled       bool
@@ -76,6 +291,7 @@ func newWSAPIHandler(node *core.OpenBazaarNode, ctx commands.Context, authCookie
 	}
 	handler = wsHandler{
 		h:             hub,
+		node:          node,
 		path:          ctx.ConfigRoot,
 		context:       ctx,
 		enabled:       config.Enabled,
@@ -88,12 +304,9 @@ func newWSAPIHandler(node *core.OpenBazaarNode, ctx commands.Context, authCookie
 	return &handler, nil
 }
 
+/* Clients authenticate as they would with the API. Notifications are pushed to every connection so
+   API tokens need the scope for reading notifications to connect. */
 func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ws, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Error("Error upgrading to websockets:", err)
-		return
-	}
 	if !wsh.enabled {
 		w.WriteHeader(http.StatusForbidden)
 		fmt.Fprint(w, "403 - Forbidden")
@@ -107,8 +320,17 @@ func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	caller := apiCaller{"unauthenticated", []string{ScopeAdmin}}
 	if wsh.authenticated {
-		if wsh.username == "" || wsh.password == "" {
+		if token := bearerToken(r); token != "" {
+			apiToken, err := wsh.node.Datastore.APITokens().GetByHash(hashAPIToken(token))
+			if err != nil {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, "403 - Forbidden")
+				return
+			}
+			caller = apiCaller{"token:" + apiToken.Name, apiToken.Scopes}
+		} else if wsh.username == "" || wsh.password == "" {
 			cookie, err := r.Cookie("OpenBazaar_Auth_Cookie")
 			if err != nil {
 				w.WriteHeader(http.StatusForbidden)
@@ -120,6 +342,7 @@ func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			caller.principal = "cookie"
 		} else {
 			username, password, ok := r.BasicAuth()
 			h := sha256.Sum256([]byte(password))
@@ -129,11 +352,78 @@ func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			caller.principal = "user:" + username
 		}
 	}
-	c := &connection{send: make(chan []byte, 256), ws: ws, h: wsh.h}
+	if !caller.allowed(requiredScope("GET", "/ob/notifications")) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "403 - Forbidden")
+		return
+	}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Error("Error upgrading to websockets:", err)
+		return
+	}
+	c := &connection{send: make(chan []byte, 256), ws: ws, h: wsh.h, node: wsh.node, caller: caller, subscriptions: make(map[string]bool)}
 	c.h.register <- c
 	defer func() { c.h.unregister <- c }()
 	go c.writer()
 	c.reader()
 }
+
+type wsTopics struct {
+	Topics []string `json:"topics"`
+}
+
+func (c *connection) subscribe(params json.RawMessage) (interface{}, error) {
+	var t wsTopics
+	if err := json.Unmarshal(params, &t); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	for _, topic := range t.Topics {
+		if !validTopic(topic) {
+			return nil, invalidParamsError{fmt.Errorf("Unknown topic %q", topic)}
+		}
+	}
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+	for _, topic := range t.Topics {
+		c.subscriptions[topic] = true
+	}
+	return c.topics(), nil
+}
+
+// Unsubscribing from every topic sends the client everything again
+func (c *connection) unsubscribe(params json.RawMessage) (interface{}, error) {
+	var t wsTopics
+	if err := json.Unmarshal(params, &t); err != nil {
+		return nil, invalidParamsError{err}
+	}
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+	for _, topic := range t.Topics {
+		delete(c.subscriptions, topic)
+	}
+	return c.topics(), nil
+}
+
+// Must be called with the subscription lock held
+func (c *connection) topics() wsTopics {
+	t := wsTopics{Topics: []string{}}
+	for topic := range c.subscriptions {
+		t.Topics = append(t.Topics, topic)
+	}
+	sort.Strings(t.Topics)
+	return t
+}
+
+func validTopic(topic string) bool {
+	root := strings.SplitN(topic, "/", 2)[0]
+	for _, t := range notifications.Topics {
+		if root == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/core"
+	"github.com/OpenBazaar/openbazaar-go/repo/db"
+)
+
+func newWSTestNode(t *testing.T) (*core.OpenBazaarNode, func()) {
+	repoPath, err := ioutil.TempDir("", "ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	return &core.OpenBazaarNode{Datastore: database}, func() {
+		database.Close()
+		os.RemoveAll(repoPath)
+	}
+}
+
+func TestConnectionSubscribed(t *testing.T) {
+	c := &connection{subscriptions: map[string]bool{}}
+	if !c.subscribed("") || !c.subscribed("wallet") {
+		t.Error("A client without subscriptions should receive everything")
+	}
+	c.subscriptions["orders"] = true
+	tests := []struct {
+		topic      string
+		subscribed bool
+	}{
+		{"orders", true},
+		{"orders/QmOrder", true},
+		{"ordersfoo", false},
+		{"wallet", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if c.subscribed(test.topic) != test.subscribed {
+			t.Errorf("Expected subscribed(%q) to be %v", test.topic, test.subscribed)
+		}
+	}
+}
+
+func TestHandleRequestScopes(t *testing.T) {
+	node, cleanup := newWSTestNode(t)
+	defer cleanup()
+	c := &connection{node: node, caller: apiCaller{"token:reader", []string{ScopeReadOnly}}, subscriptions: map[string]bool{}}
+	tests := []struct {
+		request string
+		code    int
+	}{
+		{`{"id":1,"method":"subscribe","params":{"topics":["orders"]}}`, 0},
+		{`{"id":2,"method":"sendChat","params":{"peerId":"QmPeer","message":"hi"}}`, wsForbidden},
+		{`{"id":3,"method":"sendGroupChat","params":{"subject":"group","message":"hi"}}`, wsForbidden},
+		{`{"id":4,"method":"typing","params":{"peerId":"QmPeer"}}`, wsForbidden},
+		{`{"id":5,"method":"markChatAsRead","params":{"peerId":"QmPeer"}}`, wsForbidden},
+		{`{"id":6,"method":"unknown"}`, wsMethodNotFound},
+	}
+	for _, test := range tests {
+		resp := c.handleRequest([]byte(test.request))
+		code := 0
+		if resp.Error != nil {
+			code = resp.Error.Code
+		}
+		if code != test.code {
+			t.Errorf("%s: expected error code %d, got %d", test.request, test.code, code)
+		}
+	}
+
+	// An orders token may chat but not read the notifications
+	c.caller = apiCaller{"token:chat", []string{ScopeOrders}}
+	resp := c.handleRequest([]byte(`{"id":7,"method":"subscribe","params":{"topics":["orders"]}}`))
+	if resp.Error == nil || resp.Error.Code != wsForbidden {
+		t.Error("Subscribed without the read-only scope")
+	}
+	resp = c.handleRequest([]byte(`{"id":8,"method":"sendChat","params":{"peerId":"QmPeer"}}`))
+	if resp.Error == nil || resp.Error.Code != wsInvalidParams {
+		b, _ := json.Marshal(resp)
+		t.Errorf("Expected the chat to reach parameter checks, got %s", b)
+	}
+}
+
+func TestHandleRequestAudit(t *testing.T) {
+	node, cleanup := newWSTestNode(t)
+	defer cleanup()
+	c := &connection{node: node, caller: apiCaller{"token:reader", []string{ScopeReadOnly}}, subscriptions: map[string]bool{}}
+	c.handleRequest([]byte(`{"id":1,"method":"subscribe","params":{"topics":["orders"]}}`))
+	c.handleRequest([]byte(`{"id":2,"method":"markChatAsRead","params":{"peerId":"QmPeer"}}`))
+	c.caller = apiCaller{"token:chat", []string{ScopeOrders}}
+	c.handleRequest([]byte(`{"id":3,"method":"sendChat","params":{"peerId":"QmPeer"}}`))
+
+	// Only the chat methods are audited, newest first
+	entries, err := node.Datastore.AuditLog().Get(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 audit entries, got %d", len(entries))
+	}
+	tests := []struct {
+		path      string
+		principal string
+		status    int
+	}{
+		{"sendChat", "token:chat", 400},
+		{"markChatAsRead", "token:reader", 403},
+	}
+	for i, test := range tests {
+		e := entries[i]
+		if e.Method != "WS" || e.Path != test.path || e.Principal != test.principal || e.Status != test.status {
+			t.Errorf("Unexpected audit entry %s %s by %s with status %d", e.Method, e.Path, e.Principal, e.Status)
+		}
+	}
+	if entries[0].Request != `{"peerId":"QmPeer"}` {
+		t.Errorf("Expected the request params to be audited, got %s", entries[0].Request)
+	}
+}
@@ -211,6 +211,43 @@ func (w *BitcoindWallet) Balance() (confirmed, unconfirmed int64) {
 	return int64(c.ToUnit(btc.AmountSatoshi)), int64(u.ToUnit(btc.AmountSatoshi))
 }
 
+func (w *BitcoindWallet) ListUnspent() ([]spvwallet.Utxo, error) {
+	unspent, err := w.rpcClient.ListUnspent()
+	if err != nil {
+		return nil, err
+	}
+	height := w.ChainTip()
+	var ret []spvwallet.Utxo
+	for _, u := range unspent {
+		if !u.Spendable {
+			continue
+		}
+		h, err := chainhash.NewHashFromStr(u.TxID)
+		if err != nil {
+			continue
+		}
+		script, err := hex.DecodeString(u.ScriptPubKey)
+		if err != nil {
+			continue
+		}
+		amt, err := btc.NewAmount(u.Amount)
+		if err != nil {
+			continue
+		}
+		var atHeight int32
+		if u.Confirmations > 0 {
+			atHeight = int32(height) - int32(u.Confirmations) + 1
+		}
+		ret = append(ret, spvwallet.Utxo{
+			Op:           *wire.NewOutPoint(h, u.Vout),
+			AtHeight:     atHeight,
+			Value:        int64(amt.ToUnit(btc.AmountSatoshi)),
+			ScriptPubkey: script,
+		})
+	}
+	return ret, nil
+}
+
 func (w *BitcoindWallet) Transactions() ([]spvwallet.Txn, error) {
 	var ret []spvwallet.Txn
 	resp, err := w.rpcClient.ListTransactions(Account)
@@ -278,6 +315,57 @@ func (w *BitcoindWallet) Spend(amount int64, addr btc.Address, feeLevel spvwalle
 	return w.rpcClient.SendFrom(Account, addr, amt)
 }
 
+func (w *BitcoindWallet) SpendMany(outs []spvwallet.TransactionOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	if len(outs) == 0 {
+		return nil, errors.New("No outputs to spend to")
+	}
+	amounts := make(map[btc.Address]btc.Amount)
+	seen := make(map[string]bool)
+	for _, out := range outs {
+		addr, err := w.ScriptToAddress(out.ScriptPubKey)
+		if err != nil {
+			return nil, err
+		}
+		// Bitcoind pays each address once per transaction. Addresses are compared encoded as the
+		// map keys are pointers which differ even for the same address.
+		if seen[addr.EncodeAddress()] {
+			return nil, errors.New("Duplicate output address")
+		}
+		seen[addr.EncodeAddress()] = true
+		amt, err := btc.NewAmount(float64(out.Value) / 100000000)
+		if err != nil {
+			return nil, err
+		}
+		amounts[addr] = amt
+	}
+	return w.rpcClient.SendMany(Account, amounts)
+}
+
+// Lock the coins which may not be used while the transaction is built so bitcoind skips them
+func (w *BitcoindWallet) SpendCoins(outs []spvwallet.TransactionOutput, allow func(op wire.OutPoint) bool, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	if allow == nil {
+		return w.SpendMany(outs, feeLevel)
+	}
+	utxos, err := w.ListUnspent()
+	if err != nil {
+		return nil, err
+	}
+	var locked []*wire.OutPoint
+	for _, u := range utxos {
+		if !allow(u.Op) {
+			op := u.Op
+			locked = append(locked, &op)
+		}
+	}
+	if len(locked) > 0 {
+		if err := w.rpcClient.LockUnspent(false, locked); err != nil {
+			return nil, err
+		}
+		defer w.rpcClient.LockUnspent(true, locked)
+	}
+	return w.SpendMany(outs, feeLevel)
+}
+
 func (w *BitcoindWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
 	includeWatchOnly := false
 	tx, err := w.rpcClient.GetTransaction(&txid, &includeWatchOnly)
@@ -415,6 +503,11 @@ func (w *BitcoindWallet) CreateMultisigSignature(ins []spvwallet.TransactionInpu
 	return sigs, nil
 }
 
+func (w *BitcoindWallet) Broadcast(tx *wire.MsgTx) error {
+	_, err := w.rpcClient.SendRawTransaction(tx, false)
+	return err
+}
+
 func (w *BitcoindWallet) Multisign(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, sigs1 []spvwallet.Signature, sigs2 []spvwallet.Signature, redeemScript []byte, feePerByte uint64, broadcast bool) ([]byte, error) {
 	tx := wire.NewMsgTx(wire.TxVersion)
 	for _, in := range ins {
@@ -3,11 +3,15 @@ package exchange
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/OpenBazaar/openbazaar-go/repo"
 	"github.com/op/go-logging"
 	"golang.org/x/net/proxy"
+	"math"
 	"net"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -15,11 +19,25 @@ import (
 
 const SatoshiPerBTC = 100000000
 
+// Rates older than this are not returned unless the fetcher is given a different maximum age
+const DefaultMaxRateAge = time.Hour
+
+// How often the rates are saved to the exchange rate history
+const SnapshotInterval = time.Hour
+
+// A provider's rate is discarded if it differs from the median of all providers by more than this fraction
+const OutlierTolerance = 0.05
+
+/* A rate is only updated when at least this many providers agree on it. A single provider could
+   be stale and of two providers which disagree there is no telling which one is right. */
+const MinAgreeingProviders = 2
+
 var log = logging.MustGetLogger("exchangeRates")
 
+var CurrencyNotTrackedError = errors.New("Currency not tracked")
+
 type ExchangeRateProvider struct {
 	fetchUrl string
-	cache    map[string]float64
 	client   *http.Client
 	decoder  ExchangeRateDecoder
 }
@@ -36,13 +54,26 @@ type BitcoinChartsDecoder struct{}
 
 type BitcoinPriceFetcher struct {
 	sync.Mutex
-	cache     map[string]float64
-	providers []*ExchangeRateProvider
+	cache        map[string]float64
+	updated      map[string]time.Time
+	maxAge       time.Duration
+	providers    []*ExchangeRateProvider
+	history      repo.ExchangeRateHistory
+	lastSnapshot time.Time
 }
 
-func NewBitcoinPriceFetcher(dialer proxy.Dialer) *BitcoinPriceFetcher {
+/* NewBitcoinPriceFetcher returns a fetcher which refreshes its rates from all providers every 15 minutes.
+   A rate which hasn't been refreshed within maxAge is treated as unavailable. If a history is
+   given the rates are saved to it every SnapshotInterval. */
+func NewBitcoinPriceFetcher(dialer proxy.Dialer, maxAge time.Duration, history repo.ExchangeRateHistory) *BitcoinPriceFetcher {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxRateAge
+	}
 	b := BitcoinPriceFetcher{
-		cache: make(map[string]float64),
+		cache:   make(map[string]float64),
+		updated: make(map[string]time.Time),
+		maxAge:  maxAge,
+		history: history,
 	}
 	dial := net.Dial
 	if dialer != nil {
@@ -52,10 +83,10 @@ func NewBitcoinPriceFetcher(dialer proxy.Dialer) *BitcoinPriceFetcher {
 	client := &http.Client{Transport: tbTransport, Timeout: time.Minute}
 
 	b.providers = []*ExchangeRateProvider{
-		{"https://ticker.openbazaar.org/api", b.cache, client, BitcoinAverageDecoder{}},
-		{"https://bitpay.com/api/rates", b.cache, client, BitPayDecoder{}},
-		{"https://blockchain.info/ticker", b.cache, client, BlockchainInfoDecoder{}},
-		{"https://api.bitcoincharts.com/v1/weighted_prices.json", b.cache, client, BitcoinChartsDecoder{}},
+		{"https://ticker.openbazaar.org/api", client, BitcoinAverageDecoder{}},
+		{"https://bitpay.com/api/rates", client, BitPayDecoder{}},
+		{"https://blockchain.info/ticker", client, BlockchainInfoDecoder{}},
+		{"https://api.bitcoincharts.com/v1/weighted_prices.json", client, BitcoinChartsDecoder{}},
 	}
 	go b.run()
 	return &b
@@ -66,63 +97,154 @@ func (b *BitcoinPriceFetcher) GetExchangeRate(currencyCode string) (float64, err
 	defer b.Unlock()
 	price, ok := b.cache[currencyCode]
 	if !ok {
-		return 0, errors.New("Currency not tracked")
+		return 0, CurrencyNotTrackedError
+	}
+	if age := time.Since(b.updated[currencyCode]); age > b.maxAge {
+		return 0, fmt.Errorf("Exchange rate for %s is %s old", currencyCode, age-age%time.Second)
 	}
 	return price, nil
 }
 
 func (b *BitcoinPriceFetcher) GetLatestRate(currencyCode string) (float64, error) {
 	b.fetchCurrentRates()
+	return b.GetExchangeRate(currencyCode)
+}
+
+// GetAllRates returns the rates which are not older than the maximum age
+func (b *BitcoinPriceFetcher) GetAllRates() (map[string]float64, error) {
 	b.Lock()
 	defer b.Unlock()
-	price, ok := b.cache[currencyCode]
-	if !ok {
-		return 0, errors.New("Currency not tracked")
+	rates := make(map[string]float64)
+	for currencyCode, price := range b.cache {
+		if time.Since(b.updated[currencyCode]) <= b.maxAge {
+			rates[currencyCode] = price
+		}
 	}
-	return price, nil
+	return rates, nil
 }
 
-func (b *BitcoinPriceFetcher) GetAllRates() (map[string]float64, error) {
+// GetRateTimestamp returns the time the rate for the currency was last updated
+func (b *BitcoinPriceFetcher) GetRateTimestamp(currencyCode string) (time.Time, error) {
 	b.Lock()
 	defer b.Unlock()
-	return b.cache, nil
+	updated, ok := b.updated[currencyCode]
+	if !ok {
+		return time.Time{}, CurrencyNotTrackedError
+	}
+	return updated, nil
 }
 
 func (b *BitcoinPriceFetcher) UnitsPerCoin() int {
 	return SatoshiPerBTC
 }
 
+/* Query all the providers at once and set each rate to the median of the providers which returned it.
+   A currency whose providers disagree too much to settle on a rate keeps its previous rate until it
+   goes stale. */
 func (b *BitcoinPriceFetcher) fetchCurrentRates() error {
+	results := make([]map[string]float64, len(b.providers))
+	var wg sync.WaitGroup
+	for i, provider := range b.providers {
+		wg.Add(1)
+		go func(i int, provider *ExchangeRateProvider) {
+			defer wg.Done()
+			rates, err := provider.fetch()
+			if err == nil {
+				results[i] = rates
+			}
+		}(i, provider)
+	}
+	wg.Wait()
+
+	quotes := make(map[string][]float64)
+	for _, rates := range results {
+		for currencyCode, price := range rates {
+			quotes[currencyCode] = append(quotes[currencyCode], price)
+		}
+	}
+	if len(quotes) == 0 {
+		log.Error("Failed to fetch bitcoin exchange rates")
+		return errors.New("All exchange rate API queries failed")
+	}
+
+	now := time.Now()
 	b.Lock()
 	defer b.Unlock()
-	for _, provider := range b.providers {
-		err := provider.fetch()
-		if err == nil {
-			return nil
+	for currencyCode, prices := range quotes {
+		price, err := medianRate(prices, OutlierTolerance, MinAgreeingProviders)
+		if err != nil {
+			log.Warningf("Not updating %s exchange rate: %s", currencyCode, err)
+			continue
 		}
+		b.cache[currencyCode] = price
+		b.updated[currencyCode] = now
 	}
-	log.Error("Failed to fetch bitcoin exchange rates")
-	return errors.New("All exchange rate API queries failed")
+	if b.history != nil && now.Sub(b.lastSnapshot) >= SnapshotInterval {
+		snapshot := make(map[string]float64)
+		for currencyCode, updated := range b.updated {
+			if updated.Equal(now) {
+				snapshot[currencyCode] = b.cache[currencyCode]
+			}
+		}
+		if err := b.history.Put(snapshot, now); err != nil {
+			log.Error("Failed to save exchange rate snapshot:", err)
+		} else {
+			b.lastSnapshot = now
+		}
+	}
+	return nil
 }
 
-func (provider *ExchangeRateProvider) fetch() (err error) {
+/* The median of the prices after discarding those further than the tolerance from the median of
+   them all. Errors if fewer than minAgreeing prices are left. */
+func medianRate(prices []float64, tolerance float64, minAgreeing int) (float64, error) {
+	m := median(prices)
+	var accepted []float64
+	for _, price := range prices {
+		if math.Abs(price-m) <= m*tolerance {
+			accepted = append(accepted, price)
+		}
+	}
+	if len(accepted) < minAgreeing {
+		return 0, fmt.Errorf("Not enough providers agree on the rate %v", prices)
+	}
+	return median(accepted), nil
+}
+
+func median(prices []float64) float64 {
+	sorted := make([]float64, len(prices))
+	copy(sorted, prices)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func (provider *ExchangeRateProvider) fetch() (map[string]float64, error) {
 	if len(provider.fetchUrl) == 0 {
-		err = errors.New("Provider has no fetchUrl")
-		return err
+		return nil, errors.New("Provider has no fetchUrl")
 	}
 	resp, err := provider.client.Get(provider.fetchUrl)
 	if err != nil {
 		log.Error("Failed to fetch from "+provider.fetchUrl, err)
-		return err
+		return nil, err
 	}
+	defer resp.Body.Close()
 	decoder := json.NewDecoder(resp.Body)
 	var dataMap interface{}
 	err = decoder.Decode(&dataMap)
 	if err != nil {
 		log.Error("Failed to decode JSON from "+provider.fetchUrl, err)
-		return err
+		return nil, err
+	}
+	rates := make(map[string]float64)
+	if err := provider.decoder.decode(dataMap, rates); err != nil {
+		log.Error("Failed to decode rates from "+provider.fetchUrl, err)
+		return nil, err
 	}
-	return provider.decoder.decode(dataMap, provider.cache)
+	return rates, nil
 }
 
 func (b *BitcoinPriceFetcher) run() {
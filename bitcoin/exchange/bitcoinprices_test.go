@@ -6,20 +6,23 @@ import (
 	"io"
 	gonet "net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
 
 func setupBitcoinPriceFetcher() (b BitcoinPriceFetcher) {
 	b = BitcoinPriceFetcher{
-		cache: make(map[string]float64),
+		cache:   make(map[string]float64),
+		updated: make(map[string]time.Time),
+		maxAge:  DefaultMaxRateAge,
 	}
 	client := &http.Client{Transport: &http.Transport{Dial: gonet.Dial}, Timeout: time.Minute}
 	b.providers = []*ExchangeRateProvider{
-		{"https://ticker.openbazaar.org/api", b.cache, client, BitcoinAverageDecoder{}},
-		{"https://bitpay.com/api/rates", b.cache, client, BitPayDecoder{}},
-		{"https://blockchain.info/ticker", b.cache, client, BlockchainInfoDecoder{}},
-		{"https://api.bitcoincharts.com/v1/weighted_prices.json", b.cache, client, BitcoinChartsDecoder{}},
+		{"https://ticker.openbazaar.org/api", client, BitcoinAverageDecoder{}},
+		{"https://bitpay.com/api/rates", client, BitPayDecoder{}},
+		{"https://blockchain.info/ticker", client, BlockchainInfoDecoder{}},
+		{"https://api.bitcoincharts.com/v1/weighted_prices.json", client, BitcoinChartsDecoder{}},
 	}
 	return b
 }
@@ -39,6 +42,7 @@ func TestGetLatestRate(t *testing.T) {
 		t.Error("Incorrect return at GetLatestRate (price, err)", price, err)
 	}
 	b.cache["USD"] = 650.00
+	b.updated["USD"] = time.Now()
 	price, ok := b.cache["USD"]
 	if !ok || price != 650 {
 		t.Error("Failed to fetch exchange rates from cache")
@@ -53,6 +57,8 @@ func TestGetAllRates(t *testing.T) {
 	b := setupBitcoinPriceFetcher()
 	b.cache["USD"] = 650.00
 	b.cache["EUR"] = 600.00
+	b.updated["USD"] = time.Now()
+	b.updated["EUR"] = time.Now()
 	priceMap, err := b.GetAllRates()
 	if err != nil {
 		t.Error(err)
@@ -70,6 +76,7 @@ func TestGetAllRates(t *testing.T) {
 func TestGetExchangeRate(t *testing.T) {
 	b := setupBitcoinPriceFetcher()
 	b.cache["usd"] = 650.00
+	b.updated["usd"] = time.Now()
 	r, err := b.GetExchangeRate("usd")
 	if err != nil {
 		t.Error("Failed to fetch exchange rate")
@@ -83,6 +90,167 @@ func TestGetExchangeRate(t *testing.T) {
 	}
 }
 
+func TestGetExchangeRateStale(t *testing.T) {
+	b := setupBitcoinPriceFetcher()
+	b.cache["USD"] = 650.00
+	b.updated["USD"] = time.Now().Add(-DefaultMaxRateAge - time.Minute)
+	b.cache["EUR"] = 600.00
+	b.updated["EUR"] = time.Now()
+	if _, err := b.GetExchangeRate("USD"); err == nil {
+		t.Error("Returned a stale exchange rate")
+	}
+	rates, err := b.GetAllRates()
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := rates["USD"]; ok {
+		t.Error("Returned a stale exchange rate")
+	}
+	if rates["EUR"] != 600.00 {
+		t.Error("Failed to return fresh exchange rate")
+	}
+}
+
+func TestMedianRate(t *testing.T) {
+	tests := []struct {
+		prices   []float64
+		expected float64
+		valid    bool
+	}{
+		// A single provider may be stale so it isn't trusted alone
+		{[]float64{600}, 0, false},
+		// Two providers which agree are both used
+		{[]float64{600, 620}, 610, true},
+		{[]float64{600, 610, 620}, 610, true},
+		{[]float64{600, 610, 620, 630}, 615, true},
+		// A single provider far off the others is ignored
+		{[]float64{600, 610, 620, 1000}, 610, true},
+		{[]float64{300, 600, 610, 620}, 610, true},
+		// Two providers which disagree can't be settled
+		{[]float64{600, 1000}, 0, false},
+		// Nor can three which all disagree
+		{[]float64{600, 800, 1000}, 0, false},
+	}
+	for _, test := range tests {
+		price, err := medianRate(test.prices, OutlierTolerance, MinAgreeingProviders)
+		if test.valid && err != nil {
+			t.Errorf("Failed to find the rate of %v: %s", test.prices, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Found a rate for %v", test.prices)
+		}
+		if price != test.expected {
+			t.Errorf("Rate of %v is %f, expected %f", test.prices, price, test.expected)
+		}
+	}
+}
+
+func TestFetchCurrentRatesMedian(t *testing.T) {
+	responses := []string{
+		`[{"code":"USD","name":"US Dollar","rate":600},{"code":"EUR","name":"Eurozone Euro","rate":540}]`,
+		`[{"code":"USD","name":"US Dollar","rate":610},{"code":"EUR","name":"Eurozone Euro","rate":1000}]`,
+		`[{"code":"USD","name":"US Dollar","rate":620},{"code":"EUR","name":"Eurozone Euro","rate":550}]`,
+		`not json`,
+	}
+	b := setupBitcoinPriceFetcher()
+	b.providers = nil
+	for _, response := range responses {
+		server := httptest.NewServer(http.HandlerFunc(func(response string) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(response))
+			}
+		}(response)))
+		defer server.Close()
+		b.providers = append(b.providers, &ExchangeRateProvider{server.URL, http.DefaultClient, BitPayDecoder{}})
+	}
+	if err := b.fetchCurrentRates(); err != nil {
+		t.Error(err)
+	}
+	usd, err := b.GetExchangeRate("USD")
+	if err != nil || usd != 610 {
+		t.Error("Incorrect USD rate", usd, err)
+	}
+	eur, err := b.GetExchangeRate("EUR")
+	if err != nil || eur != 545 {
+		t.Error("Incorrect EUR rate", eur, err)
+	}
+	if updated, err := b.GetRateTimestamp("USD"); err != nil || time.Since(updated) > time.Minute {
+		t.Error("Rate timestamp not recorded")
+	}
+}
+
+func TestFetchCurrentRatesAgreement(t *testing.T) {
+	responses := []string{
+		`[{"code":"USD","name":"US Dollar","rate":600},{"code":"EUR","name":"Eurozone Euro","rate":540}]`,
+		`[{"code":"EUR","name":"Eurozone Euro","rate":1000}]`,
+	}
+	b := setupBitcoinPriceFetcher()
+	b.providers = nil
+	for i := range responses {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(responses[i]))
+		}))
+		defer server.Close()
+		b.providers = append(b.providers, &ExchangeRateProvider{server.URL, http.DefaultClient, BitPayDecoder{}})
+	}
+	updated := time.Now().Add(-time.Minute)
+	b.cache["USD"], b.updated["USD"] = 500, updated
+	b.cache["EUR"], b.updated["EUR"] = 530, updated
+
+	// USD is quoted by a single provider and the two EUR quotes disagree so both keep their rates
+	b.fetchCurrentRates()
+	if b.cache["USD"] != 500 || !b.updated["USD"].Equal(updated) {
+		t.Error("Rate quoted by a single provider was used", b.cache["USD"])
+	}
+	if b.cache["EUR"] != 530 || !b.updated["EUR"].Equal(updated) {
+		t.Error("Rate two providers disagree on was used", b.cache["EUR"])
+	}
+
+	// Two providers which agree update the rate
+	responses[1] = `[{"code":"USD","name":"US Dollar","rate":610},{"code":"EUR","name":"Eurozone Euro","rate":550}]`
+	b.fetchCurrentRates()
+	if b.cache["USD"] != 605 || b.cache["EUR"] != 545 {
+		t.Error("Rates two providers agree on were not used", b.cache)
+	}
+}
+
+type mockRateHistory struct {
+	snapshots []map[string]float64
+}
+
+func (m *mockRateHistory) Put(rates map[string]float64, timestamp time.Time) error {
+	m.snapshots = append(m.snapshots, rates)
+	return nil
+}
+
+func (m *mockRateHistory) GetAt(currencyCode string, timestamp time.Time) (float64, time.Time, error) {
+	return 0, time.Time{}, nil
+}
+
+func TestFetchCurrentRatesSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"code":"USD","name":"US Dollar","rate":600}]`))
+	}))
+	defer server.Close()
+	history := new(mockRateHistory)
+	b := setupBitcoinPriceFetcher()
+	b.history = history
+	b.providers = []*ExchangeRateProvider{{server.URL, http.DefaultClient, BitPayDecoder{}}, {server.URL, http.DefaultClient, BitPayDecoder{}}}
+	b.fetchCurrentRates()
+	b.fetchCurrentRates()
+	if len(history.snapshots) != 1 {
+		t.Fatalf("Saved %d snapshots, expected 1", len(history.snapshots))
+	}
+	if history.snapshots[0]["USD"] != 600 {
+		t.Error("Incorrect rate saved in snapshot")
+	}
+	b.lastSnapshot = time.Now().Add(-SnapshotInterval)
+	b.fetchCurrentRates()
+	if len(history.snapshots) != 2 {
+		t.Errorf("Saved %d snapshots, expected 2", len(history.snapshots))
+	}
+}
+
 type req struct {
 	io.Reader
 }
@@ -123,6 +123,10 @@ func (l *TransactionListener) OnTransactionReceived(cb spvwallet.TransactionCall
 				}
 				l.db.Purchases().Put(orderId, *contract, pb.OrderState_RESOLVED, false)
 			}
+			// The vendor claimed the escrow after the timeout instead of waiting for us to complete the order
+			if state == pb.OrderState_FULFILLED && contract.BuyerOrderCompletion == nil && contract.BuyerOrder.Payment.EscrowTimeoutHours > 0 && fundsReleased {
+				l.db.Purchases().Put(orderId, *contract, pb.OrderState_PAYMENT_FINALIZED, false)
+			}
 		}
 	}
 
@@ -194,7 +198,7 @@ func (l *TransactionListener) processSalePayment(txid []byte, output spvwallet.T
 	if contract.BuyerOrder.Payment.Method != pb.Order_Payment_MODERATED {
 		bumpable = true
 	}
-	l.db.TxMetadata().Put(repo.Metadata{chainHash.String(), "", title, orderId, thumbnail, bumpable})
+	l.db.TxMetadata().Put(repo.Metadata{chainHash.String(), "", title, orderId, thumbnail, bumpable, nil})
 }
 
 func (l *TransactionListener) processPurchasePayment(txid []byte, output spvwallet.TransactionOutput, contract *pb.RicardianContract, state pb.OrderState, funded bool, records []*spvwallet.TransactionRecord) {
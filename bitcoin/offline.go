@@ -0,0 +1,409 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+/* The public half of a wallet run by a watch-only node. It is created on the machine holding the
+   mnemonic and holds no private keys. The signature of the node's peer ID, which would otherwise
+   need the master private key every time the node builds its ID, is added once the offline wallet
+   has signed the node's peer ID signing request. */
+type WatchOnlyKey struct {
+	MasterPublicKey  string `json:"masterPublicKey"`
+	AccountPublicKey string `json:"accountPublicKey"`
+	PeerIDSignature  string `json:"peerIDSignature,omitempty"`
+}
+
+// Create the watch-only key of the wallet with the given master private key
+func NewWatchOnlyKey(masterKey *hd.ExtendedKey) (*WatchOnlyKey, error) {
+	masterPub, err := masterKey.Neuter()
+	if err != nil {
+		return nil, err
+	}
+	account, err := spvwallet.Bip44AccountKey(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	accountPub, err := account.Neuter()
+	if err != nil {
+		return nil, err
+	}
+	return &WatchOnlyKey{
+		MasterPublicKey:  masterPub.String(),
+		AccountPublicKey: accountPub.String(),
+	}, nil
+}
+
+// Parse the master and account public keys
+func (k *WatchOnlyKey) Keys() (master, account *hd.ExtendedKey, err error) {
+	master, err = hd.NewKeyFromString(k.MasterPublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	account, err = hd.NewKeyFromString(k.AccountPublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if master.IsPrivate() || account.IsPrivate() {
+		return nil, nil, errors.New("Watch-only keys must be public keys")
+	}
+	return master, account, nil
+}
+
+// A transaction built by a watch-only node which has to be signed by the offline wallet
+type UnsignedTransaction struct {
+	ID string `json:"id"`
+
+	// Describes what the transaction does, for example a spend or an escrow release
+	Type string `json:"type"`
+
+	// The hex encoded unsigned transaction
+	Transaction string `json:"transaction"`
+
+	Inputs []UnsignedInput `json:"inputs"`
+
+	/* The inputs are multisig coins which also need the other party's signature so only our
+	   signatures are returned. Otherwise the signer returns the signed transaction. */
+	SignaturesOnly bool `json:"signaturesOnly"`
+
+	// Set instead of the transaction when the master key has to sign a message, such as our peer ID
+	Message string `json:"message,omitempty"`
+}
+
+// The type of the request to sign a watch-only node's peer ID
+const PeerIDSigningType = "peerid"
+
+// Ask the offline wallet to sign our peer ID. Its ID is the hash of the peer ID.
+func NewPeerIDSigningRequest(peerID string) *UnsignedTransaction {
+	h := sha256.Sum256([]byte(peerID))
+	return &UnsignedTransaction{
+		ID:      hex.EncodeToString(h[:]),
+		Type:    PeerIDSigningType,
+		Message: peerID,
+	}
+}
+
+// Check the signature of the peer ID against the master public key
+func VerifyPeerIDSignature(peerID string, sig []byte, masterPubKey *hd.ExtendedKey) error {
+	ecPubKey, err := masterPubKey.ECPubKey()
+	if err != nil {
+		return err
+	}
+	signature, err := btcec.ParseSignature(sig, btcec.S256())
+	if err != nil {
+		return err
+	}
+	if !signature.Verify([]byte(peerID), ecPubKey) {
+		return errors.New("Invalid peer ID signature")
+	}
+	return nil
+}
+
+// Package a transaction for signing. Its ID is the hash of the unsigned transaction.
+func NewUnsignedTransaction(txType string, tx *wire.MsgTx, inputs []UnsignedInput, signaturesOnly bool) (*UnsignedTransaction, error) {
+	var buf bytes.Buffer
+	if err := tx.BtcEncode(&buf, wire.ProtocolVersion); err != nil {
+		return nil, err
+	}
+	return &UnsignedTransaction{
+		ID:             tx.TxHash().String(),
+		Type:           txType,
+		Transaction:    hex.EncodeToString(buf.Bytes()),
+		Inputs:         inputs,
+		SignaturesOnly: signaturesOnly,
+	}, nil
+}
+
+/* How the offline wallet derives the key for an input. Coins in our wallet are found by their BIP44
+   key path. Coins held in an order's escrow or payment address use the key derived from the master
+   key and the order's chaincode. */
+type UnsignedInput struct {
+	Value        int64  `json:"value"`
+	ScriptPubKey string `json:"scriptPubKey,omitempty"`
+	RedeemScript string `json:"redeemScript,omitempty"`
+	Purpose      int    `json:"purpose"`
+	Index        int    `json:"index"`
+	Chaincode    string `json:"chaincode,omitempty"`
+}
+
+type SignedTransaction struct {
+	ID string `json:"id"`
+
+	// The hex encoded signed transaction
+	Transaction string `json:"transaction,omitempty"`
+
+	Signatures []InputSignature `json:"signatures,omitempty"`
+
+	// The hex encoded signature of the message
+	Signature string `json:"signature,omitempty"`
+}
+
+type InputSignature struct {
+	InputIndex uint32 `json:"inputIndex"`
+	Signature  string `json:"signature"`
+}
+
+/* What an exported package would have the offline wallet sign. It must be shown to the user
+   before signing as the node which exported it may be compromised. */
+type SigningSummary struct {
+	Type string
+
+	// The peer ID of a peer ID signing request
+	Message string
+
+	// The total value of the inputs as reported by the node, the outputs and the fee they leave
+	InputValue int64
+	Outputs    []SummaryOutput
+	Fee        int64
+}
+
+type SummaryOutput struct {
+	Address string
+	Value   int64
+}
+
+/* Decode the package into what would be signed. Errors if it is malformed, if the input values
+   don't cover the outputs or if a peer ID signing request is for something other than a peer ID. */
+func (utx *UnsignedTransaction) Summary(params *chaincfg.Params) (*SigningSummary, error) {
+	summary := &SigningSummary{Type: utx.Type}
+	if utx.Type == PeerIDSigningType {
+		if !isPeerID(utx.Message) {
+			return nil, errors.New("Signing request is not for a peer ID")
+		}
+		summary.Message = utx.Message
+		return summary, nil
+	}
+	tx, err := utx.Tx()
+	if err != nil {
+		return nil, err
+	}
+	if len(utx.Inputs) != len(tx.TxIn) {
+		return nil, errors.New("Transaction inputs do not match the signing data")
+	}
+	for _, in := range utx.Inputs {
+		if in.Value <= 0 {
+			return nil, errors.New("Every input must have a value")
+		}
+		summary.InputValue += in.Value
+	}
+	var outputValue int64
+	for _, out := range tx.TxOut {
+		address := "unknown script " + hex.EncodeToString(out.PkScript)
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, params)
+		if err == nil && len(addrs) == 1 {
+			address = addrs[0].EncodeAddress()
+		}
+		summary.Outputs = append(summary.Outputs, SummaryOutput{address, out.Value})
+		outputValue += out.Value
+	}
+	summary.Fee = summary.InputValue - outputValue
+	if summary.Fee < 0 {
+		return nil, errors.New("Inputs do not cover the outputs")
+	}
+	return summary, nil
+}
+
+func (s *SigningSummary) String() string {
+	if s.Type == PeerIDSigningType {
+		return fmt.Sprintf("Sign peer ID %s\n", s.Message)
+	}
+	ret := fmt.Sprintf("Type: %s\nInputs: %d satoshis\n", s.Type, s.InputValue)
+	for _, out := range s.Outputs {
+		ret += fmt.Sprintf("Pay %d satoshis to %s\n", out.Value, out.Address)
+	}
+	ret += fmt.Sprintf("Fee: %d satoshis\n", s.Fee)
+	return ret
+}
+
+// A peer ID is the base58 encoded sha256 multihash of the identity key
+func isPeerID(s string) bool {
+	b := base58.Decode(s)
+	return len(b) == 34 && b[0] == 0x12 && b[1] == 0x20
+}
+
+/* Sign the transaction with the master private key of the wallet which created the watch-only key.
+   The package is checked the same way as for its summary first. */
+func SignTransaction(utx *UnsignedTransaction, masterKey *hd.ExtendedKey, params *chaincfg.Params) (*SignedTransaction, error) {
+	if _, err := utx.Summary(params); err != nil {
+		return nil, err
+	}
+	if utx.Type == PeerIDSigningType {
+		ecPrivKey, err := masterKey.ECPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := ecPrivKey.Sign([]byte(utx.Message))
+		if err != nil {
+			return nil, err
+		}
+		return &SignedTransaction{ID: utx.ID, Signature: hex.EncodeToString(sig.Serialize())}, nil
+	}
+	tx, err := utx.Tx()
+	if err != nil {
+		return nil, err
+	}
+	signed := &SignedTransaction{ID: utx.ID}
+	for i, txIn := range tx.TxIn {
+		in := utx.Inputs[i]
+		key, err := inputKey(in, masterKey, params)
+		if err != nil {
+			return nil, err
+		}
+		privKey, err := key.ECPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		redeemScript, err := hex.DecodeString(in.RedeemScript)
+		if err != nil {
+			return nil, err
+		}
+		if utx.SignaturesOnly {
+			sig, err := txscript.RawTxInSignature(tx, i, redeemScript, txscript.SigHashAll, privKey)
+			if err != nil {
+				return nil, err
+			}
+			signed.Signatures = append(signed.Signatures, InputSignature{uint32(i), hex.EncodeToString(sig)})
+			continue
+		}
+		prevScript, err := hex.DecodeString(in.ScriptPubKey)
+		if err != nil {
+			return nil, err
+		}
+		getKey := txscript.KeyClosure(func(addr btc.Address) (*btcec.PrivateKey, bool, error) {
+			return privKey, true, nil
+		})
+		getScript := txscript.ScriptClosure(func(addr btc.Address) ([]byte, error) {
+			return redeemScript, nil
+		})
+		script, err := txscript.SignTxOutput(params, tx, i, prevScript, txscript.SigHashAll, getKey, getScript, txIn.SignatureScript)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to sign input %d: %s", i, err)
+		}
+		txIn.SignatureScript = script
+	}
+	if !utx.SignaturesOnly {
+		var buf bytes.Buffer
+		if err := tx.BtcEncode(&buf, wire.ProtocolVersion); err != nil {
+			return nil, err
+		}
+		signed.Transaction = hex.EncodeToString(buf.Bytes())
+	}
+	return signed, nil
+}
+
+/* Check the signed transaction spends exactly what was exported and that every input verifies.
+   Returns the transaction to broadcast, or nil if only signatures were requested. */
+func VerifySignedTransaction(utx *UnsignedTransaction, signed *SignedTransaction) (*wire.MsgTx, error) {
+	unsigned, err := utx.Tx()
+	if err != nil {
+		return nil, err
+	}
+	if utx.SignaturesOnly {
+		if len(signed.Signatures) != len(unsigned.TxIn) {
+			return nil, errors.New("A signature is required for every input")
+		}
+		for _, sig := range signed.Signatures {
+			if int(sig.InputIndex) >= len(unsigned.TxIn) {
+				return nil, errors.New("Signature for an unknown input")
+			}
+		}
+		return nil, nil
+	}
+	tx, err := decodeTx(signed.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	stripped := tx.Copy()
+	for _, txIn := range stripped.TxIn {
+		txIn.SignatureScript = nil
+	}
+	if stripped.TxHash() != unsigned.TxHash() {
+		return nil, errors.New("Signed transaction does not match the unsigned transaction")
+	}
+	for i, in := range utx.Inputs {
+		prevScript, err := hex.DecodeString(in.ScriptPubKey)
+		if err != nil {
+			return nil, err
+		}
+		engine, err := txscript.NewEngine(prevScript, tx, i, txscript.StandardVerifyFlags, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := engine.Execute(); err != nil {
+			return nil, fmt.Errorf("Input %d is not signed correctly: %s", i, err)
+		}
+	}
+	return tx, nil
+}
+
+// Decode the unsigned transaction
+func (utx *UnsignedTransaction) Tx() (*wire.MsgTx, error) {
+	return decodeTx(utx.Transaction)
+}
+
+func decodeTx(s string) (*wire.MsgTx, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.BtcDecode(bytes.NewReader(b), wire.ProtocolVersion); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func inputKey(in UnsignedInput, masterKey *hd.ExtendedKey, params *chaincfg.Params) (*hd.ExtendedKey, error) {
+	if in.Chaincode != "" {
+		chaincode, err := hex.DecodeString(in.Chaincode)
+		if err != nil {
+			return nil, err
+		}
+		return EscrowKey(masterKey, chaincode, params)
+	}
+	internal, external, err := spvwallet.Bip44Derivation(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	switch spvwallet.KeyPurpose(in.Purpose) {
+	case spvwallet.EXTERNAL:
+		return external.Child(uint32(in.Index))
+	case spvwallet.INTERNAL:
+		return internal.Child(uint32(in.Index))
+	}
+	return nil, errors.New("Unknown key purpose")
+}
+
+/* Derive our key for an order's escrow or payment address from the master key and the order's
+   chaincode. A public master key gives the public escrow key. */
+func EscrowKey(masterKey *hd.ExtendedKey, chaincode []byte, params *chaincfg.Params) (*hd.ExtendedKey, error) {
+	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
+	var hdKey *hd.ExtendedKey
+	if masterKey.IsPrivate() {
+		mECKey, err := masterKey.ECPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		hdKey = hd.NewExtendedKey(params.HDPrivateKeyID[:], mECKey.Serialize(), chaincode, parentFP, 0, 0, true)
+	} else {
+		mECKey, err := masterKey.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		hdKey = hd.NewExtendedKey(params.HDPublicKeyID[:], mECKey.SerializeCompressed(), chaincode, parentFP, 0, 0, false)
+	}
+	return hdKey.Child(0)
+}
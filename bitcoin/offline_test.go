@@ -0,0 +1,280 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func newOfflineTestKey(t *testing.T) *hd.ExtendedKey {
+	seed := make([]byte, 32)
+	seed[0] = 0x42
+	key, err := hd.NewMaster(seed, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func encodeTestTx(t *testing.T, tx *wire.MsgTx) string {
+	var buf bytes.Buffer
+	if err := tx.BtcEncode(&buf, wire.ProtocolVersion); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(buf.Bytes())
+}
+
+func TestWatchOnlyKey(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	master := newOfflineTestKey(t)
+	wk, err := NewWatchOnlyKey(master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masterPub, accountPub, err := wk.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Addresses derived by the watch-only node must match the offline wallet
+	_, external, err := spvwallet.Bip44Derivation(master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privChild, err := external.Child(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubExternal, err := accountPub.Child(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubChild, err := pubExternal.Child(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a1, _ := privChild.Address(params)
+	a2, _ := pubChild.Address(params)
+	if a1.String() != a2.String() {
+		t.Error("Watch-only address does not match the wallet's address")
+	}
+
+	// Likewise for the escrow keys
+	chaincode := make([]byte, 32)
+	chaincode[0] = 0x07
+	privEscrow, err := EscrowKey(master, chaincode, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubEscrow, err := EscrowKey(masterPub, chaincode, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a1, _ = privEscrow.Address(params)
+	a2, _ = pubEscrow.Address(params)
+	if a1.String() != a2.String() {
+		t.Error("Watch-only escrow key does not match the wallet's escrow key")
+	}
+
+	if wk.PeerIDSignature != "" {
+		t.Error("Watch-only key should not carry a signature")
+	}
+}
+
+const testPeerID = "QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr"
+
+func TestPeerIDSigningRequest(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	master := newOfflineTestKey(t)
+	masterPub, err := master.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	utx := NewPeerIDSigningRequest(testPeerID)
+	signed, err := SignTransaction(utx, master, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.ID != utx.ID {
+		t.Error("Signed request has the wrong ID")
+	}
+	sig, err := hex.DecodeString(signed.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyPeerIDSignature(testPeerID, sig, masterPub); err != nil {
+		t.Error(err)
+	}
+	if err := VerifyPeerIDSignature("QmOther", sig, masterPub); err == nil {
+		t.Error("Signature verified for another peer ID")
+	}
+
+	// The master key must not sign anything which isn't a peer ID, such as a transaction hash
+	utx.Message = string(bytes.Repeat([]byte{0x01}, 32))
+	if _, err := SignTransaction(utx, master, params); err == nil {
+		t.Error("Signed a message which is not a peer ID")
+	}
+}
+
+func TestSignTransaction(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	master := newOfflineTestKey(t)
+
+	// A wallet coin at m/44'/0'/0'/1/3
+	internal, _, err := spvwallet.Bip44Derivation(master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletKey, err := internal.Child(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletAddr, err := walletKey.Address(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletScript, err := txscript.PayToAddrScript(walletAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A coin held by a 1 of 1 multisig payment address using the escrow key
+	chaincode := make([]byte, 32)
+	chaincode[0] = 0x09
+	escrowKey, err := EscrowKey(master, chaincode, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	escrowPub, err := escrowKey.ECPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubAddr, err := btc.NewAddressPubKey(escrowPub.SerializeCompressed(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redeemScript, err := txscript.MultiSigScript([]*btc.AddressPubKey{pubAddr}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	escrowAddr, err := btc.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	escrowScript, err := txscript.PayToAddrScript(escrowAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x01}, 0), nil))
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x02}, 1), nil))
+	tx.AddTxOut(wire.NewTxOut(150000, walletScript))
+	utx := &UnsignedTransaction{
+		ID:          tx.TxHash().String(),
+		Type:        "spend",
+		Transaction: encodeTestTx(t, tx),
+		Inputs: []UnsignedInput{
+			{Value: 100000, ScriptPubKey: hex.EncodeToString(walletScript), Purpose: int(spvwallet.INTERNAL), Index: 3},
+			{Value: 60000, ScriptPubKey: hex.EncodeToString(escrowScript), RedeemScript: hex.EncodeToString(redeemScript), Chaincode: hex.EncodeToString(chaincode)},
+		},
+	}
+	signed, err := SignTransaction(utx, master, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.ID != utx.ID {
+		t.Error("Signed transaction has the wrong ID")
+	}
+	if _, err := VerifySignedTransaction(utx, signed); err != nil {
+		t.Error(err)
+	}
+
+	// Signed by a different wallet
+	seed := make([]byte, 32)
+	other, err := hd.NewMaster(seed, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err = SignTransaction(utx, other, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifySignedTransaction(utx, signed); err == nil {
+		t.Error("Transaction signed with the wrong key verified")
+	}
+
+	// Only signatures for a multisig payout
+	utx.SignaturesOnly = true
+	utx.Inputs = []UnsignedInput{
+		{Value: 100000, RedeemScript: hex.EncodeToString(redeemScript), Chaincode: hex.EncodeToString(chaincode)},
+		{Value: 60000, RedeemScript: hex.EncodeToString(redeemScript), Chaincode: hex.EncodeToString(chaincode)},
+	}
+	signed, err = SignTransaction(utx, master, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed.Signatures) != 2 || signed.Transaction != "" {
+		t.Error("Expected a signature for each input and no transaction")
+	}
+	if _, err := VerifySignedTransaction(utx, signed); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSigningSummary(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	master := newOfflineTestKey(t)
+	_, external, err := spvwallet.Bip44Derivation(master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := external.Child(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := key.Address(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x01}, 0), nil))
+	tx.AddTxOut(wire.NewTxOut(90000, script))
+	utx := &UnsignedTransaction{
+		ID:          tx.TxHash().String(),
+		Type:        "spend",
+		Transaction: encodeTestTx(t, tx),
+		Inputs:      []UnsignedInput{{Value: 100000, ScriptPubKey: hex.EncodeToString(script), Purpose: int(spvwallet.EXTERNAL)}},
+	}
+	summary, err := utx.Summary(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.InputValue != 100000 || summary.Fee != 10000 || len(summary.Outputs) != 1 {
+		t.Errorf("Incorrect summary %+v", summary)
+	}
+	if summary.Outputs[0].Address != addr.EncodeAddress() || summary.Outputs[0].Value != 90000 {
+		t.Errorf("Incorrect summary output %+v", summary.Outputs[0])
+	}
+
+	// Input values which don't cover the outputs would hide part of the payment from the signer
+	utx.Inputs[0].Value = 80000
+	if _, err := utx.Summary(params); err == nil {
+		t.Error("Summarized a package whose inputs don't cover its outputs")
+	}
+	if _, err := SignTransaction(utx, master, params); err == nil {
+		t.Error("Signed a package whose inputs don't cover its outputs")
+	}
+}
@@ -2,28 +2,25 @@ package bitcoin
 
 import (
 	"context"
-	"encoding/json"
 	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
 )
 
 type StatusUpdater struct {
-	w   BitcoinWallet
-	c   chan interface{}
-	ctx context.Context
+	w         BitcoinWallet
+	c         chan interface{}
+	ctx       context.Context
+	listeners []func(confirmed, unconfirmed int64)
 }
 
-type walletUpdateWrapper struct {
-	WalletUpdate walletUpdate `json:"walletUpdate"`
+func NewStatusUpdater(w BitcoinWallet, c chan interface{}, ctx context.Context) *StatusUpdater {
+	return &StatusUpdater{w: w, c: c, ctx: ctx}
 }
 
-type walletUpdate struct {
-	Height      uint32 `json:"height"`
-	Unconfirmed int64  `json:"unconfirmed"`
-	Confirmed   int64  `json:"confirmed"`
-}
-
-func NewStatusUpdater(w BitcoinWallet, c chan interface{}, ctx context.Context) *StatusUpdater {
-	return &StatusUpdater{w, c, ctx}
+// Add a callback run with the wallet balance on every update. Must be called before Start.
+func (s *StatusUpdater) AddBalanceListener(cb func(confirmed, unconfirmed int64)) {
+	s.listeners = append(s.listeners, cb)
 }
 
 func (s *StatusUpdater) Start() {
@@ -32,16 +29,14 @@ func (s *StatusUpdater) Start() {
 		select {
 		case <-t.C:
 			confirmed, unconfirmed := s.w.Balance()
-			u := walletUpdate{
+			for _, cb := range s.listeners {
+				cb(confirmed, unconfirmed)
+			}
+			s.c <- notifications.WalletUpdate{
 				Height:      s.w.ChainTip(),
 				Unconfirmed: unconfirmed,
 				Confirmed:   confirmed,
 			}
-			ser, err := json.MarshalIndent(walletUpdateWrapper{u}, "", "    ")
-			if err != nil {
-				continue
-			}
-			s.c <- ser
 		case <-s.ctx.Done():
 			break
 		}
@@ -0,0 +1,151 @@
+package bitcoin
+
+import (
+	"errors"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/btcsuite/btcutil/txsort"
+)
+
+// Blocks are mined on average every ten minutes
+const blocksPerHour = 6
+
+// The longest escrow timeout which fits in a relative lock time measured in blocks
+const MaxEscrowTimeoutHours = 0xffff / blocksPerHour
+
+// Number of confirmations the escrowed coins need before the timeout path can spend them
+func EscrowTimeoutBlocks(timeoutHours uint32) uint32 {
+	return timeoutHours * blocksPerHour
+}
+
+/* Generate a multisig script which can also be spent by the timeout key alone once the coins
+   have been confirmed for the given number of hours. The relative lock time runs from the block
+   which confirms the coins, that is from payment, and the script knows nothing else about the
+   order. Whether it was fulfilled or disputed can only be checked by the timeout key's owner.
+
+       OP_DEPTH OP_1 OP_EQUAL
+       OP_IF
+           <blocks> OP_CHECKSEQUENCEVERIFY OP_DROP <timeoutKey> OP_CHECKSIG
+       OP_ELSE
+           <threshold> <keys...> <len(keys)> OP_CHECKMULTISIG
+       OP_ENDIF
+
+   The multisig branch is selected by the signature script `OP_0 <sig> <sig>` used for plain
+   multisig scripts so cooperative payouts can still be signed with the wallet's Multisign. */
+func GenerateTimelockedMultisigScript(keys []hd.ExtendedKey, threshold int, timeoutKey hd.ExtendedKey, timeoutHours uint32, params *chaincfg.Params) (addr btc.Address, redeemScript []byte, err error) {
+	if timeoutHours == 0 || timeoutHours > MaxEscrowTimeoutHours {
+		return nil, nil, errors.New("Escrow timeout is out of range")
+	}
+	if threshold < 1 || threshold > len(keys) {
+		return nil, nil, errors.New("Invalid multisig threshold")
+	}
+	timeoutPubkey, err := timeoutKey.ECPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	builder := txscript.NewScriptBuilder()
+	builder.AddOp(txscript.OP_DEPTH)
+	builder.AddOp(txscript.OP_1)
+	builder.AddOp(txscript.OP_EQUAL)
+	builder.AddOp(txscript.OP_IF)
+	builder.AddInt64(int64(EscrowTimeoutBlocks(timeoutHours)))
+	builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+	builder.AddOp(txscript.OP_DROP)
+	builder.AddData(timeoutPubkey.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+	builder.AddOp(txscript.OP_ELSE)
+	builder.AddInt64(int64(threshold))
+	for _, key := range keys {
+		ecKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		builder.AddData(ecKey.SerializeCompressed())
+	}
+	builder.AddInt64(int64(len(keys)))
+	builder.AddOp(txscript.OP_CHECKMULTISIG)
+	builder.AddOp(txscript.OP_ENDIF)
+	redeemScript, err = builder.Script()
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err = btc.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, redeemScript, nil
+}
+
+/* Sweep coins held by a script from GenerateTimelockedMultisigScript to the given address using
+   the timeout path. The network rejects the transaction until every coin has enough confirmations. */
+func ReleaseTimelockedEscrow(w BitcoinWallet, utxos []spvwallet.Utxo, address btc.Address, key *hd.ExtendedKey, redeemScript []byte, timeoutHours uint32, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	tx, err := BuildTimelockedRelease(utxos, address, key, redeemScript, timeoutHours, w.GetFeePerByte(feeLevel))
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Broadcast(tx); err != nil {
+		return nil, err
+	}
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+// Build and sign the transaction used by ReleaseTimelockedEscrow
+func BuildTimelockedRelease(utxos []spvwallet.Utxo, address btc.Address, key *hd.ExtendedKey, redeemScript []byte, timeoutHours uint32, feePerByte uint64) (*wire.MsgTx, error) {
+	if len(utxos) == 0 {
+		return nil, errors.New("No escrowed coins to release")
+	}
+	script, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relative lock times are only enforced from version 2 transactions
+	tx := wire.NewMsgTx(2)
+	var val int64
+	for _, u := range utxos {
+		val += u.Value
+		in := wire.NewTxIn(wire.NewOutPoint(&u.Op.Hash, u.Op.Index), []byte{})
+		in.Sequence = EscrowTimeoutBlocks(timeoutHours)
+		tx.AddTxIn(in)
+	}
+	out := wire.NewTxOut(val, script)
+	tx.AddTxOut(out)
+
+	// Each signature script holds a signature of up to 73 bytes and the redeem script, plus their pushes
+	estimatedSize := tx.SerializeSize() + len(utxos)*(73+len(redeemScript)+5)
+	fee := int64(estimatedSize) * int64(feePerByte)
+	if val-fee <= 0 {
+		return nil, errors.New("Escrowed coins do not cover the transaction fee")
+	}
+	out.Value = val - fee
+
+	// BIP 69 sorting
+	txsort.InPlaceSort(tx)
+
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	for i, txIn := range tx.TxIn {
+		sig, err := txscript.RawTxInSignature(tx, i, redeemScript, txscript.SigHashAll, privKey)
+		if err != nil {
+			return nil, err
+		}
+		builder := txscript.NewScriptBuilder()
+		builder.AddData(sig)
+		builder.AddData(redeemScript)
+		scriptSig, err := builder.Script()
+		if err != nil {
+			return nil, err
+		}
+		txIn.SignatureScript = scriptSig
+	}
+	return tx, nil
+}
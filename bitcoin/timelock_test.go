@@ -0,0 +1,114 @@
+package bitcoin
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func newTimelockTestKeys(t *testing.T) (priv []*hd.ExtendedKey, pub []hd.ExtendedKey) {
+	for i := 0; i < 3; i++ {
+		seed := make([]byte, 32)
+		seed[0] = byte(i + 1)
+		key, err := hd.NewMaster(seed, &chaincfg.TestNet3Params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKey, err := key.Neuter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv = append(priv, key)
+		pub = append(pub, *pubKey)
+	}
+	return priv, pub
+}
+
+func executeTimelockScript(tx *wire.MsgTx, prevScript []byte) error {
+	engine, err := txscript.NewEngine(prevScript, tx, 0, txscript.StandardVerifyFlags, nil)
+	if err != nil {
+		return err
+	}
+	return engine.Execute()
+}
+
+func TestTimelockedMultisigScript(t *testing.T) {
+	priv, pub := newTimelockTestKeys(t)
+	addr, redeemScript, err := GenerateTimelockedMultisigScript(pub, 2, pub[1], 24, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utxos := []spvwallet.Utxo{{
+		Op:           *wire.NewOutPoint(&chainhash.Hash{0x01}, 0),
+		Value:        100000,
+		ScriptPubkey: prevScript,
+	}}
+
+	// Timeout path
+	tx, err := BuildTimelockedRelease(utxos, addr, priv[1], redeemScript, 24, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.TxIn[0].Sequence != 144 {
+		t.Errorf("Expected a relative lock time of 144 blocks, got %d", tx.TxIn[0].Sequence)
+	}
+	if err := executeTimelockScript(tx, prevScript); err != nil {
+		t.Errorf("Timeout release failed to verify: %s", err)
+	}
+	tx.TxIn[0].Sequence = 143
+	if err := executeTimelockScript(tx, prevScript); err == nil {
+		t.Error("Timeout release verified before the lock time")
+	}
+	tx, err = BuildTimelockedRelease(utxos, addr, priv[0], redeemScript, 24, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := executeTimelockScript(tx, prevScript); err == nil {
+		t.Error("Timeout release verified with the wrong key")
+	}
+
+	// Multisig path using the signature script built by the wallet's Multisign
+	tx = wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&utxos[0].Op, []byte{}))
+	tx.AddTxOut(wire.NewTxOut(90000, prevScript))
+	builder := txscript.NewScriptBuilder()
+	builder.AddOp(txscript.OP_0)
+	for _, key := range priv[:2] {
+		ecKey, err := key.ECPrivKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := txscript.RawTxInSignature(tx, 0, redeemScript, txscript.SigHashAll, ecKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		builder.AddData(sig)
+	}
+	builder.AddData(redeemScript)
+	tx.TxIn[0].SignatureScript, err = builder.Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := executeTimelockScript(tx, prevScript); err != nil {
+		t.Errorf("Multisig spend failed to verify: %s", err)
+	}
+}
+
+func TestTimelockedMultisigScriptTimeoutRange(t *testing.T) {
+	_, pub := newTimelockTestKeys(t)
+	if _, _, err := GenerateTimelockedMultisigScript(pub, 2, pub[1], 0, &chaincfg.TestNet3Params); err == nil {
+		t.Error("Generated a timelocked script without a timeout")
+	}
+	if _, _, err := GenerateTimelockedMultisigScript(pub, 2, pub[1], MaxEscrowTimeoutHours+1, &chaincfg.TestNet3Params); err == nil {
+		t.Error("Generated a timelocked script with a timeout that doesn't fit in a relative lock time")
+	}
+}
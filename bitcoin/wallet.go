@@ -4,6 +4,7 @@ import (
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	btc "github.com/btcsuite/btcutil"
 	hd "github.com/btcsuite/btcutil/hdkeychain"
 )
@@ -49,6 +50,9 @@ type BitcoinWallet interface {
 	// Get the confirmed and unconfirmed balances
 	Balance() (confirmed, unconfirmed int64)
 
+	// Returns the coins this wallet can spend
+	ListUnspent() ([]spvwallet.Utxo, error)
+
 	// Returns a list of transactions for this wallet
 	Transactions() ([]spvwallet.Txn, error)
 
@@ -64,6 +68,12 @@ type BitcoinWallet interface {
 	// Send bitcoins to an external wallet
 	Spend(amount int64, addr btc.Address, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error)
 
+	// Send bitcoins to several outputs in a single transaction
+	SpendMany(outs []spvwallet.TransactionOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error)
+
+	// Send bitcoins to several outputs using only the coins accepted by allow
+	SpendCoins(outs []spvwallet.TransactionOutput, allow func(op wire.OutPoint) bool, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error)
+
 	// Bump the fee for the given transaction
 	BumpFee(txid chainhash.Hash) (*chainhash.Hash, error)
 
@@ -76,6 +86,9 @@ type BitcoinWallet interface {
 	// Create a signature for a multisig transaction
 	CreateMultisigSignature(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, key *hd.ExtendedKey, redeemScript []byte, feePerByte uint64) ([]spvwallet.Signature, error)
 
+	// Broadcast a signed transaction to the network
+	Broadcast(tx *wire.MsgTx) error
+
 	// Combine signatures and optionally broadcast
 	Multisign(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, sigs1 []spvwallet.Signature, sigs2 []spvwallet.Signature, redeemScript []byte, feePerByte uint64, broadcast bool) ([]byte, error)
 
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+/* Place a bid on an auction listing. The bid is signed with our identity key and sent
+   directly to the vendor who must be online to accept it. Once the auction ends the
+   holder of the highest bid can purchase the item at the bid price using the normal
+   order flow. The amount is denominated in the pricing currency of the listing. */
+func (n *OpenBazaarNode) PlaceBid(listingHash string, amount uint64) (*pb.SignedBid, error) {
+	b, err := ipfs.Cat(n.Context, listingHash)
+	if err != nil {
+		return nil, err
+	}
+	sl := new(pb.SignedListing)
+	err = jsonpb.UnmarshalString(string(b), sl)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVersionNumber(sl.Listing); err != nil {
+		return nil, err
+	}
+	if err := validateVendorID(sl.Listing); err != nil {
+		return nil, err
+	}
+	if err := validateListing(sl.Listing); err != nil {
+		return nil, fmt.Errorf("Listing failed to validate, reason: %q", err.Error())
+	}
+	if err := verifySignaturesOnListing(sl); err != nil {
+		return nil, err
+	}
+	if sl.Listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+		return nil, errors.New("Listing is not an auction")
+	}
+	if sl.Listing.VendorID.PeerID == n.IpfsNode.Identity.Pretty() {
+		return nil, errors.New("Cannot bid on our own listing")
+	}
+	if auctionHasEnded(sl.Listing) {
+		return nil, errors.New("Auction has ended")
+	}
+	if amount < sl.Listing.Item.Price {
+		return nil, fmt.Errorf("Bid must be at least the starting price of %d", sl.Listing.Item.Price)
+	}
+
+	ser, err := proto.Marshal(sl.Listing)
+	if err != nil {
+		return nil, err
+	}
+	listingMH, err := EncodeMultihash(ser)
+	if err != nil {
+		return nil, err
+	}
+	id, err := n.createSignedID()
+	if err != nil {
+		return nil, err
+	}
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	bid := &pb.Bid{
+		ListingHash: listingMH.B58String(),
+		VendorID:    sl.Listing.VendorID.PeerID,
+		Slug:        sl.Listing.Slug,
+		BuyerID:     id,
+		Amount:      amount,
+		Timestamp:   ts,
+	}
+	sb, err := n.SignBid(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor, err := peer.IDB58Decode(bid.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	if !n.PeerSupports(vendor, FeatureAuctions) {
+		return nil, errors.New("Vendor's node is too old to accept bids")
+	}
+	resp, err := n.SendBid(bid.VendorID, sb)
+	if err != nil {
+		return nil, errors.New("Vendor must be online to accept bids")
+	}
+	if resp.MessageType == pb.Message_ERROR {
+		return nil, fmt.Errorf("Vendor rejected bid, reason: %s", string(resp.Payload.Value))
+	}
+	if resp.MessageType != pb.Message_BID {
+		return nil, errors.New("Vendor responded to the bid with an incorrect message type")
+	}
+	err = n.Datastore.Bids().Put(sb)
+	if err != nil {
+		return nil, err
+	}
+	return sb, nil
+}
+
+func (n *OpenBazaarNode) SignBid(bid *pb.Bid) (*pb.SignedBid, error) {
+	ser, err := proto.Marshal(bid)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := n.IpfsNode.PrivateKey.Sign(ser)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignedBid{Bid: bid, Signature: sig}, nil
+}
+
+// Validate a bid received on one of our auction listings
+func (n *OpenBazaarNode) ValidateBid(sb *pb.SignedBid) error {
+	if sb.Bid == nil {
+		return errors.New("Bid is empty")
+	}
+	if sb.Bid.BuyerID == nil || sb.Bid.BuyerID.Pubkeys == nil {
+		return errors.New("Bid doesn't contain a buyer ID")
+	}
+	if sb.Bid.Timestamp == nil {
+		return errors.New("Bid is missing a timestamp")
+	}
+	if err := verifySignaturesOnBid(sb); err != nil {
+		return err
+	}
+	if sb.Bid.VendorID != n.IpfsNode.Identity.Pretty() {
+		return errors.New("Bid is not for one of our listings")
+	}
+	sl, err := n.GetListingFromSlug(sb.Bid.Slug)
+	if err != nil {
+		return errors.New("Listing not found")
+	}
+	listing := sl.Listing
+	if listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+		return errors.New("Listing is not an auction")
+	}
+	if auctionHasEnded(listing) {
+		return errors.New("Auction has ended")
+	}
+
+	// Inventory is not part of the listing the buyer saw so it must be cleared before hashing
+	for _, sku := range listing.Item.Skus {
+		sku.Quantity = 0
+	}
+	ser, err := proto.Marshal(listing)
+	if err != nil {
+		return err
+	}
+	listingMH, err := EncodeMultihash(ser)
+	if err != nil {
+		return err
+	}
+	if sb.Bid.ListingHash != listingMH.B58String() {
+		return errors.New("Bid was placed on an outdated version of the listing")
+	}
+
+	if sb.Bid.Amount < listing.Item.Price {
+		return fmt.Errorf("Bid must be at least the starting price of %d", listing.Item.Price)
+	}
+	highest, err := n.Datastore.Bids().GetHighest(sb.Bid.VendorID, sb.Bid.Slug)
+	if err == nil {
+		minimum := highest.Bid.Amount + listing.Auction.BidIncrement
+		if listing.Auction.BidIncrement == 0 {
+			minimum++
+		}
+		if sb.Bid.Amount < minimum {
+			return fmt.Errorf("Bid must be at least %d", minimum)
+		}
+	}
+	return nil
+}
+
+/* Check the bid attached to an auction item in an order is the winning bid recorded
+   in our database and was placed by the buyer. */
+func (n *OpenBazaarNode) validateWinningBid(order *pb.Order, item *pb.Order_Item, listing *pb.Listing) error {
+	if item.Bid == nil || item.Bid.Bid == nil {
+		return errors.New("Auction items must include the winning bid")
+	}
+	if !auctionHasEnded(listing) {
+		return errors.New("Auction has not yet ended")
+	}
+	if item.Quantity != 1 {
+		return errors.New("Auction items can only be purchased with a quantity of one")
+	}
+	if item.Bid.Bid.BuyerID == nil || item.Bid.Bid.BuyerID.Pubkeys == nil {
+		return errors.New("Bid doesn't contain a buyer ID")
+	}
+	if err := verifySignaturesOnBid(item.Bid); err != nil {
+		return err
+	}
+	if item.Bid.Bid.BuyerID.PeerID != order.BuyerID.PeerID {
+		return errors.New("Bid was not placed by the buyer")
+	}
+	if item.Bid.Bid.VendorID != listing.VendorID.PeerID || item.Bid.Bid.Slug != listing.Slug {
+		return errors.New("Bid is not for this listing")
+	}
+	if item.Bid.Bid.Amount < listing.Auction.ReservePrice {
+		return errors.New("Winning bid did not meet the reserve price")
+	}
+	highest, err := n.Datastore.Bids().GetHighest(listing.VendorID.PeerID, listing.Slug)
+	if err != nil {
+		return errors.New("No bids were placed on this auction")
+	}
+	if !bytes.Equal(highest.Signature, item.Bid.Signature) {
+		return errors.New("Bid is not the winning bid")
+	}
+	return nil
+}
+
+// Return the highest of our bids on the given auction if the auction is over
+func (n *OpenBazaarNode) getWinningBid(listing *pb.Listing) (*pb.SignedBid, error) {
+	if !auctionHasEnded(listing) {
+		return nil, errors.New("Auction has not yet ended")
+	}
+	bid, err := n.Datastore.Bids().GetHighest(listing.VendorID.PeerID, listing.Slug)
+	if err != nil {
+		return nil, errors.New("We have not placed a bid on this auction")
+	}
+	if bid.Bid.Amount < listing.Auction.ReservePrice {
+		return nil, errors.New("Our bid did not meet the reserve price")
+	}
+	return bid, nil
+}
+
+func auctionHasEnded(listing *pb.Listing) bool {
+	if listing.Auction == nil || listing.Auction.EndTime == nil {
+		return true
+	}
+	return !time.Now().Before(time.Unix(listing.Auction.EndTime.Seconds, 0))
+}
+
+func verifySignaturesOnBid(sb *pb.SignedBid) error {
+	if err := verifySignature(
+		sb.Bid,
+		sb.Bid.BuyerID.Pubkeys.Identity,
+		sb.Signature,
+		sb.Bid.BuyerID.PeerID,
+	); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Buyer's identity signature on bid failed to verify")
+		case matchKeyError:
+			return errors.New("Public key in bid does not match reported buyer ID")
+		default:
+			return err
+		}
+	}
+
+	if err := verifyBitcoinSignature(
+		sb.Bid.BuyerID.Pubkeys.Bitcoin,
+		sb.Bid.BuyerID.BitcoinSig,
+		sb.Bid.BuyerID.PeerID,
+	); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Buyer's bitcoin signature on GUID failed to verify")
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"golang.org/x/net/context"
+)
+
+// Principal recorded for spends the node makes on its own behalf
+const walletPrincipal = "wallet"
+
+type principalKey struct{}
+
+// Attach the principal, such as an API caller, on whose behalf spends are made
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+/* Wrap the wallet so every transaction it broadcasts is recorded in the audit log. This
+   catches spends made by the node itself, such as refunds and escrow releases, as well as
+   spends made through the API. */
+func NewAuditedWallet(w bitcoin.BitcoinWallet, auditLog repo.AuditLog) bitcoin.BitcoinWallet {
+	return &auditedWallet{w, auditLog, walletPrincipal}
+}
+
+type auditedWallet struct {
+	bitcoin.BitcoinWallet
+	auditLog  repo.AuditLog
+	principal string
+}
+
+/* Return the wallet to spend from on behalf of the principal in the context. Spends are
+   recorded in the audit log against that principal rather than the node itself. */
+func (n *OpenBazaarNode) WalletFor(ctx context.Context) bitcoin.BitcoinWallet {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	w, audited := n.Wallet.(*auditedWallet)
+	if !ok || !audited {
+		return n.Wallet
+	}
+	return &auditedWallet{w.BitcoinWallet, w.auditLog, principal}
+}
+
+func (w *auditedWallet) Spend(amount int64, addr btc.Address, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	txid, err := w.BitcoinWallet.Spend(amount, addr, feeLevel)
+	w.record("SPEND", fmt.Sprintf("amount=%d address=%s", amount, addr.String()), txid, err)
+	return txid, err
+}
+
+func (w *auditedWallet) SpendMany(outs []spvwallet.TransactionOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	txid, err := w.BitcoinWallet.SpendMany(outs, feeLevel)
+	var value int64
+	for _, out := range outs {
+		value += out.Value
+	}
+	w.record("SPEND", fmt.Sprintf("amount=%d outputs=%d", value, len(outs)), txid, err)
+	return txid, err
+}
+
+func (w *auditedWallet) SpendCoins(outs []spvwallet.TransactionOutput, allow func(op wire.OutPoint) bool, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	txid, err := w.BitcoinWallet.SpendCoins(outs, allow, feeLevel)
+	var value int64
+	for _, out := range outs {
+		value += out.Value
+	}
+	w.record("SPEND", fmt.Sprintf("amount=%d outputs=%d", value, len(outs)), txid, err)
+	return txid, err
+}
+
+func (w *auditedWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
+	newTxid, err := w.BitcoinWallet.BumpFee(txid)
+	w.record("BUMPFEE", "txid="+txid.String(), newTxid, err)
+	return newTxid, err
+}
+
+func (w *auditedWallet) SweepAddress(utxos []spvwallet.Utxo, address *btc.Address, key *hd.ExtendedKey, redeemScript *[]byte, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	txid, err := w.BitcoinWallet.SweepAddress(utxos, address, key, redeemScript, feeLevel)
+	to := "internal"
+	if address != nil {
+		to = (*address).String()
+	}
+	var value int64
+	for _, u := range utxos {
+		value += u.Value
+	}
+	w.record("SWEEP", fmt.Sprintf("amount=%d inputs=%d address=%s", value, len(utxos), to), txid, err)
+	return txid, err
+}
+
+func (w *auditedWallet) Broadcast(tx *wire.MsgTx) error {
+	err := w.BitcoinWallet.Broadcast(tx)
+	var value int64
+	for _, out := range tx.TxOut {
+		value += out.Value
+	}
+	txid := tx.TxHash()
+	w.record("BROADCAST", fmt.Sprintf("amount=%d inputs=%d outputs=%d", value, len(tx.TxIn), len(tx.TxOut)), &txid, err)
+	return err
+}
+
+func (w *auditedWallet) Multisign(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, sigs1 []spvwallet.Signature, sigs2 []spvwallet.Signature, redeemScript []byte, feePerByte uint64, broadcast bool) ([]byte, error) {
+	tx, err := w.BitcoinWallet.Multisign(ins, outs, sigs1, sigs2, redeemScript, feePerByte, broadcast)
+	if broadcast {
+		var value int64
+		for _, out := range outs {
+			value += out.Value
+		}
+		w.record("MULTISIGN", fmt.Sprintf("amount=%d inputs=%d outputs=%d", value, len(ins), len(outs)), nil, err)
+	}
+	return tx, err
+}
+
+func (w *auditedWallet) record(method, request string, txid *chainhash.Hash, err error) {
+	entry := repo.AuditEntry{
+		Timestamp: time.Now(),
+		Method:    method,
+		Path:      "wallet",
+		Principal: w.principal,
+		Request:   request,
+		Status:    200,
+	}
+	if err != nil {
+		entry.Status = 500
+		entry.Result = err.Error()
+	} else if txid != nil {
+		entry.Result = "txid=" + txid.String()
+	}
+	if err := w.auditLog.Put(entry); err != nil {
+		log.Errorf("Error writing wallet spend to the audit log: %s", err.Error())
+	}
+}
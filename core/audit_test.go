@@ -0,0 +1,47 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"golang.org/x/net/context"
+)
+
+type bumpFeeWallet struct {
+	bitcoin.BitcoinWallet
+}
+
+func (w *bumpFeeWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
+	return nil, errors.New("not found")
+}
+
+type memoryAuditLog struct {
+	repo.AuditLog
+	entries []repo.AuditEntry
+}
+
+func (l *memoryAuditLog) Put(entry repo.AuditEntry) error {
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+func TestWalletForRecordsPrincipal(t *testing.T) {
+	auditLog := new(memoryAuditLog)
+	n := &OpenBazaarNode{Wallet: NewAuditedWallet(new(bumpFeeWallet), auditLog)}
+
+	n.Wallet.BumpFee(chainhash.Hash{})
+	n.WalletFor(context.Background()).BumpFee(chainhash.Hash{})
+	n.WalletFor(WithPrincipal(context.Background(), "token:shop")).BumpFee(chainhash.Hash{})
+
+	if len(auditLog.entries) != 3 {
+		t.Fatalf("Expected 3 audit entries, got %d", len(auditLog.entries))
+	}
+	for i, principal := range []string{"wallet", "wallet", "token:shop"} {
+		if auditLog.entries[i].Principal != principal {
+			t.Errorf("Expected principal %s, got %s", principal, auditLog.entries[i].Principal)
+		}
+	}
+}
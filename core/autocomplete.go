@@ -0,0 +1,183 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+)
+
+const (
+	DefaultAutoCompleteDays = 30
+	DefaultReminderDays     = 7
+
+	// Stop reminding a buyer who hasn't completed the order after this many messages
+	maxCompletionReminders = 3
+)
+
+const completionReminder = "Your order has been fulfilled. Once you have received it please complete the order and leave a rating."
+
+// Check the order completion settings before they are saved
+func ValidateOrderCompletionSettings(s *repo.OrderCompletionSettings) error {
+	if s == nil {
+		return nil
+	}
+	if s.AutoCompleteDays < 0 || s.ReminderDays < 0 {
+		return errors.New("Order completion days cannot be negative")
+	}
+	if s.DefaultRating != 0 && (s.DefaultRating < RatingMin || s.DefaultRating > RatingMax) {
+		return errors.New("Default rating must be between 1 and 5, or 0 to leave no rating")
+	}
+	return nil
+}
+
+/* Complete our purchases which have been fulfilled for longer than the configured number of
+   days and remind the buyers of our fulfilled sales to complete them. Both are opt in through
+   the order completion settings. */
+func (n *OpenBazaarNode) ProcessFulfilledOrders() {
+	settings, err := n.Datastore.Settings().Get()
+	if err != nil || settings.OrderCompletion == nil {
+		return
+	}
+	conf := settings.OrderCompletion
+	if conf.AutoComplete {
+		days := conf.AutoCompleteDays
+		if days == 0 {
+			days = DefaultAutoCompleteDays
+		}
+		n.autoCompletePurchases(time.Duration(days)*24*time.Hour, conf.DefaultRating)
+	}
+	if conf.Reminders {
+		days := conf.ReminderDays
+		if days == 0 {
+			days = DefaultReminderDays
+		}
+		n.sendCompletionReminders(time.Duration(days) * 24 * time.Hour)
+	}
+}
+
+// Check for fulfilled orders once an hour
+func (n *OpenBazaarNode) RunOrderCompleter() {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	n.ProcessFulfilledOrders()
+	for range tick.C {
+		n.ProcessFulfilledOrders()
+	}
+}
+
+func (n *OpenBazaarNode) autoCompletePurchases(timeout time.Duration, rating int) {
+	purchases, _, err := n.Datastore.Purchases().GetAll([]pb.OrderState{pb.OrderState_FULFILLED}, "", false, false, -1, []string{})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, p := range purchases {
+		contract, state, _, records, _, err := n.Datastore.Purchases().GetByOrderId(p.OrderId)
+		if err != nil || state != pb.OrderState_FULFILLED {
+			continue
+		}
+		fulfilled, ok := fulfillmentTime(contract)
+		if !ok || time.Since(fulfilled) < timeout {
+			continue
+		}
+		orderRatings := &OrderRatings{OrderId: p.OrderId}
+		if rating > 0 {
+			orderRatings.Ratings = defaultRatings(contract, rating)
+		}
+		if err := n.CompleteOrder(context.Background(), orderRatings, contract, records); err != nil {
+			log.Errorf("Error automatically completing order %s: %s", p.OrderId, err.Error())
+			continue
+		}
+		log.Infof("Automatically completed order %s", p.OrderId)
+	}
+}
+
+/* Send the buyer a chat message in the order's conversation. Reminders are spaced by the
+   interval and the chat history is used to count them, so they survive a restart. */
+func (n *OpenBazaarNode) sendCompletionReminders(interval time.Duration) {
+	sales, _, err := n.Datastore.Sales().GetAll([]pb.OrderState{pb.OrderState_FULFILLED}, "", false, false, -1, []string{})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, s := range sales {
+		contract, _, _, _, _, err := n.Datastore.Sales().GetByOrderId(s.OrderId)
+		if err != nil || contract.BuyerOrder.BuyerID == nil {
+			continue
+		}
+		last, ok := fulfillmentTime(contract)
+		if !ok {
+			continue
+		}
+		buyer := contract.BuyerOrder.BuyerID.PeerID
+		sent := 0
+		for _, m := range n.Datastore.Chat().GetMessages(buyer, s.OrderId, "", -1) {
+			if m.Outgoing && m.Message == completionReminder {
+				sent++
+				if m.Timestamp.After(last) {
+					last = m.Timestamp
+				}
+			}
+		}
+		if sent >= maxCompletionReminders || time.Since(last) < interval {
+			continue
+		}
+		chatPb, t, err := NewChatMessage(s.OrderId, completionReminder)
+		if err != nil {
+			continue
+		}
+		if err := n.SendChat(buyer, chatPb); err != nil {
+			log.Errorf("Error sending completion reminder for order %s: %s", s.OrderId, err.Error())
+			continue
+		}
+		n.Datastore.Chat().Put(chatPb.MessageId, buyer, s.OrderId, completionReminder, t, false, true)
+	}
+}
+
+// Return the time of the vendor's last fulfillment of the order
+func fulfillmentTime(contract *pb.RicardianContract) (time.Time, bool) {
+	var latest time.Time
+	for _, f := range contract.VendorOrderFulfillment {
+		if f.Timestamp == nil {
+			continue
+		}
+		t, err := ptypes.Timestamp(f.Timestamp)
+		if err != nil {
+			continue
+		}
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
+// Rate every listing the vendor signed a rating for with the same anonymous score
+func defaultRatings(contract *pb.RicardianContract, rating int) []RatingData {
+	var ratings []RatingData
+	rated := make(map[string]bool)
+	for _, f := range contract.VendorOrderFulfillment {
+		if f.RatingSignature == nil || f.RatingSignature.Metadata == nil {
+			continue
+		}
+		slug := f.RatingSignature.Metadata.ListingSlug
+		if rated[slug] {
+			continue
+		}
+		rated[slug] = true
+		ratings = append(ratings, RatingData{
+			Slug:            slug,
+			Overall:         rating,
+			Quality:         rating,
+			Description:     rating,
+			DeliverySpeed:   rating,
+			CustomerService: rating,
+			Anonymous:       true,
+		})
+	}
+	return ratings
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestValidateOrderCompletionSettings(t *testing.T) {
+	tests := []struct {
+		settings *repo.OrderCompletionSettings
+		valid    bool
+	}{
+		{nil, true},
+		{&repo.OrderCompletionSettings{AutoComplete: true}, true},
+		{&repo.OrderCompletionSettings{AutoComplete: true, AutoCompleteDays: 14, DefaultRating: 3}, true},
+		{&repo.OrderCompletionSettings{AutoCompleteDays: -1}, false},
+		{&repo.OrderCompletionSettings{Reminders: true, ReminderDays: -7}, false},
+		{&repo.OrderCompletionSettings{DefaultRating: 6}, false},
+	}
+	for i, test := range tests {
+		err := ValidateOrderCompletionSettings(test.settings)
+		if test.valid && err != nil {
+			t.Errorf("Settings %d should be valid: %s", i, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Settings %d should be invalid", i)
+		}
+	}
+}
+
+func TestDefaultRatings(t *testing.T) {
+	first, _ := ptypes.TimestampProto(time.Now().Add(-48 * time.Hour))
+	second, _ := ptypes.TimestampProto(time.Now().Add(-24 * time.Hour))
+	ratingSig := func(slug string) *pb.RatingSignature {
+		return &pb.RatingSignature{Metadata: &pb.RatingSignature_TransactionMetadata{ListingSlug: slug}}
+	}
+	contract := &pb.RicardianContract{
+		VendorOrderFulfillment: []*pb.OrderFulfillment{
+			{Timestamp: second, RatingSignature: ratingSig("shirt")},
+			{Timestamp: first, RatingSignature: ratingSig("shirt")},
+			{Timestamp: first, RatingSignature: ratingSig("hat")},
+		},
+	}
+	fulfilled, ok := fulfillmentTime(contract)
+	if !ok || fulfilled.Unix() != second.Seconds {
+		t.Error("Fulfillment time should be the time of the last fulfillment")
+	}
+	ratings := defaultRatings(contract, 3)
+	if len(ratings) != 2 {
+		t.Fatalf("Expected one rating per listing, got %d", len(ratings))
+	}
+	for _, r := range ratings {
+		if r.Overall != 3 || r.CustomerService != 3 || !r.Anonymous {
+			t.Errorf("Rating for %s does not use the default rating", r.Slug)
+		}
+	}
+	if _, ok := fulfillmentTime(&pb.RicardianContract{}); ok {
+		t.Error("Unfulfilled order returned a fulfillment time")
+	}
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+/* Version of the OpenBazaar protocol spoken by this node. Peers which don't report a version
+   predate the handshake and are recorded as version zero with no features. */
+const ProtocolVersion = 1
+
+// Features which older peers may not understand
+const (
+	FeatureAuctions       = "auctions"
+	FeaturePartialRefunds = "partial-refunds"
+	FeatureOfflineRelay   = "offline-relay"
+)
+
+var Features = []string{
+	FeatureAuctions,
+	FeaturePartialRefunds,
+	FeatureOfflineRelay,
+}
+
+// How long a handshake is trusted before we ask the peer again
+const CapabilitiesRefreshInterval = time.Hour * 24
+
+// How long to wait for a peer to answer a handshake
+const HandshakeTimeout = time.Second * 10
+
+// How long to wait after a failed handshake before asking the peer again
+const HandshakeRetryInterval = time.Minute * 10
+
+// The capabilities we announce to other peers
+func (n *OpenBazaarNode) LocalCapabilities() *pb.Capabilities {
+	return &pb.Capabilities{
+		PeerID:          n.IpfsNode.Identity.Pretty(),
+		ProtocolVersion: ProtocolVersion,
+		Features:        Features,
+		UserAgent:       n.UserAgent,
+	}
+}
+
+/* Save what a peer announced. A nil announcement, or one carrying somebody else's ID (an older
+   node echoing our own PING), means the peer predates capability negotiation. */
+func (n *OpenBazaarNode) RecordCapabilities(p peer.ID, c *pb.Capabilities) (repo.PeerCapabilities, error) {
+	capabilities := repo.PeerCapabilities{
+		PeerID:   p.Pretty(),
+		Features: []string{},
+		Updated:  time.Now(),
+	}
+	if c != nil && c.PeerID == p.Pretty() {
+		capabilities.ProtocolVersion = int(c.ProtocolVersion)
+		capabilities.Features = c.Features
+		capabilities.UserAgent = c.UserAgent
+	}
+	if err := n.Datastore.Capabilities().Put(capabilities); err != nil {
+		return capabilities, err
+	}
+	return capabilities, nil
+}
+
+// Exchange capabilities with a peer. Errors if the peer can't be reached.
+func (n *OpenBazaarNode) Handshake(p peer.ID) (repo.PeerCapabilities, error) {
+	a, err := ptypes.MarshalAny(n.LocalCapabilities())
+	if err != nil {
+		return repo.PeerCapabilities{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), HandshakeTimeout)
+	defer cancel()
+	resp, err := n.Service.SendRequest(ctx, p, &pb.Message{MessageType: pb.Message_PING, Payload: a})
+	if err != nil {
+		return repo.PeerCapabilities{}, err
+	}
+	var c *pb.Capabilities
+	if resp.Payload != nil {
+		c = new(pb.Capabilities)
+		if err := ptypes.UnmarshalAny(resp.Payload, c); err != nil {
+			c = nil
+		}
+	}
+	return n.RecordCapabilities(p, c)
+}
+
+/* Can the peer handle the feature? A recent handshake is used as is, otherwise we ask the peer
+   again unless a handshake with it failed recently. If we have never been able to reach the peer
+   we assume it can, as we did before peers announced their capabilities. */
+func (n *OpenBazaarNode) PeerSupports(p peer.ID, feature string) bool {
+	capabilities, err := n.Datastore.Capabilities().Get(p.Pretty())
+	known := err == nil
+	if known && time.Since(capabilities.Updated) < CapabilitiesRefreshInterval {
+		return capabilities.Supports(feature)
+	}
+	if n.Service != nil && n.shouldHandshake(p) {
+		c, err := n.Handshake(p)
+		n.handshakeDone(p, err)
+		if err == nil {
+			return c.Supports(feature)
+		}
+	}
+	if known {
+		return capabilities.Supports(feature)
+	}
+	return true
+}
+
+// Has the retry interval passed since our last failed handshake with the peer?
+func (n *OpenBazaarNode) shouldHandshake(p peer.ID) bool {
+	n.handshakeLock.Lock()
+	defer n.handshakeLock.Unlock()
+	return time.Now().After(n.handshakeRetry[p])
+}
+
+// Remember a failed handshake so offline peers don't stall every caller
+func (n *OpenBazaarNode) handshakeDone(p peer.ID, err error) {
+	n.handshakeLock.Lock()
+	defer n.handshakeLock.Unlock()
+	if err == nil {
+		delete(n.handshakeRetry, p)
+		return
+	}
+	if n.handshakeRetry == nil {
+		n.handshakeRetry = make(map[peer.ID]time.Time)
+	}
+	n.handshakeRetry[p] = time.Now().Add(HandshakeRetryInterval)
+}
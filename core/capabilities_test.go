@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/net"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/openbazaar-go/repo/db"
+	"github.com/ipfs/go-ipfs/core"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+// A network service which can't reach anybody
+type offlineService struct {
+	net.NetworkService
+	requests int
+}
+
+func (s *offlineService) SendRequest(ctx context.Context, p peer.ID, pmes *pb.Message) (*pb.Message, error) {
+	s.requests++
+	return nil, errors.New("Peer is offline")
+}
+
+func TestCapabilities(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "capabilities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	n := &OpenBazaarNode{Datastore: database}
+
+	upgraded, _ := peer.IDB58Decode("QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr")
+	legacy, _ := peer.IDB58Decode("QmbwSMS35CaYKdrYBvvR9aHU9FzeWhjJ7E3jLKeR2DWrs3")
+	stale, _ := peer.IDB58Decode("QmNaYCcRVMHD2UAdh6XPYZELfg4ry7XZvJHu8o6b8dAgaq")
+	unknown, _ := peer.IDB58Decode("QmTUR3qNEpjJKrnbvBUTfq5rxQCbM4gHHd3rCjGkoNgBov")
+
+	c, err := n.RecordCapabilities(upgraded, &pb.Capabilities{PeerID: upgraded.Pretty(), ProtocolVersion: 1, Features: []string{FeatureAuctions}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ProtocolVersion != 1 || !c.Supports(FeatureAuctions) {
+		t.Error("Failed to record announced capabilities")
+	}
+	// An older node echoes our own PING back to us
+	c, err = n.RecordCapabilities(legacy, &pb.Capabilities{PeerID: upgraded.Pretty(), ProtocolVersion: 1, Features: Features})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ProtocolVersion != 0 || len(c.Features) != 0 {
+		t.Error("Recorded our own capabilities for a legacy peer")
+	}
+	err = database.Capabilities().Put(repo.PeerCapabilities{PeerID: stale.Pretty(), ProtocolVersion: 1, Features: []string{FeatureOfflineRelay}, Updated: time.Now().Add(-CapabilitiesRefreshInterval * 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		p        peer.ID
+		feature  string
+		supports bool
+	}{
+		{upgraded, FeatureAuctions, true},
+		{upgraded, FeaturePartialRefunds, false},
+		{legacy, FeatureAuctions, false},
+		{stale, FeatureOfflineRelay, true},
+		{stale, FeatureAuctions, false},
+		{unknown, FeatureAuctions, true},
+	}
+	for _, test := range tests {
+		if n.PeerSupports(test.p, test.feature) != test.supports {
+			t.Errorf("Peer %s support for %s should be %t", test.p.Pretty(), test.feature, test.supports)
+		}
+	}
+}
+
+func TestPeerSupportsOffline(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "capabilities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	self, _ := peer.IDB58Decode("QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr")
+	offline, _ := peer.IDB58Decode("QmTUR3qNEpjJKrnbvBUTfq5rxQCbM4gHHd3rCjGkoNgBov")
+	service := new(offlineService)
+	n := &OpenBazaarNode{Datastore: database, Service: service, IpfsNode: &core.IpfsNode{Identity: self}}
+
+	// Only the first call waits on the peer
+	for i := 0; i < 3; i++ {
+		if !n.PeerSupports(offline, FeatureAuctions) {
+			t.Error("Unreachable peer should be assumed to support the feature")
+		}
+	}
+	if service.requests != 1 {
+		t.Errorf("Expected a single handshake, got %d", service.requests)
+	}
+
+	// Once the retry interval passes we ask again
+	n.handshakeRetry[offline] = time.Now().Add(-time.Second)
+	n.PeerSupports(offline, FeatureAuctions)
+	if service.requests != 2 {
+		t.Errorf("Expected a second handshake after the retry interval, got %d", service.requests)
+	}
+}
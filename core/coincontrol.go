@@ -0,0 +1,155 @@
+package core
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+)
+
+type WalletCoin struct {
+	Outpoint      string `json:"outpoint"`
+	Value         int64  `json:"value"`
+	Address       string `json:"address"`
+	Confirmations uint32 `json:"confirmations"`
+	Frozen        bool   `json:"frozen"`
+	OrderId       string `json:"orderId"`
+}
+
+/* Wrap the wallet so coins frozen through the API are skipped whenever the wallet picks the
+   inputs itself. SpendCoins is passed through unchanged as its caller chose the coins. */
+func NewCoinControlWallet(w bitcoin.BitcoinWallet, frozen repo.FrozenCoins) bitcoin.BitcoinWallet {
+	return &coinControlWallet{w, frozen}
+}
+
+type coinControlWallet struct {
+	bitcoin.BitcoinWallet
+	frozen repo.FrozenCoins
+}
+
+func (w *coinControlWallet) Spend(amount int64, addr btc.Address, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	allow, err := w.unfrozen()
+	if err != nil {
+		return nil, err
+	}
+	if allow == nil {
+		return w.BitcoinWallet.Spend(amount, addr, feeLevel)
+	}
+	script, err := w.AddressToScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	return w.BitcoinWallet.SpendCoins([]spvwallet.TransactionOutput{{ScriptPubKey: script, Value: amount}}, allow, feeLevel)
+}
+
+func (w *coinControlWallet) SpendMany(outs []spvwallet.TransactionOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	allow, err := w.unfrozen()
+	if err != nil {
+		return nil, err
+	}
+	return w.BitcoinWallet.SpendCoins(outs, allow, feeLevel)
+}
+
+// Fee bumps spend the unconfirmed outputs of the transaction so they are refused if any are frozen
+func (w *coinControlWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
+	frozen, err := w.frozen.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range frozen {
+		op, err := ParseOutpoint(f)
+		if err == nil && op.Hash.IsEqual(&txid) {
+			return nil, errors.New("Transaction has frozen outputs")
+		}
+	}
+	return w.BitcoinWallet.BumpFee(txid)
+}
+
+// Return a filter rejecting frozen coins or nil if none are frozen
+func (w *coinControlWallet) unfrozen() (func(op wire.OutPoint) bool, error) {
+	frozen, err := w.frozen.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(frozen) == 0 {
+		return nil, nil
+	}
+	set := make(map[string]bool)
+	for _, f := range frozen {
+		set[f] = true
+	}
+	return func(op wire.OutPoint) bool {
+		return !set[op.String()]
+	}, nil
+}
+
+// Parse an outpoint in the txid:index form used by the API
+func ParseOutpoint(s string) (*wire.OutPoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || len(parts[0]) != chainhash.MaxHashStringSize {
+		return nil, errors.New("Outpoint must be in the form txid:index")
+	}
+	hash, err := chainhash.NewHashFromStr(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return wire.NewOutPoint(hash, uint32(index)), nil
+}
+
+/* Return the coins in the wallet. A coin is linked to an order if the transaction which
+   created it was recorded against one or it was paid to the payment address of a sale. */
+func (n *OpenBazaarNode) GetWalletCoins() ([]WalletCoin, error) {
+	utxos, err := n.Wallet.ListUnspent()
+	if err != nil {
+		return nil, err
+	}
+	frozen, err := n.Datastore.FrozenCoins().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	frozenSet := make(map[string]bool)
+	for _, f := range frozen {
+		frozenSet[f] = true
+	}
+	metadata, err := n.Datastore.TxMetadata().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	height := n.Wallet.ChainTip()
+	coins := []WalletCoin{}
+	for _, u := range utxos {
+		coin := WalletCoin{
+			Outpoint: u.Op.String(),
+			Value:    u.Value,
+			Frozen:   frozenSet[u.Op.String()],
+		}
+		if u.AtHeight > 0 && height >= uint32(u.AtHeight) {
+			coin.Confirmations = height - uint32(u.AtHeight) + 1
+		}
+		if m, ok := metadata[u.Op.Hash.String()]; ok {
+			coin.OrderId = m.OrderId
+		}
+		addr, err := n.Wallet.ScriptToAddress(u.ScriptPubkey)
+		if err == nil {
+			coin.Address = addr.EncodeAddress()
+			if coin.OrderId == "" {
+				contract, _, _, _, err := n.Datastore.Sales().GetByPaymentAddress(addr)
+				if err == nil && contract != nil {
+					coin.OrderId, _ = n.CalcOrderId(contract.BuyerOrder)
+				}
+			}
+		}
+		coins = append(coins, coin)
+	}
+	return coins, nil
+}
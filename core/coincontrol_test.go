@@ -0,0 +1,22 @@
+package core
+
+import "testing"
+
+func TestParseOutpoint(t *testing.T) {
+	txid := "a0ee0dda2d6f6a1ad9b6d3f1bd87c0bcbd5f6bb6be6d5b0c34e9e3a2f6e5b4c3"
+	op, err := ParseOutpoint(txid + ":2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Hash.String() != txid || op.Index != 2 {
+		t.Errorf("Parsed the wrong outpoint %s", op.String())
+	}
+	if op.String() != txid+":2" {
+		t.Errorf("Outpoint does not round trip, got %s", op.String())
+	}
+	for _, s := range []string{txid, txid + ":", txid + ":-1", "abc:0", txid + ":0:1"} {
+		if _, err := ParseOutpoint(s); err == nil {
+			t.Errorf("Parsed invalid outpoint %s", s)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// Wait this long after a failed sweep before trying again
+const coldStorageRetryInterval = 10 * time.Minute
+
+var ErrColdStorageNotConfigured = errors.New("Cold storage is not configured")
+
+// A sweep of the confirmed balance above the threshold to the next cold storage address
+type ColdStorageSweep struct {
+	Sweep     bool   `json:"sweep"`
+	Reason    string `json:"reason,omitempty"`
+	Balance   int64  `json:"balance"`
+	Threshold int64  `json:"threshold"`
+	Amount    int64  `json:"amount"`
+	Address   string `json:"address"`
+	Index     int    `json:"index"`
+	FeeLevel  string `json:"feeLevel"`
+
+	xpub  string
+	coins map[wire.OutPoint]bool
+}
+
+// Check the cold storage settings before they are saved
+func ValidateColdStorageSettings(s *repo.ColdStorageSettings, params *chaincfg.Params) error {
+	if s == nil {
+		return nil
+	}
+	if s.Threshold < 0 || s.MinimumSweep < 0 {
+		return errors.New("Cold storage amounts cannot be negative")
+	}
+	if _, err := parseFeeLevel(s.FeeLevel); err != nil {
+		return err
+	}
+	if s.Xpub == "" {
+		if s.AutoSweep {
+			return errors.New("An xpub is required to sweep to cold storage")
+		}
+		return nil
+	}
+	_, err := coldStorageKey(s.Xpub, params)
+	return err
+}
+
+/* Sweep the confirmed balance above the threshold to cold storage if automatic sweeping is
+   enabled. This is a balance listener of the wallet status updater so the sweep runs in its own
+   goroutine. Balance updates arriving while a sweep is still running are ignored. */
+func (n *OpenBazaarNode) OnWalletBalance(confirmed, unconfirmed int64) {
+	n.coldStorageLock.Lock()
+	defer n.coldStorageLock.Unlock()
+	if n.coldStorageSweeping || time.Now().Before(n.coldStorageRetry) {
+		return
+	}
+	n.coldStorageSweeping = true
+	go func() {
+		retry := !n.sweepColdStorage(confirmed)
+		n.coldStorageLock.Lock()
+		n.coldStorageSweeping = false
+		if retry {
+			n.coldStorageRetry = time.Now().Add(coldStorageRetryInterval)
+		}
+		n.coldStorageLock.Unlock()
+	}()
+}
+
+// Run the automatic sweep. Returns false if it failed and should be retried later.
+func (n *OpenBazaarNode) sweepColdStorage(confirmed int64) bool {
+	settings, err := n.Datastore.Settings().Get()
+	if err != nil || settings.ColdStorage == nil || !settings.ColdStorage.AutoSweep {
+		return true
+	}
+	if confirmed <= settings.ColdStorage.Threshold {
+		return true
+	}
+	sweep, err := n.planColdStorageSweep(settings.ColdStorage)
+	if err != nil {
+		log.Errorf("Error planning cold storage sweep: %s", err.Error())
+		return false
+	}
+	if !sweep.Sweep {
+		return true
+	}
+	txid, err := n.sweepToColdStorage(sweep)
+	if err != nil {
+		if _, ok := err.(*SigningRequiredError); ok {
+			log.Noticef("Cold storage sweep: %s", err.Error())
+		} else {
+			log.Errorf("Error sweeping to cold storage: %s", err.Error())
+		}
+		return false
+	}
+	log.Infof("Swept %d satoshis to cold storage address %s in transaction %s", sweep.Amount, sweep.Address, txid.String())
+	return true
+}
+
+/* Return the sweep the current settings and balance would make without sending it. The
+   automatic sweep setting is ignored so sweeps can be previewed before it is turned on. */
+func (n *OpenBazaarNode) PlanColdStorageSweep() (*ColdStorageSweep, error) {
+	settings, err := n.Datastore.Settings().Get()
+	if err != nil || settings.ColdStorage == nil || settings.ColdStorage.Xpub == "" {
+		return nil, ErrColdStorageNotConfigured
+	}
+	return n.planColdStorageSweep(settings.ColdStorage)
+}
+
+func (n *OpenBazaarNode) planColdStorageSweep(s *repo.ColdStorageSettings) (*ColdStorageSweep, error) {
+	params := n.Wallet.Params()
+	key, err := coldStorageKey(s.Xpub, params)
+	if err != nil {
+		return nil, err
+	}
+	sweep := &ColdStorageSweep{
+		Threshold: s.Threshold,
+		FeeLevel:  strings.ToUpper(s.FeeLevel),
+		xpub:      s.Xpub,
+		coins:     make(map[wire.OutPoint]bool),
+	}
+	if sweep.FeeLevel == "" {
+		sweep.FeeLevel = "ECONOMIC"
+	}
+
+	// Only confirmed coins which haven't been frozen are swept
+	utxos, err := n.Wallet.ListUnspent()
+	if err != nil {
+		return nil, err
+	}
+	frozen, err := n.Datastore.FrozenCoins().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	isFrozen := make(map[string]bool)
+	for _, f := range frozen {
+		isFrozen[f] = true
+	}
+	for _, u := range utxos {
+		if u.AtHeight <= 0 || isFrozen[u.Op.String()] {
+			continue
+		}
+		sweep.Balance += u.Value
+		sweep.coins[u.Op] = true
+	}
+
+	index, err := n.Datastore.ColdStorage().GetIndex(s.Xpub)
+	if err != nil {
+		return nil, err
+	}
+	addr, index, err := coldStorageAddress(key, index, params)
+	if err != nil {
+		return nil, err
+	}
+	sweep.Address = addr.String()
+	sweep.Index = index
+
+	sweep.Amount = sweep.Balance - s.Threshold
+	switch {
+	case sweep.Amount <= 0:
+		sweep.Amount = 0
+		sweep.Reason = "Confirmed balance does not exceed the threshold"
+	case sweep.Amount < s.MinimumSweep || n.Wallet.IsDust(sweep.Amount):
+		sweep.Reason = "Amount is below the minimum sweep"
+	default:
+		sweep.Sweep = true
+	}
+	return sweep, nil
+}
+
+// Move on to the next cold storage address, send the sweep and record the transaction
+func (n *OpenBazaarNode) sweepToColdStorage(sweep *ColdStorageSweep) (*chainhash.Hash, error) {
+	feeLevel, err := parseFeeLevel(sweep.FeeLevel)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := n.Wallet.DecodeAddress(sweep.Address)
+	if err != nil {
+		return nil, err
+	}
+	script, err := n.Wallet.AddressToScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	allow := func(op wire.OutPoint) bool {
+		return sweep.coins[op]
+	}
+
+	// Move on to the next address before sending so a failure to save can't lead to address reuse
+	if err := n.Datastore.ColdStorage().PutIndex(sweep.xpub, sweep.Index+1); err != nil {
+		return nil, err
+	}
+	txid, err := n.Wallet.SpendCoins([]spvwallet.TransactionOutput{{ScriptPubKey: script, Value: sweep.Amount}}, allow, feeLevel)
+	if err != nil {
+		// Nothing was paid to the address so the next sweep can use it
+		if err := n.Datastore.ColdStorage().PutIndex(sweep.xpub, sweep.Index); err != nil {
+			log.Error(err)
+		}
+		return nil, err
+	}
+	if err := n.Datastore.TxMetadata().Put(repo.Metadata{
+		Txid:       txid.String(),
+		Address:    sweep.Address,
+		Memo:       "Sweep to cold storage",
+		CanBumpFee: false,
+	}); err != nil {
+		log.Error(err)
+	}
+	return txid, nil
+}
+
+func coldStorageKey(xpub string, params *chaincfg.Params) (*hd.ExtendedKey, error) {
+	key, err := hd.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, errors.New("Invalid cold storage xpub")
+	}
+	if key.IsPrivate() {
+		return nil, errors.New("Cold storage key must be an extended public key")
+	}
+	if !key.IsForNet(params) {
+		return nil, errors.New("Cold storage xpub is for a different network")
+	}
+	return key, nil
+}
+
+// Derive the address at or after index on the external chain of the account key
+func coldStorageAddress(key *hd.ExtendedKey, index int, params *chaincfg.Params) (btc.Address, int, error) {
+	external, err := key.Child(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	for {
+		child, err := external.Child(uint32(index))
+		if err == hd.ErrInvalidChild {
+			index++
+			continue
+		} else if err != nil {
+			return nil, 0, err
+		}
+		addr, err := child.Address(params)
+		return addr, index, err
+	}
+}
+
+func parseFeeLevel(s string) (spvwallet.FeeLevel, error) {
+	switch strings.ToUpper(s) {
+	case "PRIORITY":
+		return spvwallet.PRIOIRTY, nil
+	case "NORMAL":
+		return spvwallet.NORMAL, nil
+	case "ECONOMIC", "":
+		return spvwallet.ECONOMIC, nil
+	}
+	return spvwallet.ECONOMIC, errors.New("Fee level must be PRIORITY, NORMAL or ECONOMIC")
+}
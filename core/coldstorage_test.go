@@ -0,0 +1,266 @@
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/openbazaar-go/repo/db"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// Blocks listing the coins until released so a sweep can be held in progress
+type slowSweepWallet struct {
+	bitcoin.BitcoinWallet
+	calls   chan bool
+	release chan bool
+}
+
+func (w *slowSweepWallet) Params() *chaincfg.Params {
+	return &chaincfg.TestNet3Params
+}
+
+func (w *slowSweepWallet) ListUnspent() ([]spvwallet.Utxo, error) {
+	w.calls <- true
+	<-w.release
+	return nil, errors.New("Wallet is offline")
+}
+
+// Records the saved cold storage index at the time of the spend
+type indexSweepWallet struct {
+	bitcoin.BitcoinWallet
+	db      repo.Datastore
+	xpub    string
+	indexes []int
+	fail    bool
+}
+
+func (w *indexSweepWallet) DecodeAddress(addr string) (btc.Address, error) {
+	return btc.DecodeAddress(addr, &chaincfg.TestNet3Params)
+}
+
+func (w *indexSweepWallet) AddressToScript(addr btc.Address) ([]byte, error) {
+	return txscript.PayToAddrScript(addr)
+}
+
+func (w *indexSweepWallet) SpendCoins(outs []spvwallet.TransactionOutput, allow func(wire.OutPoint) bool, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	index, err := w.db.ColdStorage().GetIndex(w.xpub)
+	if err != nil {
+		return nil, err
+	}
+	w.indexes = append(w.indexes, index)
+	if w.fail {
+		return nil, errors.New("Broadcast failed")
+	}
+	return &chainhash.Hash{}, nil
+}
+
+func newColdStorageTestKey(t *testing.T, params *chaincfg.Params) *hd.ExtendedKey {
+	seed := make([]byte, 32)
+	seed[0] = 0x11
+	key, err := hd.NewMaster(seed, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestValidateColdStorageSettings(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	priv := newColdStorageTestKey(t, params)
+	pub, err := priv.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainnetPub, err := newColdStorageTestKey(t, &chaincfg.MainNetParams).Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		settings *repo.ColdStorageSettings
+		valid    bool
+	}{
+		{nil, true},
+		{&repo.ColdStorageSettings{}, true},
+		{&repo.ColdStorageSettings{AutoSweep: true, Xpub: pub.String(), Threshold: 1000000, FeeLevel: "normal"}, true},
+		{&repo.ColdStorageSettings{AutoSweep: true}, false},
+		{&repo.ColdStorageSettings{Xpub: "xpub"}, false},
+		{&repo.ColdStorageSettings{Xpub: priv.String()}, false},
+		{&repo.ColdStorageSettings{Xpub: mainnetPub.String()}, false},
+		{&repo.ColdStorageSettings{Xpub: pub.String(), Threshold: -1}, false},
+		{&repo.ColdStorageSettings{Xpub: pub.String(), FeeLevel: "FAST"}, false},
+	}
+	for i, test := range tests {
+		err := ValidateColdStorageSettings(test.settings, params)
+		if test.valid && err != nil {
+			t.Errorf("Settings %d should be valid: %s", i, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Settings %d should be invalid", i)
+		}
+	}
+}
+
+func TestColdStorageAddress(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	priv := newColdStorageTestKey(t, params)
+	pub, err := priv.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, index, err := coldStorageAddress(pub, 4, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != 4 {
+		t.Errorf("Expected index 4, got %d", index)
+	}
+
+	// The cold wallet must find the funds at m/0/4 of its account key
+	external, err := priv.Child(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := external.Child(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := child.Address(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.String() != expected.String() {
+		t.Errorf("Expected address %s, got %s", expected, addr)
+	}
+}
+
+func TestOnWalletBalanceOverlap(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "coldstorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	pub, err := newColdStorageTestKey(t, &chaincfg.TestNet3Params).Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := repo.SettingsData{ColdStorage: &repo.ColdStorageSettings{AutoSweep: true, Xpub: pub.String(), Threshold: 1000}}
+	if err := database.Settings().Put(settings); err != nil {
+		t.Fatal(err)
+	}
+	w := &slowSweepWallet{calls: make(chan bool, 10), release: make(chan bool)}
+	n := &OpenBazaarNode{Datastore: database, Wallet: w}
+
+	// The listener must not wait for the sweep
+	done := make(chan bool)
+	go func() {
+		n.OnWalletBalance(5000, 0)
+		n.OnWalletBalance(5000, 0)
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Balance listener blocked on the sweep")
+	}
+	<-w.calls
+	n.OnWalletBalance(5000, 0)
+	close(w.release)
+
+	// The failed sweep is retried only after the retry interval
+	for i := 0; i < 100; i++ {
+		n.coldStorageLock.Lock()
+		sweeping := n.coldStorageSweeping
+		n.coldStorageLock.Unlock()
+		if !sweeping {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	n.OnWalletBalance(5000, 0)
+	if len(w.calls) != 0 {
+		t.Errorf("Expected a single sweep, got %d", len(w.calls)+1)
+	}
+	n.coldStorageLock.Lock()
+	defer n.coldStorageLock.Unlock()
+	if n.coldStorageRetry.Before(time.Now()) {
+		t.Error("Failed sweep did not set the retry time")
+	}
+}
+
+func TestSweepToColdStorageIndex(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "coldstorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	params := &chaincfg.TestNet3Params
+	pub, err := newColdStorageTestKey(t, params).Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, index, err := coldStorageAddress(pub, 3, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &indexSweepWallet{db: database, xpub: pub.String(), fail: true}
+	n := &OpenBazaarNode{Datastore: database, Wallet: w}
+	sweep := &ColdStorageSweep{Amount: 5000, Address: addr.EncodeAddress(), Index: index, FeeLevel: "normal", xpub: pub.String()}
+
+	// A failed spend leaves the address unused
+	if _, err := n.sweepToColdStorage(sweep); err == nil {
+		t.Error("Expected the failed spend to be returned")
+	}
+	saved, err := database.ColdStorage().GetIndex(pub.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != index {
+		t.Errorf("Expected index %d after a failed spend, got %d", index, saved)
+	}
+
+	// The next index must be saved before the coins are sent
+	w.fail = false
+	if _, err := n.sweepToColdStorage(sweep); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.indexes) != 2 || w.indexes[0] != index+1 || w.indexes[1] != index+1 {
+		t.Errorf("Expected index %d to be saved before each spend, got %v", index+1, w.indexes)
+	}
+	saved, err = database.ColdStorage().GetIndex(pub.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != index+1 {
+		t.Errorf("Expected index %d after the sweep, got %d", index+1, saved)
+	}
+}
@@ -12,9 +12,9 @@ import (
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
-	hd "github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
 )
 
 func (n *OpenBazaarNode) NewOrderConfirmation(contract *pb.RicardianContract, addressRequest bool) (*pb.RicardianContract, error) {
@@ -77,7 +77,14 @@ func (n *OpenBazaarNode) NewOrderConfirmation(contract *pb.RicardianContract, ad
 	return contract, nil
 }
 
-func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+func (n *OpenBazaarNode) ConfirmOfflineOrder(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	if isCrowdFund(contract) {
+		return errors.New("Crowd fund pledges are confirmed automatically once the goal is met")
+	}
+	return n.confirmOfflineOrder(ctx, contract, records)
+}
+
+func (n *OpenBazaarNode) confirmOfflineOrder(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
 	contract, err := n.NewOrderConfirmation(contract, false)
 	if err != nil {
 		return err
@@ -108,25 +115,7 @@ func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, rec
 		if err != nil {
 			return err
 		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
-		if err != nil {
-			return err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return err
-		}
-		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
-
-		vendorKey, err := hdKey.Child(0)
+		vendorKey, err := n.EscrowKey(chaincode)
 		if err != nil {
 			return err
 		}
@@ -134,7 +123,7 @@ func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, rec
 		if err != nil {
 			return err
 		}
-		_, err = n.Wallet.SweepAddress(utxos, nil, vendorKey, &redeemScript, spvwallet.NORMAL)
+		_, err = n.WalletFor(ctx).SweepAddress(utxos, nil, vendorKey, &redeemScript, spvwallet.NORMAL)
 		if err != nil {
 			return err
 		}
@@ -191,25 +180,7 @@ func (n *OpenBazaarNode) RejectOfflineOrder(contract *pb.RicardianContract, reco
 		if err != nil {
 			return err
 		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
-		if err != nil {
-			return err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return err
-		}
-		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
-
-		vendorKey, err := hdKey.Child(0)
+		vendorKey, err := n.EscrowKey(chaincode)
 		if err != nil {
 			return err
 		}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sync"
 	"time"
 
 	bstk "github.com/OpenBazaar/go-blockstackclient"
@@ -69,6 +70,9 @@ type OpenBazaarNode struct {
 	// Storage for our outgoing messages
 	MessageStorage sto.OfflineMessagingStorage
 
+	// A service that deletes acknowledged and expired messages from storage
+	MessageCollector *sto.MessageCollector
+
 	// A service that periodically checks the dht for outstanding messages
 	MessageRetriever *ret.MessageRetriever
 
@@ -84,6 +88,12 @@ type OpenBazaarNode struct {
 	// An optional gateway URL where we can crosspost data to ensure persistence
 	CrosspostGateways []*url.URL
 
+	// Relays which hold our offline messages and those we send until the recipient connects
+	OfflineRelays []peer.ID
+
+	// Settings for holding offline messages for other nodes, nil if we don't
+	RelayConfig *repo.RelayConfig
+
 	// The user-agent for this node
 	UserAgent string
 
@@ -92,6 +102,22 @@ type OpenBazaarNode struct {
 
 	// Manage blocked peers
 	BanManager *net.BanManager
+
+	// Guards the automatic cold storage sweep so only one runs at a time
+	coldStorageLock     sync.Mutex
+	coldStorageSweeping bool
+	coldStorageRetry    time.Time
+
+	// Serializes relayed message stores so the relay limits hold
+	relayStoreLock sync.Mutex
+
+	// Guards the per-recipient relay push locks
+	relayLock      sync.Mutex
+	relayPushLocks map[string]*relayPushLock
+
+	// Peers we failed to handshake with and when we may try them again
+	handshakeLock  sync.Mutex
+	handshakeRetry map[peer.ID]time.Time
 }
 
 // Unpin the current node repo, re-add it, then publish to IPNS
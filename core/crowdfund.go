@@ -0,0 +1,243 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"golang.org/x/net/context"
+)
+
+// Order states in which a funded pledge still counts towards the goal
+var pledgeStates = []pb.OrderState{
+	pb.OrderState_PENDING,
+	pb.OrderState_AWAITING_FULFILLMENT,
+	pb.OrderState_PARTIALLY_FULFILLED,
+	pb.OrderState_FULFILLED,
+	pb.OrderState_COMPLETED,
+}
+
+type CrowdFundStatus struct {
+	Slug     string    `json:"slug"`
+	Goal     uint64    `json:"goal"`
+	Pledged  uint64    `json:"pledged"`
+	Pledges  int       `json:"pledges"`
+	Deadline time.Time `json:"deadline"`
+	Ended    bool      `json:"ended"`
+	GoalMet  bool      `json:"goalMet"`
+}
+
+type crowdFundPledge struct {
+	orderId  string
+	contract *pb.RicardianContract
+	state    pb.OrderState
+	records  []*spvwallet.TransactionRecord
+}
+
+/* Return the funding status of one of our crowd fund listings. The goal and the pledged
+   amount are denominated in the pricing currency of the listing. */
+func (n *OpenBazaarNode) GetCrowdFundStatus(slug string) (*CrowdFundStatus, error) {
+	pledges, err := n.getCrowdFundPledges(slug)
+	if err != nil {
+		return nil, err
+	}
+	var listing *pb.Listing
+	if len(pledges) > 0 {
+		listing = pledges[0].contract.VendorListings[0]
+	} else {
+		sl, err := n.GetListingFromSlug(slug)
+		if err != nil {
+			return nil, errors.New("Listing not found")
+		}
+		listing = sl.Listing
+	}
+	if listing.CrowdFund == nil || listing.CrowdFund.Deadline == nil {
+		return nil, errors.New("Listing is not a crowd fund")
+	}
+	return newCrowdFundStatus(listing, pledges), nil
+}
+
+/* Settle every crowd fund whose deadline has passed. If the goal was met any pledges made while
+   we were offline are confirmed and the funds stay in escrow until we fulfill. Otherwise each
+   pledge is returned to the buyer using the normal moderated refund flow. */
+func (n *OpenBazaarNode) SettleCrowdFunds() {
+	sales, _, err := n.Datastore.Sales().GetAll([]pb.OrderState{pb.OrderState_PENDING, pb.OrderState_AWAITING_FULFILLMENT}, "", false, false, -1, []string{})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	slugs := make(map[string]bool)
+	for _, s := range sales {
+		slugs[s.Slug] = true
+	}
+	for slug := range slugs {
+		if err := n.settleCrowdFund(slug); err != nil {
+			log.Errorf("Error settling crowd fund %s: %s", slug, err.Error())
+		}
+	}
+}
+
+// Settle our crowd funds once an hour
+func (n *OpenBazaarNode) RunCrowdFundSettler() {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	n.SettleCrowdFunds()
+	for range tick.C {
+		n.SettleCrowdFunds()
+	}
+}
+
+func (n *OpenBazaarNode) settleCrowdFund(slug string) error {
+	pledges, err := n.getCrowdFundPledges(slug)
+	if err != nil {
+		return err
+	}
+	if len(pledges) == 0 {
+		return nil
+	}
+	listing := pledges[0].contract.VendorListings[0]
+	status := newCrowdFundStatus(listing, pledges)
+	if !status.Ended {
+		return nil
+	}
+
+	// A pledge which can't be settled is retried on the next run without holding up the others
+	settled, failed := 0, 0
+	for _, p := range pledges {
+		if status.GoalMet && p.state == pb.OrderState_PENDING {
+			err = n.confirmOfflineOrder(context.Background(), p.contract, p.records)
+		} else if !status.GoalMet && (p.state == pb.OrderState_PENDING || p.state == pb.OrderState_AWAITING_FULFILLMENT) {
+			err = n.RefundOrder(context.Background(), p.contract, p.records)
+		} else {
+			continue
+		}
+		if err != nil {
+			log.Errorf("Error settling crowd fund pledge %s: %s", p.orderId, err.Error())
+			failed++
+			continue
+		}
+		settled++
+	}
+	if settled == 0 {
+		return failedPledges(failed)
+	}
+
+	var thumbnailTiny string
+	var thumbnailSmall string
+	if listing.Item != nil && len(listing.Item.Images) > 0 {
+		thumbnailTiny = listing.Item.Images[0].Tiny
+		thumbnailSmall = listing.Item.Images[0].Small
+	}
+	notif := notifications.CrowdFundNotification{
+		"crowdFund",
+		slug,
+		listing.Item.Title,
+		status.GoalMet,
+		status.Pledged,
+		status.Goal,
+		notifications.Thumbnail{thumbnailTiny, thumbnailSmall},
+	}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, notif.Type, time.Now())
+	return failedPledges(failed)
+}
+
+func failedPledges(failed int) error {
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d pledges could not be settled", failed)
+}
+
+// Load the funded pledges made on one of our crowd fund listings
+func (n *OpenBazaarNode) getCrowdFundPledges(slug string) ([]crowdFundPledge, error) {
+	sales, _, err := n.Datastore.Sales().GetAll(pledgeStates, "", false, false, -1, []string{})
+	if err != nil {
+		return nil, err
+	}
+	var pledges []crowdFundPledge
+	for _, s := range sales {
+		if s.Slug != slug {
+			continue
+		}
+		contract, state, funded, records, _, err := n.Datastore.Sales().GetByOrderId(s.OrderId)
+		if err != nil || !funded || !isCrowdFund(contract) {
+			continue
+		}
+		pledges = append(pledges, crowdFundPledge{s.OrderId, contract, state, records})
+	}
+	return pledges, nil
+}
+
+func newCrowdFundStatus(listing *pb.Listing, pledges []crowdFundPledge) *CrowdFundStatus {
+	status := &CrowdFundStatus{
+		Slug:     listing.Slug,
+		Goal:     listing.CrowdFund.Goal,
+		Pledges:  len(pledges),
+		Deadline: time.Unix(listing.CrowdFund.Deadline.Seconds, 0),
+		Ended:    crowdFundHasEnded(listing),
+	}
+	for _, p := range pledges {
+		for _, item := range p.contract.BuyerOrder.Items {
+			status.Pledged += p.contract.VendorListings[0].Item.Price * uint64(item.Quantity)
+		}
+	}
+	status.GoalMet = status.Pledged >= status.Goal
+	return status
+}
+
+/* Crowd fund pledges are held in a moderated multisig address and must be ordered on their own
+   so they can be released or refunded independently of any other items. */
+func (n *OpenBazaarNode) validateCrowdFundPledge(contract *pb.RicardianContract) error {
+	if len(contract.VendorListings) != 1 || len(contract.BuyerOrder.Items) != 1 {
+		return errors.New("Crowd fund pledges must be ordered separately from other items")
+	}
+	if contract.BuyerOrder.Payment.Method != pb.Order_Payment_MODERATED {
+		return errors.New("Crowd fund pledges must be paid to a moderated multisig address")
+	}
+	if crowdFundHasEnded(contract.VendorListings[0]) {
+		return errors.New("Crowd fund deadline has passed")
+	}
+	total, err := n.CalculateOrderTotal(contract)
+	if err != nil {
+		return err
+	}
+	if !n.ValidatePaymentAmount(total, contract.BuyerOrder.Payment.Amount) {
+		return errors.New("Calculated a different payment amount")
+	}
+	return nil
+}
+
+// Crowd fund pledges can only be fulfilled after the deadline if the goal was met
+func (n *OpenBazaarNode) validateCrowdFundFulfillment(contract *pb.RicardianContract) error {
+	status, err := n.GetCrowdFundStatus(contract.VendorListings[0].Slug)
+	if err != nil {
+		return err
+	}
+	if !status.Ended {
+		return errors.New("Crowd fund has not yet ended")
+	}
+	if !status.GoalMet {
+		return errors.New("Crowd fund did not meet its goal")
+	}
+	return nil
+}
+
+func isCrowdFund(contract *pb.RicardianContract) bool {
+	for _, listing := range contract.VendorListings {
+		if listing.Metadata != nil && listing.Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND {
+			return true
+		}
+	}
+	return false
+}
+
+func crowdFundHasEnded(listing *pb.Listing) bool {
+	if listing.CrowdFund == nil || listing.CrowdFund.Deadline == nil {
+		return true
+	}
+	return !time.Now().Before(time.Unix(listing.CrowdFund.Deadline.Seconds, 0))
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+func TestValidateCrowdFundPledgePayment(t *testing.T) {
+	n := &OpenBazaarNode{}
+	listing := &pb.Listing{
+		Metadata:  &pb.Listing_Metadata{ContractType: pb.Listing_Metadata_CROWD_FUND},
+		CrowdFund: &pb.Listing_CrowdFund{Goal: 100000, Deadline: &timestamp.Timestamp{Seconds: time.Now().Add(time.Hour).Unix()}},
+	}
+	for _, method := range []pb.Order_Payment_Method{pb.Order_Payment_ADDRESS_REQUEST, pb.Order_Payment_DIRECT} {
+		contract := &pb.RicardianContract{
+			VendorListings: []*pb.Listing{listing},
+			BuyerOrder: &pb.Order{
+				Items:   []*pb.Order_Item{{Quantity: 1}},
+				Payment: &pb.Order_Payment{Method: method},
+			},
+		}
+		if err := n.validateCrowdFundPledge(contract); err == nil {
+			t.Errorf("Accepted a crowd fund pledge paid with %s", method)
+		}
+	}
+}
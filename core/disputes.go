@@ -570,29 +570,11 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	}
 
 	// Create moderator key
-	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
 	chaincodeBytes, err := hex.DecodeString(chaincode)
 	if err != nil {
 		return err
 	}
-	mPrivKey := n.Wallet.MasterPrivateKey()
-	if err != nil {
-		return err
-	}
-	mECKey, err := mPrivKey.ECPrivKey()
-	if err != nil {
-		return err
-	}
-	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPrivateKeyID[:],
-		mECKey.Serialize(),
-		chaincodeBytes,
-		parentFP,
-		0,
-		0,
-		true)
-
-	moderatorKey, err := hdKey.Child(0)
+	moderatorKey, err := n.EscrowKey(chaincodeBytes)
 	if err != nil {
 		return err
 	}
@@ -892,7 +874,11 @@ func (n *OpenBazaarNode) ValidateCaseContract(contract *pb.RicardianContract) []
 			validationErrors = append(validationErrors, "Error validating bitcoin address and redeem script")
 			return validationErrors
 		}
-		addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey, *moderatorKey}, 2)
+		addr, redeemScript, err := n.generateEscrowScript(buyerKey, vendorKey, moderatorKey, contract.BuyerOrder.Payment.EscrowTimeoutHours)
+		if err != nil {
+			validationErrors = append(validationErrors, "Error validating bitcoin address and redeem script")
+			return validationErrors
+		}
 
 		if contract.BuyerOrder.Payment.Address != addr.EncodeAddress() {
 			validationErrors = append(validationErrors, "The calculated bitcoin address doesn't match the address in the order")
@@ -976,7 +962,7 @@ func (n *OpenBazaarNode) verifySignatureOnDisputeResolution(contract *pb.Ricardi
 	return nil
 }
 
-func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+func (n *OpenBazaarNode) ReleaseFunds(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
 	// Create inputs
 	var inputs []spvwallet.TransactionInput
 	for _, o := range contract.DisputeResolution.Payout.Inputs {
@@ -1032,29 +1018,11 @@ func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []
 	}
 
 	// Create signing key
-	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
 	chaincodeBytes, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
 	if err != nil {
 		return err
 	}
-	mPrivKey := n.Wallet.MasterPrivateKey()
-	if err != nil {
-		return err
-	}
-	mECKey, err := mPrivKey.ECPrivKey()
-	if err != nil {
-		return err
-	}
-	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPrivateKeyID[:],
-		mECKey.Serialize(),
-		chaincodeBytes,
-		parentFP,
-		0,
-		0,
-		true)
-
-	signingKey, err := hdKey.Child(0)
+	signingKey, err := n.EscrowKey(chaincodeBytes)
 	if err != nil {
 		return err
 	}
@@ -1100,7 +1068,7 @@ func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []
 		n.Datastore.Sales().Put(orderId, *contract, pb.OrderState_DECIDED, true)
 	}
 
-	_, err = n.Wallet.Multisign(inputs, outputs, mySigs, moderatorSigs, redeemScriptBytes, 0, true)
+	_, err = n.WalletFor(ctx).Multisign(inputs, outputs, mySigs, moderatorSigs, redeemScriptBytes, 0, true)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"golang.org/x/net/context"
+)
+
+// Orders we have already told the vendor about so the notification is only sent once per run
+var escrowNotified = struct {
+	sync.Mutex
+	orders map[string]bool
+}{orders: make(map[string]bool)}
+
+/* The shortest escrow timeout a listing may offer. The timeout runs from the payment confirming,
+   not from fulfillment, so it has to cover handling, shipping and the buyer opening and settling a
+   dispute. Once it expires the vendor can claim the funds whatever state the order is in. */
+const MinEscrowTimeoutHours = 24 * 30
+
+/* The escrow timeout of an order is only offered if every listing in it has one, in which case
+   the longest timeout applies so the buyer gets the most time to open a dispute. */
+func escrowTimeoutHours(listings []*pb.Listing) uint32 {
+	var timeout uint32
+	for _, listing := range listings {
+		if listing.Metadata == nil || listing.Metadata.EscrowTimeoutHours == 0 {
+			return 0
+		}
+		if listing.Metadata.EscrowTimeoutHours > timeout {
+			timeout = listing.Metadata.EscrowTimeoutHours
+		}
+	}
+	return timeout
+}
+
+/* Generate the 2 of 3 escrow address for a moderated order. When the order has an escrow timeout
+   the vendor's key can also spend the funds on its own once the timeout has passed. */
+func (n *OpenBazaarNode) generateEscrowScript(buyerKey, vendorKey, moderatorKey *hd.ExtendedKey, timeoutHours uint32) (btc.Address, []byte, error) {
+	keys := []hd.ExtendedKey{*buyerKey, *vendorKey, *moderatorKey}
+	if timeoutHours == 0 {
+		return n.Wallet.GenerateMultisigScript(keys, 2)
+	}
+	return bitcoin.GenerateTimelockedMultisigScript(keys, 2, *vendorKey, timeoutHours, n.Wallet.Params())
+}
+
+/* Claim the funds of a fulfilled moderated order without the buyer's signature once the escrow
+   timeout, counted from payment, has passed. The script only enforces the timeout, refusing to
+   claim the funds of unfulfilled or disputed orders is up to us. */
+func (n *OpenBazaarNode) ReleaseEscrowAfterTimeout(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	utxos, err := n.releasableEscrow(contract, records)
+	if err != nil {
+		return err
+	}
+	orderId, err := n.CalcOrderId(contract.BuyerOrder)
+	if err != nil {
+		return err
+	}
+
+	chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+	vendorKey, err := n.EscrowKey(chaincode)
+	if err != nil {
+		return err
+	}
+	redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
+	if err != nil {
+		return err
+	}
+	_, err = bitcoin.ReleaseTimelockedEscrow(n.WalletFor(ctx), utxos, n.Wallet.CurrentAddress(spvwallet.INTERNAL), vendorKey, redeemScript, contract.BuyerOrder.Payment.EscrowTimeoutHours, spvwallet.NORMAL)
+	if err != nil {
+		return err
+	}
+	n.Datastore.Sales().Put(orderId, *contract, pb.OrderState_PAYMENT_FINALIZED, true)
+	return nil
+}
+
+/* Notify the vendor about every fulfilled sale whose escrow can now be released. The buyer may
+   still complete the order normally so the funds are not claimed automatically. */
+func (n *OpenBazaarNode) NotifyReleasableEscrow() {
+	sales, _, err := n.Datastore.Sales().GetAll([]pb.OrderState{pb.OrderState_FULFILLED}, "", false, false, -1, []string{})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, s := range sales {
+		escrowNotified.Lock()
+		notified := escrowNotified.orders[s.OrderId]
+		escrowNotified.Unlock()
+		if notified {
+			continue
+		}
+		contract, _, _, records, _, err := n.Datastore.Sales().GetByOrderId(s.OrderId)
+		if err != nil {
+			continue
+		}
+		utxos, err := n.releasableEscrow(contract, records)
+		if err != nil {
+			continue
+		}
+		var amount int64
+		for _, u := range utxos {
+			amount += u.Value
+		}
+		var thumbnailTiny string
+		var thumbnailSmall string
+		var title string
+		if len(contract.VendorListings) > 0 && contract.VendorListings[0].Item != nil {
+			title = contract.VendorListings[0].Item.Title
+			if len(contract.VendorListings[0].Item.Images) > 0 {
+				thumbnailTiny = contract.VendorListings[0].Item.Images[0].Tiny
+				thumbnailSmall = contract.VendorListings[0].Item.Images[0].Small
+			}
+		}
+		notif := notifications.EscrowReleasableNotification{
+			"escrowReleasable",
+			s.OrderId,
+			title,
+			amount,
+			notifications.Thumbnail{thumbnailTiny, thumbnailSmall},
+		}
+		n.Broadcast <- notif
+		n.Datastore.Notifications().Put(notif, notif.Type, time.Now())
+
+		escrowNotified.Lock()
+		escrowNotified.orders[s.OrderId] = true
+		escrowNotified.Unlock()
+	}
+}
+
+// Check for releasable escrow once an hour
+func (n *OpenBazaarNode) RunEscrowTimeoutNotifier() {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	n.NotifyReleasableEscrow()
+	for range tick.C {
+		n.NotifyReleasableEscrow()
+	}
+}
+
+// Return the escrowed coins of the order if the vendor can claim them using the timeout path
+func (n *OpenBazaarNode) releasableEscrow(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) ([]spvwallet.Utxo, error) {
+	payment := contract.BuyerOrder.Payment
+	if payment.Method != pb.Order_Payment_MODERATED || payment.EscrowTimeoutHours == 0 {
+		return nil, errors.New("Order does not have an escrow timeout")
+	}
+	if contract.VendorListings[0].VendorID.PeerID != n.IpfsNode.Identity.Pretty() {
+		return nil, errors.New("Only the vendor can release the escrow")
+	}
+	if contract.Dispute != nil {
+		return nil, errors.New("Escrow cannot be released while the order is disputed")
+	}
+	if len(contract.VendorOrderFulfillment) == 0 {
+		return nil, errors.New("Order must be fulfilled before the escrow can be released")
+	}
+	blocks := bitcoin.EscrowTimeoutBlocks(payment.EscrowTimeoutHours)
+	var utxos []spvwallet.Utxo
+	for _, r := range records {
+		if r.Spent || r.Value <= 0 {
+			continue
+		}
+		hash, err := chainhash.NewHashFromStr(r.Txid)
+		if err != nil {
+			return nil, err
+		}
+		confirmations, _, err := n.Wallet.GetConfirmations(*hash)
+		if err != nil {
+			return nil, err
+		}
+		if confirmations < blocks {
+			return nil, errors.New("Escrow timeout has not expired yet")
+		}
+		scriptBytes, err := hex.DecodeString(r.ScriptPubKey)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, spvwallet.Utxo{
+			Op:           *wire.NewOutPoint(hash, r.Index),
+			Value:        r.Value,
+			ScriptPubkey: scriptBytes,
+		})
+	}
+	if len(utxos) == 0 {
+		return nil, errors.New("There are no funds held in escrow")
+	}
+	return utxos, nil
+}
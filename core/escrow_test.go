@@ -0,0 +1,177 @@
+package core
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ipfs/go-ipfs/core"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+// A wallet whose coins all have the same number of confirmations unless set for the transaction
+type confirmationsWallet struct {
+	bitcoin.BitcoinWallet
+	confirmations uint32
+	txs           map[chainhash.Hash]uint32
+}
+
+func (w *confirmationsWallet) Params() *chaincfg.Params {
+	return &chaincfg.TestNet3Params
+}
+
+func (w *confirmationsWallet) GetConfirmations(txid chainhash.Hash) (uint32, uint32, error) {
+	if confirmations, ok := w.txs[txid]; ok {
+		return confirmations, 0, nil
+	}
+	return w.confirmations, 0, nil
+}
+
+func (w *confirmationsWallet) DecodeAddress(addr string) (btc.Address, error) {
+	return btc.DecodeAddress(addr, w.Params())
+}
+
+func (w *confirmationsWallet) AddressToScript(addr btc.Address) ([]byte, error) {
+	return txscript.PayToAddrScript(addr)
+}
+
+func TestEscrowTimeoutSpendPath(t *testing.T) {
+	var keys []*hd.ExtendedKey
+	for i := 0; i < 3; i++ {
+		seed := make([]byte, 32)
+		seed[0] = byte(i + 1)
+		key, err := hd.NewMaster(seed, &chaincfg.TestNet3Params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	buyerKey, vendorKey, moderatorKey := keys[0], keys[1], keys[2]
+
+	vendorID, err := peer.IDB58Decode("QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := new(confirmationsWallet)
+	n := &OpenBazaarNode{Wallet: w, IpfsNode: &core.IpfsNode{Identity: vendorID}}
+
+	timeout := uint32(MinEscrowTimeoutHours)
+	addr, redeemScript, err := n.generateEscrowScript(buyerKey, vendorKey, moderatorKey, timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := &pb.RicardianContract{
+		VendorListings: []*pb.Listing{{VendorID: &pb.ID{PeerID: vendorID.Pretty()}}},
+		BuyerOrder: &pb.Order{
+			Payment: &pb.Order_Payment{
+				Method:             pb.Order_Payment_MODERATED,
+				RedeemScript:       hex.EncodeToString(redeemScript),
+				EscrowTimeoutHours: timeout,
+			},
+		},
+		VendorOrderFulfillment: []*pb.OrderFulfillment{{}},
+	}
+	records := []*spvwallet.TransactionRecord{{
+		Txid:         chainhash.Hash{0x01}.String(),
+		Value:        100000,
+		ScriptPubKey: hex.EncodeToString(prevScript),
+	}}
+
+	// The node and the script agree on when the timeout expires, counted from the payment confirming
+	blocks := bitcoin.EscrowTimeoutBlocks(timeout)
+	w.confirmations = blocks - 1
+	if _, err := n.releasableEscrow(contract, records); err == nil {
+		t.Error("Escrow was releasable before the timeout")
+	}
+	w.confirmations = blocks
+	utxos, err := n.releasableEscrow(contract, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := bitcoin.BuildTimelockedRelease(utxos, addr, vendorKey, redeemScript, timeout, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine, err := txscript.NewEngine(prevScript, tx, 0, txscript.StandardVerifyFlags, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Execute(); err != nil {
+		t.Errorf("Timeout release failed to verify against the escrow script: %s", err)
+	}
+	tx, err = bitcoin.BuildTimelockedRelease(utxos, addr, vendorKey, redeemScript, timeout-1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine, err = txscript.NewEngine(prevScript, tx, 0, txscript.StandardVerifyFlags, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Execute(); err == nil {
+		t.Error("Timeout release verified with a shorter lock time than the escrow script")
+	}
+
+	/* A partial refund pays the remainder back into the escrow address. The new coin has to wait
+	   out the whole timeout again even though the payment's timeout has expired. */
+	refundAddr, err := buyerKey.Address(&chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract.BuyerOrder.RefundAddress = refundAddr.EncodeAddress()
+	contract.BuyerOrder.Payment.Address = addr.EncodeAddress()
+	outputs, err := n.RefundOutputs(contract, &pb.Refund{Amount: 40000, Partial: true}, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 2 || hex.EncodeToString(outputs[1].ScriptPubKey) != hex.EncodeToString(prevScript) || outputs[1].Value != 60000 {
+		t.Fatal("Remainder of the partial refund was not returned to escrow")
+	}
+	remainder := chainhash.Hash{0x02}
+	records[0].Spent = true
+	records = append(records, &spvwallet.TransactionRecord{
+		Txid:         remainder.String(),
+		Index:        1,
+		Value:        outputs[1].Value,
+		ScriptPubKey: hex.EncodeToString(outputs[1].ScriptPubKey),
+	})
+	w.txs = map[chainhash.Hash]uint32{remainder: 1}
+	if _, err := n.releasableEscrow(contract, records); err == nil {
+		t.Error("Escrow remainder was releasable before its own timeout")
+	}
+	w.txs[remainder] = blocks
+	utxos, err = n.releasableEscrow(contract, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxos) != 1 || utxos[0].Op.Hash != remainder {
+		t.Fatal("Expected only the escrow remainder to be released")
+	}
+	tx, err = bitcoin.BuildTimelockedRelease(utxos, addr, vendorKey, redeemScript, timeout, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine, err = txscript.NewEngine(prevScript, tx, 0, txscript.StandardVerifyFlags, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Execute(); err != nil {
+		t.Errorf("Timeout release of the remainder failed to verify against the escrow script: %s", err)
+	}
+
+	// The script doesn't know about disputes so the node has to refuse
+	contract.Dispute = &pb.Dispute{}
+	if _, err := n.releasableEscrow(contract, records); err == nil {
+		t.Error("Escrow was releasable while the order is disputed")
+	}
+}
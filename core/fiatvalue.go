@@ -0,0 +1,70 @@
+package core
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	btc "github.com/btcsuite/btcutil"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Values are shown in this currency if the user hasn't set a local currency
+const DefaultLocalCurrency = "USD"
+
+// The user's local currency from the settings
+func (n *OpenBazaarNode) LocalCurrency() string {
+	settings, err := n.Datastore.Settings().Get()
+	if err != nil || settings.LocalCurrency == nil || *settings.LocalCurrency == "" {
+		return DefaultLocalCurrency
+	}
+	return strings.ToUpper(*settings.LocalCurrency)
+}
+
+/* GetFiatValue returns what the amount was worth in the user's local currency at the given time.
+   The saved exchange rate closest to the time is used and its timestamp is returned with the value
+   so the caller can tell how far off it was. */
+func (n *OpenBazaarNode) GetFiatValue(satoshis int64, timestamp time.Time) (*repo.FiatValue, error) {
+	currencyCode := n.LocalCurrency()
+	rate, rateTimestamp, err := n.Datastore.ExchangeRateHistory().GetAt(currencyCode, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return fiatValue(satoshis, currencyCode, rate, rateTimestamp), nil
+}
+
+// Attach the local currency value of the transaction at the time it was made if a rate was saved
+func (n *OpenBazaarNode) addFiatValue(record *pb.TransactionRecord) {
+	if record == nil || record.Timestamp == nil {
+		return
+	}
+	timestamp, err := ptypes.Timestamp(record.Timestamp)
+	if err != nil {
+		return
+	}
+	v, err := n.GetFiatValue(record.Value, timestamp)
+	if err != nil {
+		return
+	}
+	rateTimestamp, err := ptypes.TimestampProto(v.RateTimestamp)
+	if err != nil {
+		return
+	}
+	record.FiatValue = &pb.FiatValue{
+		CurrencyCode:  v.CurrencyCode,
+		Amount:        v.Amount,
+		Rate:          v.Rate,
+		RateTimestamp: rateTimestamp,
+	}
+}
+
+func fiatValue(satoshis int64, currencyCode string, rate float64, rateTimestamp time.Time) *repo.FiatValue {
+	return &repo.FiatValue{
+		CurrencyCode:  currencyCode,
+		Amount:        math.Floor(btc.Amount(satoshis).ToBTC()*rate*100+0.5) / 100,
+		Rate:          rate,
+		RateTimestamp: rateTimestamp,
+	}
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiatValue(t *testing.T) {
+	timestamp := time.Unix(1500000000, 0)
+	tests := []struct {
+		satoshis int64
+		rate     float64
+		amount   float64
+	}{
+		{100000000, 2500, 2500},
+		{1000000, 2500, 25},
+		{123456, 2543.21, 3.14},
+		{-50000000, 2000, -1000},
+		{0, 2000, 0},
+	}
+	for _, test := range tests {
+		v := fiatValue(test.satoshis, "USD", test.rate, timestamp)
+		if v.Amount != test.amount {
+			t.Errorf("%d satoshis at %f is worth %f, expected %f", test.satoshis, test.rate, v.Amount, test.amount)
+		}
+		if v.CurrencyCode != "USD" || v.Rate != test.rate || !v.RateTimestamp.Equal(timestamp) {
+			t.Error("Incorrect fiat value returned")
+		}
+	}
+}
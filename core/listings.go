@@ -17,6 +17,7 @@ import (
 	"crypto/sha256"
 
 	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
 	"github.com/OpenBazaar/openbazaar-go/ipfs"
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/OpenBazaar/openbazaar-go/repo"
@@ -57,6 +58,7 @@ type listingData struct {
 	Slug          string    `json:"slug"`
 	Title         string    `json:"title"`
 	Categories    []string  `json:"categories"`
+	Tags          []string  `json:"tags"`
 	NSFW          bool      `json:"nsfw"`
 	ContractType  string    `json:"contractType"`
 	Description   string    `json:"description"`
@@ -132,12 +134,10 @@ func (n *OpenBazaarNode) SignListing(listing *pb.Listing) (*pb.SignedListing, er
 	listing.VendorID = id
 
 	// Sign the GUID with the Bitcoin key
-	ecPrivKey, err := n.Wallet.MasterPrivateKey().ECPrivKey()
+	id.BitcoinSig, err = n.SignPeerID()
 	if err != nil {
 		return sl, err
 	}
-	sig, err := ecPrivKey.Sign([]byte(id.PeerID))
-	id.BitcoinSig = sig.Serialize()
 
 	// Set crypto currency
 	listing.Metadata.AcceptedCurrency = strings.ToUpper(n.Wallet.CurrencyCode())
@@ -234,7 +234,12 @@ func (n *OpenBazaarNode) UpdateListingIndex(listing *pb.SignedListing) error {
 	if err != nil {
 		return err
 	}
-	return n.updateListingOnDisk(index, ld, false)
+	err = n.updateListingOnDisk(index, ld, false)
+	if err != nil {
+		return err
+	}
+	go n.UpdateTagPointers()
+	return nil
 }
 
 func (n *OpenBazaarNode) extractListingData(listing *pb.SignedListing) (listingData, error) {
@@ -279,6 +284,7 @@ func (n *OpenBazaarNode) extractListingData(listing *pb.SignedListing) (listingD
 		Slug:         listing.Listing.Slug,
 		Title:        listing.Listing.Item.Title,
 		Categories:   listing.Listing.Item.Categories,
+		Tags:         listing.Listing.Item.Tags,
 		NSFW:         listing.Listing.Item.Nsfw,
 		ContractType: listing.Listing.Metadata.ContractType.String(),
 		Description:  listing.Listing.Item.Description[:descriptionLength],
@@ -548,6 +554,13 @@ func (n *OpenBazaarNode) DeleteListing(slug string) error {
 		return err
 	}
 
+	// Delete any bids if the listing was an auction
+	err = n.Datastore.Bids().Delete(n.IpfsNode.Identity.Pretty(), slug)
+	if err != nil {
+		return err
+	}
+	go n.UpdateTagPointers()
+
 	return n.updateProfileCounts()
 }
 
@@ -669,7 +682,7 @@ func validateListing(listing *pb.Listing) (err error) {
 	if listing.Metadata == nil {
 		return errors.New("Missing required field: Metadata")
 	}
-	if listing.Metadata.ContractType > pb.Listing_Metadata_SERVICE {
+	if listing.Metadata.ContractType > pb.Listing_Metadata_CROWD_FUND {
 		return errors.New("Invalid contract type")
 	}
 	if listing.Metadata.Format > pb.Listing_Metadata_AUCTION {
@@ -681,6 +694,32 @@ func validateListing(listing *pb.Listing) (err error) {
 	if time.Unix(listing.Metadata.Expiry.Seconds, 0).Before(time.Now()) {
 		return errors.New("Listing expiration must be in the future")
 	}
+	if listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+		if listing.Auction == nil || listing.Auction.EndTime == nil {
+			return errors.New("Auction listings must specify an end time")
+		}
+		if listing.Auction.EndTime.Seconds > listing.Metadata.Expiry.Seconds {
+			return errors.New("Auction end time must be before the listing expiration")
+		}
+	} else if listing.Auction != nil {
+		return errors.New("Auction details can only be set on auction listings")
+	}
+	if listing.Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND {
+		if listing.CrowdFund == nil || listing.CrowdFund.Deadline == nil {
+			return errors.New("Crowd fund listings must specify a deadline")
+		}
+		if listing.CrowdFund.Goal == 0 {
+			return errors.New("Crowd fund listings must specify a funding goal")
+		}
+		if listing.CrowdFund.Deadline.Seconds > listing.Metadata.Expiry.Seconds {
+			return errors.New("Crowd fund deadline must be before the listing expiration")
+		}
+		if listing.Metadata.Format != pb.Listing_Metadata_FIXED_PRICE {
+			return errors.New("Crowd fund listings must use the fixed price format")
+		}
+	} else if listing.CrowdFund != nil {
+		return errors.New("Crowd fund details can only be set on crowd fund listings")
+	}
 	if listing.Metadata.PricingCurrency == "" {
 		return errors.New("Listing pricing currency code must not be empty")
 	}
@@ -690,6 +729,12 @@ func validateListing(listing *pb.Listing) (err error) {
 	if len(listing.Metadata.Language) > WordMaxCharacters {
 		return fmt.Errorf("Language is longer than the max of %d characters", WordMaxCharacters)
 	}
+	if listing.Metadata.EscrowTimeoutHours > bitcoin.MaxEscrowTimeoutHours {
+		return fmt.Errorf("Escrow timeout is longer than the max of %d hours", bitcoin.MaxEscrowTimeoutHours)
+	}
+	if listing.Metadata.EscrowTimeoutHours > 0 && listing.Metadata.EscrowTimeoutHours < MinEscrowTimeoutHours {
+		return fmt.Errorf("Escrow timeout is shorter than the min of %d hours", MinEscrowTimeoutHours)
+	}
 
 	// Item
 	if listing.Item.Title == "" {
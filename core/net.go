@@ -7,6 +7,7 @@ import (
 	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
 
 	"bytes"
+	"crypto/sha256"
 	"github.com/OpenBazaar/openbazaar-go/ipfs"
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/golang/protobuf/proto"
@@ -80,8 +81,14 @@ func (n *OpenBazaarNode) SendOfflineMessage(p peer.ID, k *libp2p.PubKey, m *pb.M
 	   but we will need some way to get the recipient's desired prefix length. Likely will be in profile. */
 	pointer, err := ipfs.PublishPointer(n.IpfsNode, ctx, mh, DefaultPointerPrefixLength, addr, ciphertext)
 	if err != nil {
+		n.MessageStorage.Delete(addr)
 		return err
 	}
+	err = n.Datastore.StoredMessages().Put(pointer.Value.ID, addr, time.Now())
+	if err != nil {
+		return err
+	}
+	n.sendToRelays(p, pointer, ciphertext)
 
 	// Post provider to gateway if we have one set in the config
 	if len(n.CrosspostGateways) > 0 {
@@ -110,6 +117,10 @@ func (n *OpenBazaarNode) SendOfflineMessage(p peer.ID, k *libp2p.PubKey, m *pb.M
 		if err != nil {
 			return err
 		}
+		err = n.putOutboxMessage(p, m, pointer)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -127,11 +138,8 @@ func (n *OpenBazaarNode) GetPeerStatus(peerId string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	m := pb.Message{MessageType: pb.Message_PING}
-	_, err = n.Service.SendRequest(ctx, p, &m)
-	if err != nil {
+	// The PING doubles as a handshake so we learn what the peer supports while we're at it
+	if _, err := n.Handshake(p); err != nil {
 		return "offline", nil
 	}
 	return "online", nil
@@ -195,6 +203,30 @@ func (n *OpenBazaarNode) SendOrder(peerId string, contract *pb.RicardianContract
 	return resp, nil
 }
 
+func (n *OpenBazaarNode) SendBid(peerId string, bid *pb.SignedBid) (resp *pb.Message, err error) {
+	p, err := peer.IDB58Decode(peerId)
+	if err != nil {
+		return resp, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	any, err := ptypes.MarshalAny(bid)
+	if err != nil {
+		return resp, err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_BID,
+		Payload:     any,
+	}
+
+	resp, err = n.Service.SendRequest(ctx, p, &m)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 func (n *OpenBazaarNode) SendOrderConfirmation(peerId string, contract *pb.RicardianContract) error {
 	a, err := ptypes.MarshalAny(contract)
 	if err != nil {
@@ -336,6 +368,38 @@ func (n *OpenBazaarNode) SendDisputeClose(peerId string, k *libp2p.PubKey, resol
 	return nil
 }
 
+// Build a chat message with an ID derived from its contents
+func NewChatMessage(subject, message string) (*pb.Chat, time.Time, error) {
+	t := time.Now()
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil, t, err
+	}
+	var flag pb.Chat_Flag
+	if message == "" {
+		flag = pb.Chat_TYPING
+	} else {
+		flag = pb.Chat_MESSAGE
+	}
+	h := sha256.Sum256([]byte(message + subject + ptypes.TimestampString(ts)))
+	encoded, err := multihash.Encode(h[:], multihash.SHA2_256)
+	if err != nil {
+		return nil, t, err
+	}
+	msgId, err := multihash.Cast(encoded)
+	if err != nil {
+		return nil, t, err
+	}
+	chatPb := &pb.Chat{
+		MessageId: msgId.B58String(),
+		Subject:   subject,
+		Message:   message,
+		Timestamp: ts,
+		Flag:      flag,
+	}
+	return chatPb, t, nil
+}
+
 func (n *OpenBazaarNode) SendChat(peerId string, chatMessage *pb.Chat) error {
 	a, err := ptypes.MarshalAny(chatMessage)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	ipfspath "github.com/ipfs/go-ipfs/path"
+	"golang.org/x/net/context"
 )
 
 type option struct {
@@ -62,6 +63,12 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 		return "", "", 0, false, err
 	}
 
+	/* A direct payment address can be swept by either party so crowd fund pledges use the
+	   moderated escrow, keeping the funds locked until the vendor delivers or refunds. */
+	if isCrowdFund(contract) && data.Moderator == "" {
+		return "", "", 0, false, errors.New("Crowd fund pledges must use a moderator")
+	}
+
 	// Add payment data and send to vendor
 	if data.Moderator != "" { // Moderated payment
 		if data.Moderator == n.IpfsNode.Identity.Pretty() {
@@ -144,7 +151,8 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			return "", "", 0, false, err
 		}
 
-		addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey, *moderatorKey}, 2)
+		payment.EscrowTimeoutHours = escrowTimeoutHours(contract.VendorListings)
+		addr, redeemScript, err := n.generateEscrowScript(buyerKey, vendorKey, moderatorKey, payment.EscrowTimeoutHours)
 		if err != nil {
 			return "", "", 0, false, err
 		}
@@ -390,32 +398,10 @@ func (n *OpenBazaarNode) createContractWithOrder(data *PurchaseData) (*pb.Ricard
 	}
 	order.Shipping = shipping
 
-	id := new(pb.ID)
-	profile, err := n.GetProfile()
-	if err == nil {
-		id.BlockchainID = profile.Handle
-	}
-
-	id.PeerID = n.IpfsNode.Identity.Pretty()
-	pubkey, err := n.IpfsNode.PrivateKey.GetPublic().Bytes()
-	if err != nil {
-		return nil, err
-	}
-	keys := new(pb.ID_Pubkeys)
-	keys.Identity = pubkey
-	ecPubKey, err := n.Wallet.MasterPublicKey().ECPubKey()
-	if err != nil {
-		return nil, err
-	}
-	keys.Bitcoin = ecPubKey.SerializeCompressed()
-	id.Pubkeys = keys
-	// Sign the PeerID with the Bitcoin key
-	ecPrivKey, err := n.Wallet.MasterPrivateKey().ECPrivKey()
+	id, err := n.createSignedID()
 	if err != nil {
 		return nil, err
 	}
-	sig, err := ecPrivKey.Sign([]byte(id.PeerID))
-	id.BitcoinSig = sig.Serialize()
 	order.BuyerID = id
 
 	ts, err := ptypes.TimestampProto(time.Now())
@@ -491,6 +477,18 @@ func (n *OpenBazaarNode) createContractWithOrder(data *PurchaseData) (*pb.Ricard
 			return nil, fmt.Errorf("Contract only accepts %s, our wallet uses %s", listing.Metadata.AcceptedCurrency, n.Wallet.CurrencyCode())
 		}
 
+		// Auctions are purchased at the price of our winning bid
+		if listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+			if item.Quantity != 1 {
+				return nil, errors.New("Auction items can only be purchased with a quantity of one")
+			}
+			bid, err := n.getWinningBid(listing)
+			if err != nil {
+				return nil, err
+			}
+			i.Bid = bid
+		}
+
 		// Remove any duplicate coupons
 		couponMap := make(map[string]bool)
 		var coupons []string
@@ -549,6 +547,35 @@ func (n *OpenBazaarNode) createContractWithOrder(data *PurchaseData) (*pb.Ricard
 	return contract, nil
 }
 
+// Build our ID, including the signature of our peer ID with the bitcoin key
+func (n *OpenBazaarNode) createSignedID() (*pb.ID, error) {
+	id := new(pb.ID)
+	profile, err := n.GetProfile()
+	if err == nil {
+		id.BlockchainID = profile.Handle
+	}
+
+	id.PeerID = n.IpfsNode.Identity.Pretty()
+	pubkey, err := n.IpfsNode.PrivateKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	keys := new(pb.ID_Pubkeys)
+	keys.Identity = pubkey
+	ecPubKey, err := n.Wallet.MasterPublicKey().ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	keys.Bitcoin = ecPubKey.SerializeCompressed()
+	id.Pubkeys = keys
+	// Sign the PeerID with the Bitcoin key
+	id.BitcoinSig, err = n.SignPeerID()
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
 func (n *OpenBazaarNode) EstimateOrderTotal(data *PurchaseData) (uint64, error) {
 	contract, err := n.createContractWithOrder(data)
 	if err != nil {
@@ -557,7 +584,7 @@ func (n *OpenBazaarNode) EstimateOrderTotal(data *PurchaseData) (uint64, error)
 	return n.CalculateOrderTotal(contract)
 }
 
-func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+func (n *OpenBazaarNode) CancelOfflineOrder(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
 		return err
@@ -587,25 +614,7 @@ func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, reco
 	if err != nil {
 		return err
 	}
-	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-	mPrivKey := n.Wallet.MasterPrivateKey()
-	if err != nil {
-		return err
-	}
-	mECKey, err := mPrivKey.ECPrivKey()
-	if err != nil {
-		return err
-	}
-	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPrivateKeyID[:],
-		mECKey.Serialize(),
-		chaincode,
-		parentFP,
-		0,
-		0,
-		true)
-
-	buyerKey, err := hdKey.Child(0)
+	buyerKey, err := n.EscrowKey(chaincode)
 	if err != nil {
 		return err
 	}
@@ -614,7 +623,7 @@ func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, reco
 	if err != nil {
 		return err
 	}
-	_, err = n.Wallet.SweepAddress(utxos, &refundAddress, buyerKey, &redeemScript, spvwallet.NORMAL)
+	_, err = n.WalletFor(ctx).SweepAddress(utxos, &refundAddress, buyerKey, &redeemScript, spvwallet.NORMAL)
 	if err != nil {
 		return err
 	}
@@ -647,7 +656,6 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 
 	// Calculate the price of each item
 	for _, item := range contract.BuyerOrder.Items {
-		var itemTotal uint64
 		l, err := ParseContractForListing(item.ListingHash, contract)
 		if err != nil {
 			return 0, fmt.Errorf("Listing not found in contract for item %s", item.ListingHash)
@@ -655,64 +663,10 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 		if l.Metadata.ContractType == pb.Listing_Metadata_PHYSICAL_GOOD {
 			physicalGoods[item.ListingHash] = l
 		}
-		satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, l.Item.Price)
+		itemTotal, err := n.calculateItemPrice(contract, l, item)
 		if err != nil {
 			return 0, err
 		}
-		itemTotal += satoshis
-		selectedSku, err := GetSelectedSku(l, item.Options)
-		if err != nil {
-			return 0, err
-		}
-		skuExists := false
-		for i, sku := range l.Item.Skus {
-			if selectedSku == i {
-				skuExists = true
-				if sku.Surcharge != 0 {
-					satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, uint64(sku.Surcharge))
-					if err != nil {
-						return 0, err
-					}
-					if sku.Surcharge < 0 {
-						satoshis = -satoshis
-					}
-					itemTotal += satoshis
-				}
-				if !skuExists {
-					return 0, errors.New("Selected variant not found in listing")
-				}
-				break
-			}
-		}
-		// Subtract any coupons
-		for _, couponCode := range item.CouponCodes {
-			for _, vendorCoupon := range l.Coupons {
-				multihash, err := EncodeMultihash([]byte(couponCode))
-				if err != nil {
-					return 0, err
-				}
-				if multihash.B58String() == vendorCoupon.GetHash() {
-					if discount := vendorCoupon.GetPriceDiscount(); discount > 0 {
-						satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, discount)
-						if err != nil {
-							return 0, err
-						}
-						itemTotal -= satoshis
-					} else if discount := vendorCoupon.GetPercentDiscount(); discount > 0 {
-						itemTotal -= uint64((float32(itemTotal) * (discount / 100)))
-					}
-				}
-			}
-		}
-		// Apply tax
-		for _, tax := range l.Taxes {
-			for _, taxRegion := range tax.TaxRegions {
-				if contract.BuyerOrder.Shipping.Country == taxRegion {
-					itemTotal += uint64((float32(itemTotal) * (tax.Percentage / 100)))
-					break
-				}
-			}
-		}
 		itemTotal *= uint64(item.Quantity)
 		total += itemTotal
 	}
@@ -881,8 +835,79 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 	return total, nil
 }
 
+// Price of a single unit of an order item in satoshi, including any variant surcharge, coupons and tax
+func (n *OpenBazaarNode) calculateItemPrice(contract *pb.RicardianContract, l *pb.Listing, item *pb.Order_Item) (uint64, error) {
+	var itemTotal uint64
+	price := l.Item.Price
+	if l.Metadata.Format == pb.Listing_Metadata_AUCTION {
+		if item.Bid == nil || item.Bid.Bid == nil {
+			return 0, errors.New("Auction item is missing the winning bid")
+		}
+		price = item.Bid.Bid.Amount
+	}
+	satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, price)
+	if err != nil {
+		return 0, err
+	}
+	itemTotal += satoshis
+	selectedSku, err := GetSelectedSku(l, item.Options)
+	if err != nil {
+		return 0, err
+	}
+	skuExists := false
+	for i, sku := range l.Item.Skus {
+		if selectedSku == i {
+			skuExists = true
+			if sku.Surcharge != 0 {
+				satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, uint64(sku.Surcharge))
+				if err != nil {
+					return 0, err
+				}
+				if sku.Surcharge < 0 {
+					satoshis = -satoshis
+				}
+				itemTotal += satoshis
+			}
+			if !skuExists {
+				return 0, errors.New("Selected variant not found in listing")
+			}
+			break
+		}
+	}
+	// Subtract any coupons
+	for _, couponCode := range item.CouponCodes {
+		for _, vendorCoupon := range l.Coupons {
+			multihash, err := EncodeMultihash([]byte(couponCode))
+			if err != nil {
+				return 0, err
+			}
+			if multihash.B58String() == vendorCoupon.GetHash() {
+				if discount := vendorCoupon.GetPriceDiscount(); discount > 0 {
+					satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, discount)
+					if err != nil {
+						return 0, err
+					}
+					itemTotal -= satoshis
+				} else if discount := vendorCoupon.GetPercentDiscount(); discount > 0 {
+					itemTotal -= uint64((float32(itemTotal) * (discount / 100)))
+				}
+			}
+		}
+	}
+	// Apply tax
+	for _, tax := range l.Taxes {
+		for _, taxRegion := range tax.TaxRegions {
+			if contract.BuyerOrder.Shipping.Country == taxRegion {
+				itemTotal += uint64((float32(itemTotal) * (tax.Percentage / 100)))
+				break
+			}
+		}
+	}
+	return itemTotal, nil
+}
+
 func (n *OpenBazaarNode) getPriceInSatoshi(currencyCode string, amount uint64) (uint64, error) {
-	if strings.ToLower(currencyCode) == strings.ToLower(n.Wallet.CurrencyCode()) || "t"+strings.ToLower(currencyCode) == strings.ToLower(n.Wallet.CurrencyCode()) {
+	if n.pricedInWalletCurrency(currencyCode) {
 		return amount, nil
 	}
 	exchangeRate, err := n.ExchangeRates.GetExchangeRate(currencyCode)
@@ -895,6 +920,11 @@ func (n *OpenBazaarNode) getPriceInSatoshi(currencyCode string, amount uint64) (
 	return uint64(satoshis), nil
 }
 
+// Prices in the wallet's own currency need no exchange rate
+func (n *OpenBazaarNode) pricedInWalletCurrency(currencyCode string) bool {
+	return strings.ToLower(currencyCode) == strings.ToLower(n.Wallet.CurrencyCode()) || "t"+strings.ToLower(currencyCode) == strings.ToLower(n.Wallet.CurrencyCode())
+}
+
 func verifySignaturesOnOrder(contract *pb.RicardianContract) error {
 	if err := verifyMessageSignature(
 		contract.BuyerOrder,
@@ -976,6 +1006,9 @@ func (n *OpenBazaarNode) ValidateOrder(contract *pb.RicardianContract) error {
 		if !validMod {
 			return errors.New("Invalid moderator")
 		}
+		if contract.BuyerOrder.Payment.EscrowTimeoutHours != escrowTimeoutHours(contract.VendorListings) {
+			return errors.New("Escrow timeout does not match the listings")
+		}
 	}
 
 	// Validate that the hash of the items in the contract match claimed hash in the order
@@ -1018,6 +1051,23 @@ collectListings:
 		}
 	}
 
+	// Validate the winning bid on any auction items
+	for _, item := range contract.BuyerOrder.Items {
+		listing := listingMap[item.ListingHash]
+		if listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+			if err := n.validateWinningBid(contract.BuyerOrder, item, listing); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Validate crowd fund pledges
+	if isCrowdFund(contract) {
+		if err := n.validateCrowdFundPledge(contract); err != nil {
+			return err
+		}
+	}
+
 	// Validate no duplicate coupons
 	for _, item := range contract.BuyerOrder.Items {
 		couponMap := make(map[string]bool)
@@ -1278,7 +1328,10 @@ func (n *OpenBazaarNode) ValidateModeratedPaymentAddress(order *pb.Order) error
 	if err != nil {
 		return err
 	}
-	addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey, *ModeratorKey}, 2)
+	addr, redeemScript, err := n.generateEscrowScript(buyerKey, vendorKey, ModeratorKey, order.Payment.EscrowTimeoutHours)
+	if err != nil {
+		return err
+	}
 	if order.Payment.Address != addr.EncodeAddress() {
 		return errors.New("Invalid payment address")
 	}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+var ErrRecipientOffline = errors.New("Recipient is offline")
+
+// Keep a copy of an offline message so we can tell whether it was received and push it again
+func (n *OpenBazaarNode) putOutboxMessage(p peer.ID, m *pb.Message, pointer ipfs.Pointer) error {
+	ser, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return n.Datastore.Outbox().Put(repo.OutboxMessage{
+		PointerID:   pointer.Value.ID.Pretty(),
+		MessageType: m.MessageType.String(),
+		Recipient:   p.Pretty(),
+		OrderId:     n.outboxOrderId(m),
+		Location:    pointer.Value.Addrs[0].String(),
+		Timestamp:   now,
+		Status:      repo.OutboxPending,
+		Updated:     now,
+		Message:     ser,
+	})
+}
+
+/* OfflineMessageReceived is called once the recipient has the message, either because they
+   acknowledged the offline copy or we pushed it to them directly. The pointer is no longer
+   republished and the ciphertext is deleted from storage. */
+func (n *OpenBazaarNode) OfflineMessageReceived(pointerID peer.ID, status string) error {
+	if err := n.Datastore.Pointers().Delete(pointerID); err != nil {
+		return err
+	}
+	if err := n.Datastore.Outbox().SetStatus(pointerID.Pretty(), status, time.Now()); err != nil {
+		return err
+	}
+	if err := n.Datastore.StoredMessages().Acknowledge(pointerID); err != nil {
+		return err
+	}
+	if n.MessageCollector != nil {
+		if m, err := n.Datastore.StoredMessages().Get(pointerID); err == nil {
+			go func() {
+				if err := n.MessageCollector.Delete(m); err != nil {
+					log.Errorf("Error deleting received offline message: %s", err.Error())
+				}
+			}()
+		}
+	}
+	return nil
+}
+
+// Push a pending offline message directly to the recipient if they are online
+func (n *OpenBazaarNode) ResendOutboxMessage(pointerID string) error {
+	msg, err := n.Datastore.Outbox().Get(pointerID)
+	if err != nil {
+		return err
+	}
+	if msg.Status != repo.OutboxPending {
+		return fmt.Errorf("Message is already %s", strings.ToLower(msg.Status))
+	}
+	status, err := n.GetPeerStatus(msg.Recipient)
+	if err != nil {
+		return err
+	}
+	if status != "online" {
+		return ErrRecipientOffline
+	}
+	m := new(pb.Message)
+	if err := proto.Unmarshal(msg.Message, m); err != nil {
+		return err
+	}
+	p, err := peer.IDB58Decode(msg.Recipient)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := n.Service.SendMessage(ctx, p, m); err != nil {
+		return err
+	}
+	pid, err := peer.IDB58Decode(pointerID)
+	if err != nil {
+		return err
+	}
+	return n.OfflineMessageReceived(pid, repo.OutboxDelivered)
+}
+
+// The order a message belongs to or an empty string if it isn't about an order
+func (n *OpenBazaarNode) outboxOrderId(m *pb.Message) string {
+	if m.Payload == nil {
+		return ""
+	}
+	switch m.MessageType {
+	case pb.Message_ORDER_CANCEL:
+		return string(m.Payload.Value)
+	case pb.Message_ORDER_REJECT:
+		reject := new(pb.OrderReject)
+		if err := ptypes.UnmarshalAny(m.Payload, reject); err != nil {
+			return ""
+		}
+		return reject.OrderID
+	case pb.Message_DISPUTE_UPDATE:
+		update := new(pb.DisputeUpdate)
+		if err := ptypes.UnmarshalAny(m.Payload, update); err != nil {
+			return ""
+		}
+		return update.OrderId
+	}
+	rc := new(pb.RicardianContract)
+	if err := ptypes.UnmarshalAny(m.Payload, rc); err != nil {
+		return ""
+	}
+	switch m.MessageType {
+	case pb.Message_ORDER:
+		if rc.BuyerOrder != nil {
+			orderId, _ := n.CalcOrderId(rc.BuyerOrder)
+			return orderId
+		}
+	case pb.Message_ORDER_CONFIRMATION:
+		if rc.VendorOrderConfirmation != nil {
+			return rc.VendorOrderConfirmation.OrderID
+		}
+	case pb.Message_REFUND:
+		if rc.Refund != nil {
+			return rc.Refund.OrderID
+		}
+	case pb.Message_ORDER_FULFILLMENT:
+		if len(rc.VendorOrderFulfillment) > 0 {
+			return rc.VendorOrderFulfillment[0].OrderId
+		}
+	case pb.Message_ORDER_COMPLETION:
+		if rc.BuyerOrderCompletion != nil {
+			return rc.BuyerOrderCompletion.OrderId
+		}
+	case pb.Message_DISPUTE_OPEN:
+		if rc.BuyerOrder != nil {
+			orderId, _ := n.CalcOrderId(rc.BuyerOrder)
+			return orderId
+		}
+	case pb.Message_DISPUTE_CLOSE:
+		if rc.DisputeResolution != nil {
+			return rc.DisputeResolution.OrderId
+		}
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+func TestOutboxOrderId(t *testing.T) {
+	n := &OpenBazaarNode{}
+	buyerOrder := &pb.Order{RefundAddress: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"}
+	buyerOrderId, _ := n.CalcOrderId(buyerOrder)
+	tests := []struct {
+		messageType pb.Message_MessageType
+		payload     proto.Message
+		orderId     string
+	}{
+		{pb.Message_ORDER, &pb.RicardianContract{BuyerOrder: buyerOrder}, buyerOrderId},
+		{pb.Message_ORDER_CONFIRMATION, &pb.RicardianContract{VendorOrderConfirmation: &pb.OrderConfirmation{OrderID: "QmConfirmation"}}, "QmConfirmation"},
+		{pb.Message_ORDER_REJECT, &pb.OrderReject{OrderID: "QmReject"}, "QmReject"},
+		{pb.Message_REFUND, &pb.RicardianContract{Refund: &pb.Refund{OrderID: "QmRefund"}}, "QmRefund"},
+		{pb.Message_ORDER_FULFILLMENT, &pb.RicardianContract{VendorOrderFulfillment: []*pb.OrderFulfillment{{OrderId: "QmFulfillment"}}}, "QmFulfillment"},
+		{pb.Message_ORDER_COMPLETION, &pb.RicardianContract{BuyerOrderCompletion: &pb.OrderCompletion{OrderId: "QmCompletion"}}, "QmCompletion"},
+		{pb.Message_DISPUTE_OPEN, &pb.RicardianContract{BuyerOrder: buyerOrder}, buyerOrderId},
+		{pb.Message_DISPUTE_UPDATE, &pb.DisputeUpdate{OrderId: "QmUpdate"}, "QmUpdate"},
+		{pb.Message_DISPUTE_CLOSE, &pb.RicardianContract{DisputeResolution: &pb.DisputeResolution{OrderId: "QmResolution"}}, "QmResolution"},
+		{pb.Message_CHAT, &pb.Chat{Message: "hello"}, ""},
+	}
+	for _, test := range tests {
+		a, err := ptypes.MarshalAny(test.payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := &pb.Message{MessageType: test.messageType, Payload: a}
+		if orderId := n.outboxOrderId(m); orderId != test.orderId {
+			t.Errorf("%s message returned order ID %s, expected %s", test.messageType, orderId, test.orderId)
+		}
+	}
+	cancel := &pb.Message{MessageType: pb.Message_ORDER_CANCEL, Payload: &any.Any{Value: []byte("QmCancel")}}
+	if orderId := n.outboxOrderId(cancel); orderId != "QmCancel" {
+		t.Errorf("Cancel message returned order ID %s, expected QmCancel", orderId)
+	}
+	if orderId := n.outboxOrderId(&pb.Message{MessageType: pb.Message_FOLLOW}); orderId != "" {
+		t.Error("Returned an order ID for a message without a payload")
+	}
+}
@@ -0,0 +1,475 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	mh "gx/ipfs/QmVGtdTZdTFaLsaj2RwdVG8jcjNNcp1DE914DKZ2kHmXHw/go-multihash"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	ipnspath "github.com/ipfs/go-ipfs/path"
+	"github.com/kennygrant/sanitize"
+	"golang.org/x/net/context"
+)
+
+const (
+	PostStatusMaxCharacters = 280
+	MaxPostImages           = 10
+
+	// Feeds fetched at once when building the timeline and how long we wait for all of them
+	TimelineWorkers = 8
+	TimelineTimeout = time.Minute
+)
+
+// The feed index lives alongside the posts so no post may use its name
+const postIndexSlug = "index"
+
+type postData struct {
+	Hash      string    `json:"hash"`
+	Slug      string    `json:"slug"`
+	PeerID    string    `json:"peerId,omitempty"`
+	Status    string    `json:"status"`
+	Thumbnail thumbnail `json:"thumbnail"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type postsByTimestamp []postData
+
+func (p postsByTimestamp) Len() int           { return len(p) }
+func (p postsByTimestamp) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p postsByTimestamp) Less(i, j int) bool { return p[i].Timestamp.After(p[j].Timestamp) }
+
+func (n *OpenBazaarNode) GeneratePostSlug(status string) (string, error) {
+	status = strings.Replace(status, "/", "", -1)
+	l := TitleMaxCharacters
+	if len(status) < TitleMaxCharacters {
+		l = len(status)
+	}
+	slugBase := url.QueryEscape(sanitize.Path(strings.ToLower(status[:l])))
+	if slugBase == "" {
+		slugBase = "post"
+	}
+	counter := 1
+	slugToTry := slugBase
+	for {
+		if slugToTry == postIndexSlug {
+			slugToTry = slugBase + strconv.Itoa(counter)
+			counter++
+			continue
+		}
+		_, err := n.GetPostFromSlug(slugToTry)
+		if os.IsNotExist(err) {
+			return slugToTry, nil
+		} else if err != nil {
+			return "", err
+		}
+		slugToTry = slugBase + strconv.Itoa(counter)
+		counter++
+	}
+}
+
+// Add our identity and a timestamp to the post and sign it
+func (n *OpenBazaarNode) SignPost(post *pb.Post) (*pb.SignedPost, error) {
+	if err := validatePost(post); err != nil {
+		return nil, err
+	}
+	id, err := n.createSignedID()
+	if err != nil {
+		return nil, err
+	}
+	post.VendorID = id
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	post.Timestamp = ts
+
+	serializedPost, err := proto.Marshal(post)
+	if err != nil {
+		return nil, err
+	}
+	idSig, err := n.IpfsNode.PrivateKey.Sign(serializedPost)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignedPost{Post: post, Signature: idSig}, nil
+}
+
+// Write the post into the feed directory and add it to the feed index
+func (n *OpenBazaarNode) SavePost(sp *pb.SignedPost) error {
+	postPath := path.Join(n.RepoPath, "root", "feed", sp.Post.Slug+".json")
+	f, err := os.Create(postPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(sp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(out); err != nil {
+		return err
+	}
+
+	postHash, err := ipfs.GetHashOfFile(n.Context, postPath)
+	if err != nil {
+		return err
+	}
+	pd := newPostData(postHash, sp)
+	index, err := n.getPostIndex()
+	if err != nil {
+		return err
+	}
+	for i, d := range index {
+		if d.Slug == pd.Slug {
+			index = append(index[:i], index[i+1:]...)
+			break
+		}
+	}
+	index = append(index, pd)
+	return n.writePostIndex(index)
+}
+
+func (n *OpenBazaarNode) DeletePost(slug string) error {
+	if slug == postIndexSlug {
+		return fmt.Errorf("The slug %s is reserved", postIndexSlug)
+	}
+	err := os.Remove(path.Join(n.RepoPath, "root", "feed", slug+".json"))
+	if err != nil {
+		return err
+	}
+	index, err := n.getPostIndex()
+	if err != nil {
+		return err
+	}
+	for i, d := range index {
+		if d.Slug == slug {
+			index = append(index[:i], index[i+1:]...)
+			break
+		}
+	}
+	return n.writePostIndex(index)
+}
+
+func (n *OpenBazaarNode) GetPosts() ([]byte, error) {
+	index, err := n.getPostIndex()
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(postsByTimestamp(index))
+	return json.MarshalIndent(index, "", "    ")
+}
+
+func (n *OpenBazaarNode) GetPostFromSlug(slug string) (*pb.SignedPost, error) {
+	file, err := ioutil.ReadFile(path.Join(n.RepoPath, "root", "feed", slug+".json"))
+	if err != nil {
+		return nil, err
+	}
+	sp := new(pb.SignedPost)
+	err = jsonpb.UnmarshalString(string(file), sp)
+	if err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// Fetch a post from another node's feed and check it was signed by that node
+func (n *OpenBazaarNode) FetchPost(peerId, slug string) (*pb.SignedPost, error) {
+	postBytes, err := ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(peerId, "feed", slug+".json")))
+	if err != nil {
+		return nil, err
+	}
+	return parsePeerPost(postBytes, peerId)
+}
+
+// Parse a post fetched from a peer's feed and check it was signed by that peer
+func parsePeerPost(postBytes []byte, peerId string) (*pb.SignedPost, error) {
+	sp := new(pb.SignedPost)
+	err := jsonpb.UnmarshalString(string(postBytes), sp)
+	if err != nil {
+		return nil, err
+	}
+	if sp.Post == nil || sp.Post.VendorID == nil || sp.Post.VendorID.Pubkeys == nil {
+		return nil, errors.New("Post is missing the author's ID")
+	}
+	if sp.Post.Timestamp == nil {
+		return nil, errors.New("Post is missing a timestamp")
+	}
+	if sp.Post.VendorID.PeerID != peerId {
+		return nil, errors.New("Post was not written by this peer")
+	}
+	if err := verifySignaturesOnPost(sp); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+/* Build a timeline from our own feed and the feeds of everyone we follow, newest first. Feeds
+   are fetched by a few workers at a time and any that can't be resolved before the timeout are
+   skipped so offline peers don't hold up the rest. */
+func (n *OpenBazaarNode) GetTimeline(limit int) ([]byte, error) {
+	following, err := n.Datastore.Following().Get("", -1)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := n.getPostIndex()
+	if err != nil {
+		return nil, err
+	}
+	for i := range timeline {
+		timeline[i].PeerID = n.IpfsNode.Identity.Pretty()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimelineTimeout)
+	defer cancel()
+	peers := make(chan string)
+	results := make(chan []postData)
+	go func() {
+		defer close(peers)
+		for _, peerId := range following {
+			select {
+			case peers <- peerId:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < TimelineWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pid := range peers {
+				posts := n.fetchFeed(ctx, pid, limit)
+				select {
+				case results <- posts:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+collect:
+	for {
+		select {
+		case posts, ok := <-results:
+			if !ok {
+				break collect
+			}
+			timeline = append(timeline, posts...)
+		case <-ctx.Done():
+			log.Warning("Timed out fetching feeds for the timeline")
+			break collect
+		}
+	}
+
+	sort.Sort(postsByTimestamp(timeline))
+	if limit > 0 && len(timeline) > limit {
+		timeline = timeline[:limit]
+	}
+	return json.MarshalIndent(timeline, "", "    ")
+}
+
+/* Fetch the newest posts from a peer's feed. The feed index isn't signed so each post is
+   fetched and its signature checked, and the timeline is built from the signed posts. */
+func (n *OpenBazaarNode) fetchFeed(ctx context.Context, peerId string, limit int) []postData {
+	indexBytes, err := ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(peerId, "feed", postIndexSlug+".json")))
+	if err != nil {
+		log.Debugf("Failed to fetch feed for %s: %s", peerId, err.Error())
+		return nil
+	}
+	var index []postData
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return nil
+	}
+	sort.Sort(postsByTimestamp(index))
+	if limit > 0 && len(index) > limit {
+		index = index[:limit]
+	}
+	var posts []postData
+	for _, d := range index {
+		if ctx.Err() != nil {
+			break
+		}
+		postBytes, err := ipfs.Cat(n.Context, d.Hash)
+		if err != nil {
+			log.Debugf("Failed to fetch post %s from %s: %s", d.Slug, peerId, err.Error())
+			continue
+		}
+		sp, err := parsePeerPost(postBytes, peerId)
+		if err != nil {
+			log.Warningf("Invalid post %s in the feed of %s: %s", d.Slug, peerId, err.Error())
+			continue
+		}
+		pd := newPostData(d.Hash, sp)
+		pd.PeerID = peerId
+		posts = append(posts, pd)
+	}
+	return posts
+}
+
+func newPostData(hash string, sp *pb.SignedPost) postData {
+	pd := postData{
+		Hash:      hash,
+		Slug:      sp.Post.Slug,
+		Status:    sp.Post.Status,
+		Timestamp: time.Unix(sp.Post.Timestamp.Seconds, 0),
+	}
+	if len(sp.Post.Images) > 0 {
+		pd.Thumbnail = thumbnail{sp.Post.Images[0].Tiny, sp.Post.Images[0].Small, sp.Post.Images[0].Medium}
+	}
+	return pd
+}
+
+func (n *OpenBazaarNode) getPostIndex() ([]postData, error) {
+	index := []postData{}
+	file, err := ioutil.ReadFile(path.Join(n.RepoPath, "root", "feed", postIndexSlug+".json"))
+	if os.IsNotExist(err) {
+		return index, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(file, &index)
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (n *OpenBazaarNode) writePostIndex(index []postData) error {
+	f, err := os.Create(path.Join(n.RepoPath, "root", "feed", postIndexSlug+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	j, err := json.MarshalIndent(index, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(j)
+	return err
+}
+
+func validatePost(post *pb.Post) error {
+	if post.Slug == "" {
+		return errors.New("Slug must not be empty")
+	}
+	if len(post.Slug) > SentenceMaxCharacters {
+		return fmt.Errorf("Slug is longer than the max of %d", SentenceMaxCharacters)
+	}
+	if strings.Contains(post.Slug, " ") {
+		return errors.New("Slugs cannot contain spaces")
+	}
+	if strings.Contains(post.Slug, "/") {
+		return errors.New("Slugs cannot contain file separators")
+	}
+	if post.Slug == postIndexSlug {
+		return fmt.Errorf("The slug %s is reserved", postIndexSlug)
+	}
+	if post.Status == "" {
+		return errors.New("Post status must not be empty")
+	}
+	if len(post.Status) > PostStatusMaxCharacters {
+		return fmt.Errorf("Post status is longer than the max of %d characters", PostStatusMaxCharacters)
+	}
+	if len(post.LongForm) > DescriptionMaxCharacters {
+		return fmt.Errorf("Post long form is longer than the max of %d characters", DescriptionMaxCharacters)
+	}
+	if len(post.Tags) > MaxTags {
+		return fmt.Errorf("Tags in the post is longer than the max of %d", MaxTags)
+	}
+	for _, tag := range post.Tags {
+		if tag == "" {
+			return errors.New("Tags must not be empty")
+		}
+		if len(tag) > WordMaxCharacters {
+			return fmt.Errorf("Tags must be less than max of %d characters", WordMaxCharacters)
+		}
+	}
+	if len(post.Images) > MaxPostImages {
+		return fmt.Errorf("Number of post images is greater than the max of %d", MaxPostImages)
+	}
+	for _, img := range post.Images {
+		_, err := mh.FromB58String(img.Tiny)
+		if err != nil {
+			return errors.New("Tiny image hashes must be multihashes")
+		}
+		_, err = mh.FromB58String(img.Small)
+		if err != nil {
+			return errors.New("Small image hashes must be multihashes")
+		}
+		_, err = mh.FromB58String(img.Medium)
+		if err != nil {
+			return errors.New("Medium image hashes must be multihashes")
+		}
+		_, err = mh.FromB58String(img.Large)
+		if err != nil {
+			return errors.New("Large image hashes must be multihashes")
+		}
+		_, err = mh.FromB58String(img.Original)
+		if err != nil {
+			return errors.New("Original image hashes must be multihashes")
+		}
+		if img.Filename == "" {
+			return errors.New("Image file names must not be nil")
+		}
+		if len(img.Filename) > FilenameMaxCharacters {
+			return fmt.Errorf("Image filename length must be less than the max of %d", FilenameMaxCharacters)
+		}
+	}
+	if len(post.Listings) > MaxListItems {
+		return fmt.Errorf("Number of linked listings is greater than the max of %d", MaxListItems)
+	}
+	for _, l := range post.Listings {
+		if _, err := peer.IDB58Decode(l.PeerID); err != nil {
+			return errors.New("Linked listings must contain a valid peer ID")
+		}
+		if l.Slug == "" {
+			return errors.New("Linked listings must contain a slug")
+		}
+	}
+	return nil
+}
+
+func verifySignaturesOnPost(sp *pb.SignedPost) error {
+	if err := verifySignature(
+		sp.Post,
+		sp.Post.VendorID.Pubkeys.Identity,
+		sp.Signature,
+		sp.Post.VendorID.PeerID,
+	); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Author's identity signature on post failed to verify")
+		case matchKeyError:
+			return errors.New("Public key in post does not match reported author ID")
+		default:
+			return err
+		}
+	}
+	return nil
+}
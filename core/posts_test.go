@@ -0,0 +1,37 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+func TestPostIndexSlugIsReserved(t *testing.T) {
+	if err := validatePost(&pb.Post{Slug: "index", Status: "Hello"}); err == nil {
+		t.Error("Accepted a post which would overwrite the feed index")
+	}
+
+	repoPath, err := ioutil.TempDir("", "posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.MkdirAll(path.Join(repoPath, "root", "feed"), os.ModePerm)
+	n := &OpenBazaarNode{RepoPath: repoPath}
+	if err := n.writePostIndex([]postData{}); err != nil {
+		t.Fatal(err)
+	}
+	slug, err := n.GeneratePostSlug("Index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slug != "index1" {
+		t.Errorf("Generated slug %s, expected index1", slug)
+	}
+	if err := n.DeletePost("index"); err == nil {
+		t.Error("Deleted the feed index")
+	}
+}
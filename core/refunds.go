@@ -3,17 +3,44 @@ package core
 import (
 	"encoding/hex"
 	"errors"
-
+	"fmt"
 	"time"
 
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/OpenBazaar/spvwallet"
-	hd "github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
 )
 
-func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+// Refund everything still held for the order
+func (n *OpenBazaarNode) RefundOrder(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	return n.refundOrder(ctx, contract, records, 0, nil)
+}
+
+/* Refund part of an order. Either an amount in satoshi or the items and quantities to refund may
+   be given, items are priced the same way as the order total but without shipping. The order stays
+   open in the PARTIALLY_REFUNDED state until the remaining funds are released or refunded. If the
+   refund would only leave dust behind the whole order is refunded instead.
+
+   The remainder of a moderated order is paid back into the escrow address as a new coin, so the
+   escrow timeout starts again from the refund confirming. A vendor who could already release the
+   escrow after the timeout has to wait a full timeout again and may want to do that first. */
+func (n *OpenBazaarNode) PartialRefundOrder(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord, amount uint64, items []*pb.Refund_Item) error {
+	if amount == 0 && len(items) == 0 {
+		return errors.New("A refund amount or items to refund must be specified")
+	}
+	if amount > 0 && len(items) > 0 {
+		return errors.New("Specify either a refund amount or items to refund, not both")
+	}
+	if contract.BuyerOrder.Payment.Method != pb.Order_Payment_MODERATED && contract.VendorOrderConfirmation == nil {
+		return errors.New("Unconfirmed orders can only be refunded in full")
+	}
+	return n.refundOrder(ctx, contract, records, amount, items)
+}
+
+func (n *OpenBazaarNode) refundOrder(ctx context.Context, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord, amount uint64, items []*pb.Refund_Item) error {
 	refundMsg := new(pb.Refund)
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
@@ -25,63 +52,70 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 		return err
 	}
 	refundMsg.Timestamp = ts
+
+	var previous uint64
+	var refunded []*pb.Refund_Item
+	if contract.Refund != nil {
+		previous = contract.Refund.TotalRefunded
+		refunded = contract.Refund.Items
+	}
+	if len(items) > 0 {
+		amount, refunded, err = n.priceRefundItems(contract, refunded, items)
+		if err != nil {
+			return err
+		}
+	}
+	available := RefundableValue(contract, records)
+	if available <= 0 {
+		return errors.New("There are no funds left to refund")
+	}
+	if amount == 0 || int64(amount) >= available || n.Wallet.IsDust(available-int64(amount)) {
+		amount = uint64(available)
+	}
+	refundMsg.Amount = amount
+	refundMsg.TotalRefunded = previous + amount
+	refundMsg.Items = refunded
+	refundMsg.Partial = int64(amount) < available
+	if refundMsg.Partial {
+		// Older nodes would read a partial refund as the whole order being refunded
+		buyer, err := peer.IDB58Decode(contract.BuyerOrder.BuyerID.PeerID)
+		if err != nil {
+			return err
+		}
+		if !n.PeerSupports(buyer, FeaturePartialRefunds) {
+			return errors.New("Buyer's node is too old to accept partial refunds")
+		}
+	}
+
 	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
 		var ins []spvwallet.TransactionInput
-		var outValue int64
 		for _, r := range records {
 			if !r.Spent && r.Value > 0 {
 				outpointHash, err := hex.DecodeString(r.Txid)
 				if err != nil {
 					return err
 				}
-				outValue += r.Value
 				in := spvwallet.TransactionInput{OutpointIndex: r.Index, OutpointHash: outpointHash}
 				ins = append(ins, in)
 			}
 		}
 
-		refundAddress, err := n.Wallet.DecodeAddress(contract.BuyerOrder.RefundAddress)
-		if err != nil {
-			return err
-		}
-		var output spvwallet.TransactionOutput
-
-		outputScript, err := n.Wallet.AddressToScript(refundAddress)
+		outputs, err := n.RefundOutputs(contract, refundMsg, available)
 		if err != nil {
 			return err
 		}
-		output.ScriptPubKey = outputScript
-		output.Value = outValue
 
 		chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
 		if err != nil {
 			return err
 		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
-		if err != nil {
-			return err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return err
-		}
-		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
-
-		vendorKey, err := hdKey.Child(0)
+		vendorKey, err := n.EscrowKey(chaincode)
 		if err != nil {
 			return err
 		}
 		redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
 
-		signatures, err := n.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, vendorKey, redeemScript, contract.BuyerOrder.RefundFee)
+		signatures, err := n.Wallet.CreateMultisigSignature(ins, outputs, vendorKey, redeemScript, contract.BuyerOrder.RefundFee)
 		if err != nil {
 			return err
 		}
@@ -92,35 +126,212 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 		}
 		refundMsg.Sigs = sigs
 	} else {
-		var outValue int64
-		for _, r := range records {
-			if r.Value > 0 {
-				outValue += r.Value
-			}
-		}
 		refundAddr, err := n.Wallet.DecodeAddress(contract.BuyerOrder.RefundAddress)
 		if err != nil {
 			return err
 		}
-		txid, err := n.Wallet.Spend(outValue, refundAddr, spvwallet.NORMAL)
+		txid, err := n.WalletFor(ctx).Spend(int64(amount), refundAddr, spvwallet.NORMAL)
 		if err != nil {
 			return err
 		}
 		txinfo := new(pb.Refund_TransactionInfo)
 		txinfo.Txid = txid.String()
-		txinfo.Value = uint64(outValue)
+		txinfo.Value = amount
 		refundMsg.RefundTransaction = txinfo
 	}
 	contract.Refund = refundMsg
+	contract.Signatures = removeSignatures(contract.Signatures, pb.Signature_REFUND)
 	contract, err = n.SignRefund(contract)
 	if err != nil {
 		return err
 	}
 	n.SendRefund(contract.BuyerOrder.BuyerID.PeerID, contract)
-	n.Datastore.Sales().Put(orderId, *contract, pb.OrderState_REFUNDED, true)
+	state := pb.OrderState_REFUNDED
+	if refundMsg.Partial {
+		state = pb.OrderState_PARTIALLY_REFUNDED
+	}
+	n.Datastore.Sales().Put(orderId, *contract, state, true)
+	return nil
+}
+
+/* Return the value that can still be refunded. Moderated orders are refunded from the funds still
+   sitting in the escrow address while direct orders are refunded from the vendor's wallet, so
+   previous refunds are deducted from the amount paid. */
+func RefundableValue(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) int64 {
+	var value int64
+	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
+		for _, r := range records {
+			if !r.Spent && r.Value > 0 {
+				value += r.Value
+			}
+		}
+		return value
+	}
+	for _, r := range records {
+		if r.Value > 0 {
+			value += r.Value
+		}
+	}
+	if contract.Refund != nil {
+		value -= int64(contract.Refund.TotalRefunded)
+	}
+	return value
+}
+
+/* Build the outputs of a moderated refund. The refund goes to the buyer's refund address and
+   anything left over is paid back into the escrow address so the order can still be completed.
+   That restarts the escrow timeout for the remainder. */
+func (n *OpenBazaarNode) RefundOutputs(contract *pb.RicardianContract, refund *pb.Refund, available int64) ([]spvwallet.TransactionOutput, error) {
+	refundAddress, err := n.Wallet.DecodeAddress(contract.BuyerOrder.RefundAddress)
+	if err != nil {
+		return nil, err
+	}
+	refundScript, err := n.Wallet.AddressToScript(refundAddress)
+	if err != nil {
+		return nil, err
+	}
+	outputs := []spvwallet.TransactionOutput{{ScriptPubKey: refundScript, Value: int64(refund.Amount)}}
+	if !refund.Partial {
+		return outputs, nil
+	}
+	escrowAddress, err := n.Wallet.DecodeAddress(contract.BuyerOrder.Payment.Address)
+	if err != nil {
+		return nil, err
+	}
+	escrowScript, err := n.Wallet.AddressToScript(escrowAddress)
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, spvwallet.TransactionOutput{ScriptPubKey: escrowScript, Value: available - int64(refund.Amount)})
+	return outputs, nil
+}
+
+/* Add the items being refunded to the quantities refunded by earlier refunds and return the price
+   of the new items along with the running totals. */
+func (n *OpenBazaarNode) priceRefundItems(contract *pb.RicardianContract, refunded []*pb.Refund_Item, items []*pb.Refund_Item) (uint64, []*pb.Refund_Item, error) {
+	quantities := make(map[uint32]uint32)
+	for _, r := range refunded {
+		quantities[r.Index] = r.Quantity
+	}
+	var amount uint64
+	for _, item := range items {
+		if int(item.Index) >= len(contract.BuyerOrder.Items) {
+			return 0, nil, fmt.Errorf("Order does not contain an item at index %d", item.Index)
+		}
+		if item.Quantity == 0 {
+			return 0, nil, errors.New("Refunded item quantities must be greater than zero")
+		}
+		orderItem := contract.BuyerOrder.Items[item.Index]
+		if quantities[item.Index]+item.Quantity > orderItem.Quantity {
+			return 0, nil, fmt.Errorf("Cannot refund more than the %d ordered of item %d", orderItem.Quantity, item.Index)
+		}
+		l, err := ParseContractForListing(orderItem.ListingHash, contract)
+		if err != nil {
+			return 0, nil, fmt.Errorf("Listing not found in contract for item %s", orderItem.ListingHash)
+		}
+		price, err := n.calculateItemPrice(contract, l, orderItem)
+		if err != nil {
+			return 0, nil, err
+		}
+		amount += price * uint64(item.Quantity)
+		quantities[item.Index] += item.Quantity
+	}
+	var totals []*pb.Refund_Item
+	for i := range contract.BuyerOrder.Items {
+		if q, ok := quantities[uint32(i)]; ok {
+			totals = append(totals, &pb.Refund_Item{Index: uint32(i), Quantity: q})
+		}
+	}
+	return amount, totals, nil
+}
+
+// Exchange rates differ a little between nodes so items not priced in bitcoin may be off by this fraction
+const refundPriceTolerance = 0.02
+
+/* Check a refund received from the vendor against the order. Refunds from vendors which predate
+   partial refunds carry no amount and always return everything so there is nothing to check.
+   Refunded items are priced again and must add up to the amount refunded for them. */
+func (n *OpenBazaarNode) ValidateRefund(contract *pb.RicardianContract, refund *pb.Refund, records []*spvwallet.TransactionRecord) error {
+	if refund.Amount == 0 && !refund.Partial {
+		return nil
+	}
+	if refund.Amount == 0 {
+		return errors.New("Partial refund does not refund anything")
+	}
+	var previous uint64
+	var refunded []*pb.Refund_Item
+	quantities := make(map[uint32]uint32)
+	if contract.Refund != nil {
+		previous = contract.Refund.TotalRefunded
+		refunded = contract.Refund.Items
+		for _, r := range refunded {
+			quantities[r.Index] = r.Quantity
+		}
+	}
+	if refund.TotalRefunded != previous+refund.Amount {
+		return errors.New("Refund total does not match the previous refunds")
+	}
+	available := RefundableValue(contract, records)
+	if int64(refund.Amount) > available {
+		return errors.New("Refund is more than the funds held for the order")
+	}
+	if !refund.Partial && int64(refund.Amount) < available {
+		return errors.New("Refund does not return all of the remaining funds")
+	}
+	var items []*pb.Refund_Item
+	for _, item := range refund.Items {
+		if int(item.Index) >= len(contract.BuyerOrder.Items) {
+			return fmt.Errorf("Order does not contain an item at index %d", item.Index)
+		}
+		if item.Quantity > contract.BuyerOrder.Items[item.Index].Quantity {
+			return fmt.Errorf("Refunded quantity of item %d is more than was ordered", item.Index)
+		}
+		if item.Quantity < quantities[item.Index] {
+			return fmt.Errorf("Refunded quantity of item %d is less than previously refunded", item.Index)
+		}
+		if item.Quantity > quantities[item.Index] {
+			items = append(items, &pb.Refund_Item{Index: item.Index, Quantity: item.Quantity - quantities[item.Index]})
+		}
+		delete(quantities, item.Index)
+	}
+	if len(quantities) > 0 {
+		return errors.New("Refund is missing previously refunded items")
+	}
+	// A refund of everything that is left needs no pricing, the vendor does this instead of leaving dust
+	if len(items) == 0 || !refund.Partial {
+		return nil
+	}
+	price, _, err := n.priceRefundItems(contract, refunded, items)
+	if err != nil {
+		return err
+	}
+	tolerance := uint64(0)
+	for _, item := range items {
+		l, err := ParseContractForListing(contract.BuyerOrder.Items[item.Index].ListingHash, contract)
+		if err != nil {
+			return err
+		}
+		if !n.pricedInWalletCurrency(l.Metadata.PricingCurrency) {
+			tolerance = uint64(float64(price) * refundPriceTolerance)
+			break
+		}
+	}
+	if refund.Amount+tolerance < price || refund.Amount > price+tolerance {
+		return fmt.Errorf("Refund amount of %d does not match the price of the refunded items %d", refund.Amount, price)
+	}
 	return nil
 }
 
+func removeSignatures(sigs []*pb.Signature, section pb.Signature_Section) []*pb.Signature {
+	var kept []*pb.Signature
+	for _, sig := range sigs {
+		if sig.Section != section {
+			kept = append(kept, sig)
+		}
+	}
+	return kept
+}
+
 func (n *OpenBazaarNode) SignRefund(contract *pb.RicardianContract) (*pb.RicardianContract, error) {
 	serializedRefund, err := proto.Marshal(contract.Refund)
 	if err != nil {
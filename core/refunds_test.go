@@ -0,0 +1,111 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/golang/protobuf/proto"
+)
+
+type currencyWallet struct {
+	bitcoin.BitcoinWallet
+}
+
+func (w *currencyWallet) CurrencyCode() string {
+	return "BTC"
+}
+
+func newRefundTestListing(title string, price uint64) (*pb.Listing, string) {
+	l := &pb.Listing{
+		Metadata: &pb.Listing_Metadata{PricingCurrency: "BTC"},
+		Item:     &pb.Listing_Item{Title: title, Price: price},
+	}
+	ser, _ := proto.Marshal(l)
+	mh, _ := EncodeMultihash(ser)
+	return l, mh.B58String()
+}
+
+func newRefundTestContract(method pb.Order_Payment_Method) *pb.RicardianContract {
+	listing1, hash1 := newRefundTestListing("Item 1", 20000)
+	listing2, hash2 := newRefundTestListing("Item 2", 20000)
+	return &pb.RicardianContract{
+		VendorListings: []*pb.Listing{listing1, listing2},
+		BuyerOrder: &pb.Order{
+			Items: []*pb.Order_Item{
+				{ListingHash: hash1, Quantity: 3},
+				{ListingHash: hash2, Quantity: 1},
+			},
+			Payment: &pb.Order_Payment{Method: method, Amount: 100000},
+		},
+		VendorOrderConfirmation: &pb.OrderConfirmation{},
+	}
+}
+
+func TestRefundableValue(t *testing.T) {
+	records := []*spvwallet.TransactionRecord{
+		{Txid: "a", Value: 60000},
+		{Txid: "b", Value: 40000, Spent: true},
+		{Txid: "c", Value: -40000},
+	}
+	moderated := newRefundTestContract(pb.Order_Payment_MODERATED)
+	if v := RefundableValue(moderated, records); v != 60000 {
+		t.Errorf("Moderated refundable value should be the unspent escrow, got %d", v)
+	}
+	direct := newRefundTestContract(pb.Order_Payment_DIRECT)
+	if v := RefundableValue(direct, records); v != 100000 {
+		t.Errorf("Direct refundable value should be the amount paid, got %d", v)
+	}
+	unconfirmed := newRefundTestContract(pb.Order_Payment_DIRECT)
+	unconfirmed.VendorOrderConfirmation = nil
+	if v := RefundableValue(unconfirmed, records); v != 100000 {
+		t.Errorf("Unconfirmed direct refundable value should be the amount paid, got %d", v)
+	}
+	direct.Refund = &pb.Refund{Amount: 25000, TotalRefunded: 25000, Partial: true}
+	if v := RefundableValue(direct, records); v != 75000 {
+		t.Errorf("Direct refundable value should exclude previous refunds, got %d", v)
+	}
+}
+
+func TestValidateRefund(t *testing.T) {
+	records := []*spvwallet.TransactionRecord{{Txid: "a", Value: 100000}}
+	contract := newRefundTestContract(pb.Order_Payment_DIRECT)
+	contract.Refund = &pb.Refund{
+		Amount:        20000,
+		TotalRefunded: 20000,
+		Items:         []*pb.Refund_Item{{Index: 0, Quantity: 1}},
+		Partial:       true,
+	}
+
+	tests := []struct {
+		refund *pb.Refund
+		valid  bool
+	}{
+		// Legacy full refund
+		{&pb.Refund{}, true},
+		{&pb.Refund{Amount: 20000, TotalRefunded: 40000, Items: []*pb.Refund_Item{{Index: 0, Quantity: 2}}, Partial: true}, true},
+		{&pb.Refund{Amount: 80000, TotalRefunded: 100000, Items: []*pb.Refund_Item{{Index: 0, Quantity: 1}}}, true},
+		{&pb.Refund{Partial: true}, false},
+		{&pb.Refund{Amount: 20000, TotalRefunded: 20000, Partial: true}, false},
+		{&pb.Refund{Amount: 90000, TotalRefunded: 110000}, false},
+		{&pb.Refund{Amount: 20000, TotalRefunded: 40000}, false},
+		{&pb.Refund{Amount: 20000, TotalRefunded: 40000, Items: []*pb.Refund_Item{{Index: 2, Quantity: 1}}, Partial: true}, false},
+		{&pb.Refund{Amount: 20000, TotalRefunded: 40000, Items: []*pb.Refund_Item{{Index: 0, Quantity: 4}}, Partial: true}, false},
+		{&pb.Refund{Amount: 20000, TotalRefunded: 40000, Items: []*pb.Refund_Item{{Index: 1, Quantity: 1}}, Partial: true}, false},
+		{&pb.Refund{Amount: 40000, TotalRefunded: 60000, Items: []*pb.Refund_Item{{Index: 0, Quantity: 2}, {Index: 1, Quantity: 1}}, Partial: true}, true},
+		// Amount doesn't match the price of the refunded items
+		{&pb.Refund{Amount: 30000, TotalRefunded: 50000, Items: []*pb.Refund_Item{{Index: 0, Quantity: 2}}, Partial: true}, false},
+		{&pb.Refund{Amount: 10000, TotalRefunded: 30000, Items: []*pb.Refund_Item{{Index: 0, Quantity: 2}}, Partial: true}, false},
+	}
+	n := &OpenBazaarNode{Wallet: new(currencyWallet)}
+	for i, test := range tests {
+		err := n.ValidateRefund(contract, test.refund, records)
+		if test.valid && err != nil {
+			t.Errorf("Refund %d should be valid: %s", i, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Refund %d should be invalid", i)
+		}
+	}
+}
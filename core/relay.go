@@ -0,0 +1,252 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	sto "github.com/OpenBazaar/openbazaar-go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	inet "gx/ipfs/QmRscs8KxrSmSv4iuevHv8JfuUzHBMoqiaHzxfDRiksd6e/go-libp2p-net"
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+var (
+	ErrRelayDisabled      = errors.New("This node is not a relay")
+	ErrRelayNotAllowed    = errors.New("Peer is not allowed to register with this relay")
+	ErrRelayNotRegistered = errors.New("Recipient is not registered with this relay")
+	ErrRelayQuotaExceeded = errors.New("Relay quota exceeded for recipient")
+	ErrRelaySenderLimit   = errors.New("Relay is holding too many messages from this peer")
+	ErrRelayFull          = errors.New("Relay is full")
+)
+
+// Held while pushing to a recipient. Removed from the node once nobody is waiting for it.
+type relayPushLock struct {
+	sync.Mutex
+	waiting int
+}
+
+/* Only one push per recipient at a time or messages could be delivered twice. Pushes to
+   different recipients don't wait for each other. Returns the function which unlocks. */
+func (n *OpenBazaarNode) lockRelayPush(recipient string) func() {
+	n.relayLock.Lock()
+	if n.relayPushLocks == nil {
+		n.relayPushLocks = make(map[string]*relayPushLock)
+	}
+	l, ok := n.relayPushLocks[recipient]
+	if !ok {
+		l = new(relayPushLock)
+		n.relayPushLocks[recipient] = l
+	}
+	l.waiting++
+	n.relayLock.Unlock()
+	l.Lock()
+	return func() {
+		l.Unlock()
+		n.relayLock.Lock()
+		l.waiting--
+		if l.waiting == 0 {
+			delete(n.relayPushLocks, recipient)
+		}
+		n.relayLock.Unlock()
+	}
+}
+
+func (n *OpenBazaarNode) relayEnabled() bool {
+	return n.RelayConfig != nil && n.RelayConfig.Enabled
+}
+
+/* StartRelay pushes held messages to registered recipients whenever they connect and drops
+   messages nobody has collected within the expiry used for offline message pointers. */
+func (n *OpenBazaarNode) StartRelay() {
+	if !n.relayEnabled() {
+		return
+	}
+	n.IpfsNode.PeerHost.Network().Notify(&relayNotifiee{n})
+	go func() {
+		tick := time.NewTicker(time.Hour * 24)
+		defer tick.Stop()
+		for {
+			if err := n.Datastore.Relay().DeleteExpired(time.Now().Add(-sto.MessageExpiry)); err != nil {
+				log.Error(err)
+			}
+			<-tick.C
+		}
+	}()
+}
+
+// Accept offline messages for the peer
+func (n *OpenBazaarNode) RegisterRelayRecipient(p peer.ID) error {
+	if !n.relayEnabled() {
+		return ErrRelayDisabled
+	}
+	if !n.RelayConfig.AllowAnyRecipient {
+		allowed := false
+		for _, r := range n.RelayConfig.Recipients {
+			if r == p.Pretty() {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrRelayNotAllowed
+		}
+	}
+	return n.Datastore.Relay().Register(p.Pretty(), time.Now())
+}
+
+/* Hold a message the sender left for a registered recipient, pushing it right away if they are
+   connected. The recipient's quota, the number of messages held from the sender and the total
+   held for everyone are all checked first. */
+func (n *OpenBazaarNode) StoreRelayedMessage(sender peer.ID, rm *pb.RelayedMessage) error {
+	if !n.relayEnabled() {
+		return ErrRelayDisabled
+	}
+	recipient, err := peer.IDB58Decode(rm.Recipient)
+	if err != nil {
+		return err
+	}
+	if !n.Datastore.Relay().IsRegistered(recipient.Pretty()) {
+		return ErrRelayNotRegistered
+	}
+	ser, err := proto.Marshal(rm)
+	if err != nil {
+		return err
+	}
+
+	// Checked and saved under the lock so concurrent stores can't overshoot the limits
+	n.relayStoreLock.Lock()
+	defer n.relayStoreLock.Unlock()
+	count, size, err := n.Datastore.Relay().Usage(recipient.Pretty())
+	if err != nil {
+		return err
+	}
+	if count+1 > n.RelayConfig.MaxMessages || size+len(ser) > n.RelayConfig.MaxBytes {
+		return ErrRelayQuotaExceeded
+	}
+	count, err = n.Datastore.Relay().SenderUsage(sender.Pretty())
+	if err != nil {
+		return err
+	}
+	if count+1 > n.RelayConfig.MaxMessagesPerSender {
+		return ErrRelaySenderLimit
+	}
+	count, size, err = n.Datastore.Relay().TotalUsage()
+	if err != nil {
+		return err
+	}
+	if count+1 > n.RelayConfig.TotalMaxMessages || size+len(ser) > n.RelayConfig.TotalMaxBytes {
+		return ErrRelayFull
+	}
+	if err := n.Datastore.Relay().Put(recipient.Pretty(), sender.Pretty(), ser, time.Now()); err != nil {
+		return err
+	}
+	if n.IpfsNode.PeerHost.Network().Connectedness(recipient) == inet.Connected {
+		go n.PushRelayedMessages(recipient)
+	}
+	return nil
+}
+
+// Send the recipient everything we're holding for them. Messages are deleted as they are sent.
+func (n *OpenBazaarNode) PushRelayedMessages(recipient peer.ID) error {
+	unlock := n.lockRelayPush(recipient.Pretty())
+	defer unlock()
+	messages, err := n.Datastore.Relay().GetAll(recipient.Pretty())
+	if err != nil {
+		return err
+	}
+	for _, m := range messages {
+		rm := new(pb.RelayedMessage)
+		if err := proto.Unmarshal(m.Message, rm); err != nil {
+			n.Datastore.Relay().Delete(m.ID)
+			continue
+		}
+		a, err := ptypes.MarshalAny(rm)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		err = n.Service.SendMessage(ctx, recipient, &pb.Message{MessageType: pb.Message_OFFLINE_RELAY, Payload: a})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if err := n.Datastore.Relay().Delete(m.ID); err != nil {
+			return err
+		}
+	}
+	if len(messages) > 0 {
+		log.Debugf("Pushed %d relayed messages to %s", len(messages), recipient.Pretty())
+	}
+	return nil
+}
+
+/* Give a copy of an offline message to each of our relays. The pointer and storage location go
+   along with it so the recipient can acknowledge it and skip the copy behind the pointer. */
+func (n *OpenBazaarNode) sendToRelays(p peer.ID, pointer ipfs.Pointer, ciphertext []byte) {
+	if len(n.OfflineRelays) == 0 {
+		return
+	}
+	a, err := ptypes.MarshalAny(&pb.RelayedMessage{
+		Recipient:  p.Pretty(),
+		PointerID:  pointer.Value.ID.Pretty(),
+		Address:    pointer.Value.Addrs[0].String(),
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	m := pb.Message{MessageType: pb.Message_RELAY_STORE, Payload: a}
+	for _, relay := range n.OfflineRelays {
+		go func(relay peer.ID) {
+			if !n.PeerSupports(relay, FeatureOfflineRelay) {
+				log.Errorf("Relay %s is running a version which does not support relaying", relay.Pretty())
+				return
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			resp, err := n.Service.SendRequest(ctx, relay, &m)
+			if err != nil {
+				log.Errorf("Error sending offline message to relay %s: %s", relay.Pretty(), err.Error())
+				return
+			}
+			if resp.MessageType == pb.Message_ERROR && resp.Payload != nil {
+				log.Errorf("Relay %s refused offline message: %s", relay.Pretty(), string(resp.Payload.Value))
+			}
+		}(relay)
+	}
+}
+
+// Is the peer one of the relays we use?
+func (n *OpenBazaarNode) IsOfflineRelay(p peer.ID) bool {
+	for _, relay := range n.OfflineRelays {
+		if relay == p {
+			return true
+		}
+	}
+	return false
+}
+
+type relayNotifiee struct {
+	node *OpenBazaarNode
+}
+
+func (r *relayNotifiee) Connected(_ inet.Network, c inet.Conn) {
+	go func() {
+		if err := r.node.PushRelayedMessages(c.RemotePeer()); err != nil {
+			log.Errorf("Error pushing relayed messages to %s: %s", c.RemotePeer().Pretty(), err.Error())
+		}
+	}()
+}
+
+func (r *relayNotifiee) Listen(inet.Network, ma.Multiaddr)      {}
+func (r *relayNotifiee) ListenClose(inet.Network, ma.Multiaddr) {}
+func (r *relayNotifiee) Disconnected(inet.Network, inet.Conn)   {}
+func (r *relayNotifiee) OpenedStream(inet.Network, inet.Stream) {}
+func (r *relayNotifiee) ClosedStream(inet.Network, inet.Stream) {}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/openbazaar-go/repo/db"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+func TestRelay(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "relay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	allowed, _ := peer.IDB58Decode("QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr")
+	stranger, _ := peer.IDB58Decode("QmbwSMS35CaYKdrYBvvR9aHU9FzeWhjJ7E3jLKeR2DWrs3")
+	sender, _ := peer.IDB58Decode("QmNaYCcRVMHD2UAdh6XPYZELfg4ry7XZvJHu8o6b8dAgaq")
+	n := &OpenBazaarNode{Datastore: database}
+	if err := n.RegisterRelayRecipient(allowed); err != ErrRelayDisabled {
+		t.Error("Registered a recipient while the relay is disabled")
+	}
+
+	// Without an allow-list nobody may register unless the relay is open to anyone
+	n.RelayConfig = &repo.RelayConfig{Enabled: true}
+	if err := n.RegisterRelayRecipient(stranger); err != ErrRelayNotAllowed {
+		t.Error("Registered a recipient without an allow-list")
+	}
+
+	n.RelayConfig = &repo.RelayConfig{Enabled: true, MaxMessages: 2, MaxBytes: 1024, MaxMessagesPerSender: 1, TotalMaxMessages: 3, TotalMaxBytes: 4096, Recipients: []string{allowed.Pretty()}}
+	if err := n.RegisterRelayRecipient(stranger); err != ErrRelayNotAllowed {
+		t.Error("Registered a recipient which is not allowed")
+	}
+	if err := n.StoreRelayedMessage(sender, &pb.RelayedMessage{Recipient: stranger.Pretty()}); err != ErrRelayNotRegistered {
+		t.Error("Stored a message for an unregistered recipient")
+	}
+	if err := n.RegisterRelayRecipient(allowed); err != nil {
+		t.Fatal(err)
+	}
+	if !database.Relay().IsRegistered(allowed.Pretty()) {
+		t.Error("Failed to register recipient")
+	}
+
+	if err := database.Relay().Put(allowed.Pretty(), sender.Pretty(), []byte("held"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.StoreRelayedMessage(sender, &pb.RelayedMessage{Recipient: allowed.Pretty(), Ciphertext: []byte("another")}); err != ErrRelaySenderLimit {
+		t.Error("Stored a message beyond the sender's limit")
+	}
+	if err := database.Relay().Put(stranger.Pretty(), stranger.Pretty(), []byte("held"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Relay().Put(stranger.Pretty(), stranger.Pretty(), []byte("held"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.StoreRelayedMessage(allowed, &pb.RelayedMessage{Recipient: allowed.Pretty(), Ciphertext: []byte("another")}); err != ErrRelayFull {
+		t.Error("Stored a message beyond the relay's total limit")
+	}
+	if err := database.Relay().Put(allowed.Pretty(), stranger.Pretty(), []byte("held"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.StoreRelayedMessage(allowed, &pb.RelayedMessage{Recipient: allowed.Pretty(), Ciphertext: []byte("another")}); err != ErrRelayQuotaExceeded {
+		t.Error("Stored a message beyond the recipient's quota")
+	}
+
+	// Pushes to different recipients don't wait for each other
+	unlock := n.lockRelayPush(allowed.Pretty())
+	done := make(chan bool)
+	go func() {
+		n.lockRelayPush(stranger.Pretty())()
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Error("Push to one recipient blocked a push to another")
+	}
+	unlock()
+	if len(n.relayPushLocks) != 0 {
+		t.Error("Push locks were not released")
+	}
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	ipnspath "github.com/ipfs/go-ipfs/path"
+	multihash "gx/ipfs/QmVGtdTZdTFaLsaj2RwdVG8jcjNNcp1DE914DKZ2kHmXHw/go-multihash"
+	cid "gx/ipfs/QmYhQaCYEcaPPjxJX7YcPcVKkQfRy6sJ7B3XmGFk82XYdQ/go-cid"
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+)
+
+const TagPointerPrefixLength = 64
+
+// Held while updating our tag pointers so concurrent listing updates don't publish duplicates
+var tagPointerLock sync.Mutex
+
+// Return the key vendors publish their pointers under for the given tag
+func TagPointerID(tag string) (multihash.Multihash, error) {
+	tagHash := sha256.Sum256([]byte("tag:" + normalizeTag(tag)))
+	encoded, err := multihash.Encode(tagHash[:], multihash.SHA2_256)
+	if err != nil {
+		return nil, err
+	}
+	return multihash.Cast(encoded)
+}
+
+/* Publish a TAG pointer for every tag used in our listings and forget the pointers for tags we
+   no longer use. Existing pointers are kept alive by the PointerRepublisher so only new tags
+   are published here. */
+func (n *OpenBazaarNode) UpdateTagPointers() {
+	tagPointerLock.Lock()
+	defer tagPointerLock.Unlock()
+
+	index, err := n.getListingIndex()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	type tagKey struct {
+		tag string
+		id  multihash.Multihash
+	}
+	wanted := make(map[string]tagKey)
+	for _, ld := range index {
+		sl, err := n.GetListingFromSlug(ld.Slug)
+		if err != nil {
+			continue
+		}
+		for _, tag := range sl.Listing.Item.Tags {
+			id, err := TagPointerID(tag)
+			if err != nil {
+				continue
+			}
+			k, err := cid.Decode(ipfs.CreatePointerKey(id, TagPointerPrefixLength).B58String())
+			if err != nil {
+				continue
+			}
+			wanted[k.String()] = tagKey{normalizeTag(tag), id}
+		}
+	}
+
+	pointers, err := n.Datastore.Pointers().GetByPurpose(ipfs.TAG)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	published := make(map[string]bool)
+	for _, p := range pointers {
+		if _, ok := wanted[p.Cid.String()]; ok {
+			published[p.Cid.String()] = true
+		} else {
+			n.Datastore.Pointers().Delete(p.Value.ID)
+		}
+	}
+
+	b, err := multihash.Encode([]byte(n.IpfsNode.Identity.Pretty()), multihash.SHA1)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	mhc, err := multihash.Cast(b)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	addr, err := ma.NewMultiaddr("/ipfs/" + mhc.B58String())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ctx := context.Background()
+	for k, t := range wanted {
+		if published[k] {
+			continue
+		}
+		pointer, err := ipfs.PublishPointer(n.IpfsNode, ctx, t.id, TagPointerPrefixLength, addr, []byte(n.IpfsNode.Identity.Pretty()+t.tag))
+		if err != nil {
+			log.Errorf("Error publishing pointer for tag %s: %s", t.tag, err.Error())
+			continue
+		}
+		pointer.Purpose = ipfs.TAG
+		if err := n.Datastore.Pointers().Put(pointer); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// Fetch a vendor's listing index and return the entries which use the given tag
+func (n *OpenBazaarNode) FetchListingsWithTag(peerId, tag string) ([]byte, error) {
+	indexBytes, err := ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(peerId, "listings", "index.json")))
+	if err != nil {
+		return nil, err
+	}
+	var index []listingData
+	err = json.Unmarshal(indexBytes, &index)
+	if err != nil {
+		return nil, err
+	}
+	matches := []listingData{}
+	for _, ld := range index {
+		for _, t := range ld.Tags {
+			if normalizeTag(t) == normalizeTag(tag) {
+				matches = append(matches, ld)
+				break
+			}
+		}
+	}
+	return json.MarshalIndent(matches, "", "    ")
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
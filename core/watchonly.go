@@ -0,0 +1,420 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"golang.org/x/net/context"
+)
+
+// Returned by a watch-only wallet when a transaction has been exported for offline signing
+type SigningRequiredError struct {
+	ID string
+}
+
+func (e *SigningRequiredError) Error() string {
+	return fmt.Sprintf("Transaction %s must be signed offline and imported", e.ID)
+}
+
+/* Derive our key for the escrow or payment address of an order from its chaincode. A watch-only
+   node gets the public key which the wallet can't sign with itself. */
+func (n *OpenBazaarNode) EscrowKey(chaincode []byte) (*hd.ExtendedKey, error) {
+	if mPrivKey := n.Wallet.MasterPrivateKey(); mPrivKey != nil {
+		return bitcoin.EscrowKey(mPrivKey, chaincode, n.Wallet.Params())
+	}
+	key, err := bitcoin.EscrowKey(n.Wallet.MasterPublicKey(), chaincode, n.Wallet.Params())
+	if err != nil {
+		return nil, err
+	}
+	ecPubKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	// Remembered so the watch-only wallet can tell the offline signer which key to use
+	if err := n.Datastore.UnsignedTransactions().PutChaincode(hex.EncodeToString(ecPubKey.SerializeCompressed()), chaincode); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Sign our peer ID with the master key. A watch-only node uses the signature made by the offline wallet.
+func (n *OpenBazaarNode) SignPeerID() ([]byte, error) {
+	if mPrivKey := n.Wallet.MasterPrivateKey(); mPrivKey != nil {
+		ecPrivKey, err := mPrivKey.ECPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := ecPrivKey.Sign([]byte(n.IpfsNode.Identity.Pretty()))
+		if err != nil {
+			return nil, err
+		}
+		return sig.Serialize(), nil
+	}
+	b, err := n.Datastore.Config().GetWatchOnlyKey()
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("Wallet has no private key")
+	}
+	var key bitcoin.WatchOnlyKey
+	if err := json.Unmarshal(b, &key); err != nil {
+		return nil, err
+	}
+	if key.PeerIDSignature == "" {
+		return nil, &SigningRequiredError{bitcoin.NewPeerIDSigningRequest(n.IpfsNode.Identity.Pretty()).ID}
+	}
+	return hex.DecodeString(key.PeerIDSignature)
+}
+
+// Save the peer ID signature made by the offline wallet in the watch-only key
+func (n *OpenBazaarNode) importPeerIDSignature(pkg *bitcoin.UnsignedTransaction, signed *bitcoin.SignedTransaction) error {
+	sig, err := hex.DecodeString(signed.Signature)
+	if err != nil {
+		return err
+	}
+	if pkg.Message != n.IpfsNode.Identity.Pretty() {
+		return errors.New("Signing request is not for our peer ID")
+	}
+	if err := bitcoin.VerifyPeerIDSignature(pkg.Message, sig, n.Wallet.MasterPublicKey()); err != nil {
+		return err
+	}
+	b, err := n.Datastore.Config().GetWatchOnlyKey()
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return errors.New("Node is not watch-only")
+	}
+	var key bitcoin.WatchOnlyKey
+	if err := json.Unmarshal(b, &key); err != nil {
+		return err
+	}
+	key.PeerIDSignature = signed.Signature
+	ser, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return n.Datastore.Config().SetWatchOnlyKey(ser)
+}
+
+/* Check and store the transaction signed by the offline wallet. A fully signed transaction is
+   broadcast and its txid returned. Signatures for a multisig payout are kept until the call
+   which exported them is made again. */
+func (n *OpenBazaarNode) ImportSignedTransaction(ctx context.Context, signed *bitcoin.SignedTransaction) (*chainhash.Hash, error) {
+	utx, err := n.Datastore.UnsignedTransactions().Get(signed.ID)
+	if err != nil {
+		return nil, errors.New("Unknown transaction")
+	}
+	if utx.Signed != nil {
+		return nil, errors.New("Transaction has already been imported")
+	}
+	var pkg bitcoin.UnsignedTransaction
+	if err := json.Unmarshal(utx.Package, &pkg); err != nil {
+		return nil, err
+	}
+	if pkg.Type == bitcoin.PeerIDSigningType {
+		if err := n.importPeerIDSignature(&pkg, signed); err != nil {
+			return nil, err
+		}
+		utx.Signed, err = json.Marshal(signed)
+		if err != nil {
+			return nil, err
+		}
+		return nil, n.Datastore.UnsignedTransactions().Put(*utx)
+	}
+	tx, err := bitcoin.VerifySignedTransaction(&pkg, signed)
+	if err != nil {
+		return nil, err
+	}
+	utx.Signed, err = json.Marshal(signed)
+	if err != nil {
+		return nil, err
+	}
+	var txid *chainhash.Hash
+	if tx != nil {
+		if err := n.WalletFor(ctx).Broadcast(tx); err != nil {
+			return nil, err
+		}
+		h := tx.TxHash()
+		txid = &h
+		utx.Txid = h.String()
+	}
+	if err := n.Datastore.UnsignedTransactions().Put(*utx); err != nil {
+		return nil, err
+	}
+	return txid, nil
+}
+
+/* Wrap a watch-only wallet so every transaction it would sign is exported instead and a
+   SigningRequiredError returned. Signed transactions are broadcast when they are imported.
+   Repeating the call afterwards returns the txid or the multisig signatures, so an order
+   action such as a refund is completed by simply retrying it. */
+func NewWatchOnlyWallet(w *spvwallet.SPVWallet, db repo.UnsignedTransactions) bitcoin.BitcoinWallet {
+	return &watchOnlyWallet{w, db}
+}
+
+type watchOnlyWallet struct {
+	*spvwallet.SPVWallet
+	db repo.UnsignedTransactions
+}
+
+func (w *watchOnlyWallet) Spend(amount int64, addr btc.Address, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	script, err := w.AddressToScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	return w.SpendCoins([]spvwallet.TransactionOutput{{ScriptPubKey: script, Value: amount}}, nil, feeLevel)
+}
+
+func (w *watchOnlyWallet) SpendMany(outs []spvwallet.TransactionOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	return w.SpendCoins(outs, nil, feeLevel)
+}
+
+func (w *watchOnlyWallet) SpendCoins(outs []spvwallet.TransactionOutput, allow func(op wire.OutPoint) bool, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	h := sha256.New()
+	for _, out := range outs {
+		fmt.Fprintf(h, "%x:%d,", out.ScriptPubKey, out.Value)
+	}
+	intent := "spend:" + hex.EncodeToString(h.Sum(nil))
+	if found, txid, err := w.retried(intent); found {
+		return txid, err
+	}
+
+	// Coins in transactions which haven't been signed yet are not spent twice
+	reserved, err := w.reservedCoins()
+	if err != nil {
+		return nil, err
+	}
+	tx, utxos, err := w.BuildUnsignedTx(outs, func(op wire.OutPoint) bool {
+		return !reserved[op] && (allow == nil || allow(op))
+	}, feeLevel)
+	if err != nil {
+		return nil, err
+	}
+	var inputs []bitcoin.UnsignedInput
+	for _, u := range utxos {
+		in, err := w.walletInput(u)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, in)
+	}
+	return nil, w.export("spend", intent, tx, inputs, false)
+}
+
+func (w *watchOnlyWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
+	utxos, err := w.ListUnspent()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range utxos {
+		if u.Op.Hash.IsEqual(&txid) && u.AtHeight == 0 {
+			return w.SweepAddress([]spvwallet.Utxo{u}, nil, nil, nil, spvwallet.FEE_BUMP)
+		}
+	}
+	return nil, spvwallet.BumpFeeNotFoundError
+}
+
+/* Sweeps with a redeem script spend an order's payment address using the escrow key. Otherwise the
+   coins are our own, as when bumping a fee. */
+func (w *watchOnlyWallet) SweepAddress(utxos []spvwallet.Utxo, address *btc.Address, key *hd.ExtendedKey, redeemScript *[]byte, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	var outpoints []string
+	for _, u := range utxos {
+		outpoints = append(outpoints, u.Op.String())
+	}
+	sort.Strings(outpoints)
+	intent := "sweep:" + strings.Join(outpoints, ",")
+	if found, txid, err := w.retried(intent); found {
+		return txid, err
+	}
+
+	tx, err := w.BuildSweepTx(utxos, address, feeLevel)
+	if err != nil {
+		return nil, err
+	}
+	coins := make(map[wire.OutPoint]spvwallet.Utxo)
+	for _, u := range utxos {
+		coins[u.Op] = u
+	}
+	var chaincode []byte
+	if redeemScript != nil {
+		chaincode, err = w.escrowChaincode(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var inputs []bitcoin.UnsignedInput
+	for _, txIn := range tx.TxIn {
+		u := coins[txIn.PreviousOutPoint]
+		if redeemScript == nil {
+			in, err := w.walletInput(u)
+			if err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, in)
+			continue
+		}
+		inputs = append(inputs, bitcoin.UnsignedInput{
+			Value:        u.Value,
+			ScriptPubKey: hex.EncodeToString(u.ScriptPubkey),
+			RedeemScript: hex.EncodeToString(*redeemScript),
+			Chaincode:    hex.EncodeToString(chaincode),
+		})
+	}
+	return nil, w.export("sweep", intent, tx, inputs, false)
+}
+
+func (w *watchOnlyWallet) CreateMultisigSignature(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, key *hd.ExtendedKey, redeemScript []byte, feePerByte uint64) ([]spvwallet.Signature, error) {
+	tx, err := spvwallet.BuildMultisigTx(ins, outs, feePerByte)
+	if err != nil {
+		return nil, err
+	}
+	if utx, err := w.db.Get(tx.TxHash().String()); err == nil {
+		if utx.Signed == nil {
+			return nil, &SigningRequiredError{utx.ID}
+		}
+		var signed bitcoin.SignedTransaction
+		if err := json.Unmarshal(utx.Signed, &signed); err != nil {
+			return nil, err
+		}
+		var sigs []spvwallet.Signature
+		for _, s := range signed.Signatures {
+			sig, err := hex.DecodeString(s.Signature)
+			if err != nil {
+				return nil, err
+			}
+			sigs = append(sigs, spvwallet.Signature{InputIndex: s.InputIndex, Signature: sig})
+		}
+		return sigs, nil
+	}
+
+	chaincode, err := w.escrowChaincode(key)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[wire.OutPoint]int64)
+	for _, in := range ins {
+		hash, err := chainhash.NewHash(in.OutpointHash)
+		if err != nil {
+			return nil, err
+		}
+		values[*wire.NewOutPoint(hash, in.OutpointIndex)] = in.Value
+	}
+	var inputs []bitcoin.UnsignedInput
+	for _, txIn := range tx.TxIn {
+		inputs = append(inputs, bitcoin.UnsignedInput{
+			Value:        values[txIn.PreviousOutPoint],
+			RedeemScript: hex.EncodeToString(redeemScript),
+			Chaincode:    hex.EncodeToString(chaincode),
+		})
+	}
+	return nil, w.export("multisig", "", tx, inputs, true)
+}
+
+/* Look up the transaction exported by an earlier call with the same intent. Once it has been
+   broadcast its txid is returned a single time so a later identical call makes a new transaction. */
+func (w *watchOnlyWallet) retried(intent string) (bool, *chainhash.Hash, error) {
+	utx, err := w.db.GetByIntent(intent)
+	if err != nil {
+		return false, nil, nil
+	}
+	if utx.Txid == "" {
+		return true, nil, &SigningRequiredError{utx.ID}
+	}
+	utx.Intent = ""
+	if err := w.db.Put(*utx); err != nil {
+		return true, nil, err
+	}
+	txid, err := chainhash.NewHashFromStr(utx.Txid)
+	return true, txid, err
+}
+
+func (w *watchOnlyWallet) export(txType, intent string, tx *wire.MsgTx, inputs []bitcoin.UnsignedInput, signaturesOnly bool) error {
+	utx, err := bitcoin.NewUnsignedTransaction(txType, tx, inputs, signaturesOnly)
+	if err != nil {
+		return err
+	}
+	pkg, err := json.Marshal(utx)
+	if err != nil {
+		return err
+	}
+	err = w.db.Put(repo.UnsignedTransaction{
+		ID:        utx.ID,
+		Intent:    intent,
+		Timestamp: time.Now(),
+		Package:   pkg,
+	})
+	if err != nil {
+		return err
+	}
+	log.Noticef("Exported transaction %s for offline signing", utx.ID)
+	return &SigningRequiredError{utx.ID}
+}
+
+func (w *watchOnlyWallet) walletInput(u spvwallet.Utxo) (bitcoin.UnsignedInput, error) {
+	path, err := w.KeyPathForScript(u.ScriptPubkey)
+	if err != nil {
+		return bitcoin.UnsignedInput{}, err
+	}
+	return bitcoin.UnsignedInput{
+		Value:        u.Value,
+		ScriptPubKey: hex.EncodeToString(u.ScriptPubkey),
+		Purpose:      int(path.Purpose),
+		Index:        path.Index,
+	}, nil
+}
+
+// The inputs of every exported transaction which has not been signed yet
+func (w *watchOnlyWallet) reservedCoins() (map[wire.OutPoint]bool, error) {
+	utxs, err := w.db.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	reserved := make(map[wire.OutPoint]bool)
+	for _, utx := range utxs {
+		if utx.Signed != nil {
+			continue
+		}
+		var pkg bitcoin.UnsignedTransaction
+		if err := json.Unmarshal(utx.Package, &pkg); err != nil {
+			continue
+		}
+		tx, err := pkg.Tx()
+		if err != nil {
+			continue
+		}
+		for _, txIn := range tx.TxIn {
+			reserved[txIn.PreviousOutPoint] = true
+		}
+	}
+	return reserved, nil
+}
+
+func (w *watchOnlyWallet) escrowChaincode(key *hd.ExtendedKey) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("No escrow key")
+	}
+	ecPubKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	chaincode, err := w.db.GetChaincode(hex.EncodeToString(ecPubKey.SerializeCompressed()))
+	if err != nil {
+		return nil, errors.New("Escrow key was not derived by this node")
+	}
+	return chaincode, nil
+}
@@ -297,7 +297,7 @@ func newNode(repoPath string, db *db.SQLiteDatastore) (*core.OpenBazaarNode, err
 		Datastore:          db,
 		Wallet:             wallet,
 		Resolver:           bstk.NewBlockStackClient(resolverURL, torDialer),
-		ExchangeRates:      exchange.NewBitcoinPriceFetcher(torDialer),
+		ExchangeRates:      exchange.NewBitcoinPriceFetcher(torDialer, exchange.DefaultMaxRateAge, nil),
 		MessageStorage:     selfhosted.NewSelfHostedStorage(repoPath, ctx, gatewayUrls, torDialer),
 		CrosspostGateways:  gatewayUrls,
 		UserAgent:          core.USERAGENT,
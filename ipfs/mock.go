@@ -76,3 +76,36 @@ func MockCmdsCtx() (commands.Context, error) {
 		},
 	}, nil
 }
+
+/* NewMockNetworkNode constructs an online IpfsNode with the given identity on the mock network
+   together with a command context which runs commands on that node. */
+func NewMockNetworkNode(mn mocknet.Mocknet, identity config.Identity, configRoot string) (*core.IpfsNode, commands.Context, error) {
+	conf := config.Config{Identity: identity}
+	// The node won't start without a swarm address even though the mock network ignores it
+	conf.Addresses.Swarm = []string{"/ip4/0.0.0.0/tcp/4001"}
+
+	r := &repo.Mock{
+		D: ds2.CloserWrap(syncds.MutexWrap(datastore.NewMapDatastore())),
+		C: conf,
+	}
+
+	node, err := core.NewNode(context.Background(), &core.BuildCfg{
+		Online: true,
+		Repo:   r,
+		Host:   MockHostOption(mn),
+	})
+	if err != nil {
+		return nil, commands.Context{}, err
+	}
+
+	return node, commands.Context{
+		Online:     true,
+		ConfigRoot: configRoot,
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &conf, nil
+		},
+		ConstructNode: func() (*core.IpfsNode, error) {
+			return node, nil
+		},
+	}, nil
+}
@@ -57,6 +57,8 @@ func (r *PointerRepublisher) Republish() {
 			} else {
 				r.db.Pointers().Delete(p.Value.ID)
 			}
+		case ipfs.TAG:
+			ipfs.RePublishPointer(r.ipfsNode, ctx, p)
 		default:
 			continue
 		}
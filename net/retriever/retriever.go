@@ -42,6 +42,7 @@ type MessageRetriever struct {
 	httpClient        *http.Client
 	crosspostGateways []*url.URL
 	queueLock         *sync.Mutex
+	relays            []peer.ID
 	*sync.WaitGroup
 }
 
@@ -50,14 +51,14 @@ type offlineMessage struct {
 	env  pb.Envelope
 }
 
-func NewMessageRetriever(db repo.Datastore, ctx commands.Context, node *core.IpfsNode, bm *net.BanManager, service net.NetworkService, prefixLen int, dialer proxy.Dialer, crosspostGateways []*url.URL, sendAck func(peerId string, pointerID peer.ID) error) *MessageRetriever {
+func NewMessageRetriever(db repo.Datastore, ctx commands.Context, node *core.IpfsNode, bm *net.BanManager, service net.NetworkService, prefixLen int, dialer proxy.Dialer, crosspostGateways []*url.URL, relays []peer.ID, sendAck func(peerId string, pointerID peer.ID) error) *MessageRetriever {
 	dial := gonet.Dial
 	if dialer != nil {
 		dial = dialer.Dial
 	}
 	tbTransport := &http.Transport{Dial: dial}
 	client := &http.Client{Transport: tbTransport, Timeout: time.Second * 30}
-	mr := MessageRetriever{db, node, bm, ctx, service, prefixLen, sendAck, make(map[pb.Message_MessageType][]offlineMessage), client, crosspostGateways, new(sync.Mutex), new(sync.WaitGroup)}
+	mr := MessageRetriever{db, node, bm, ctx, service, prefixLen, sendAck, make(map[pb.Message_MessageType][]offlineMessage), client, crosspostGateways, new(sync.Mutex), relays, new(sync.WaitGroup)}
 	// Add one for initial wait at start up
 	mr.Add(1)
 	return &mr
@@ -76,6 +77,10 @@ func (m *MessageRetriever) Run() {
 }
 
 func (m *MessageRetriever) fetchPointers() {
+	// Relays push what they hold before responding so those messages are marked as retrieved
+	// before we go looking for their pointers
+	m.fetchFromRelays()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	wg := new(sync.WaitGroup)
@@ -141,6 +146,41 @@ func (m *MessageRetriever) fetchPointers() {
 	}
 }
 
+func (m *MessageRetriever) fetchFromRelays() {
+	for _, relay := range m.relays {
+		ctx, cancel := context.WithCancel(context.Background())
+		resp, err := m.service.SendRequest(ctx, relay, &pb.Message{MessageType: pb.Message_RELAY_REGISTER})
+		cancel()
+		if err != nil {
+			log.Errorf("Error fetching offline messages from relay %s: %s", relay.Pretty(), err.Error())
+			continue
+		}
+		if resp.MessageType == pb.Message_ERROR && resp.Payload != nil {
+			log.Errorf("Relay %s refused registration: %s", relay.Pretty(), string(resp.Payload.Value))
+		}
+	}
+}
+
+// Process a message one of our relays pushed to us. The address is where the sender stored the
+// same ciphertext so it's marked as retrieved like a message we downloaded ourselves.
+func (m *MessageRetriever) HandleRelayedMessage(rm *pb.RelayedMessage) error {
+	pid, err := peer.IDB58Decode(rm.PointerID)
+	if err != nil {
+		return err
+	}
+	addr, err := ma.NewMultiaddr(rm.Address)
+	if err != nil {
+		return err
+	}
+	if m.db.OfflineMessages().Has(addr.String()) {
+		return nil
+	}
+	m.db.OfflineMessages().Put(addr.String())
+	m.attemptDecrypt(rm.Ciphertext, pid, addr)
+	m.processQueue()
+	return nil
+}
+
 func (m *MessageRetriever) getPointersFromGateway() <-chan ps.PeerInfo {
 	peerOut := make(chan ps.PeerInfo, 100000)
 	go m.getPointersFromGatewayRoutine(peerOut)
@@ -286,6 +326,8 @@ func (m *MessageRetriever) queueMessage(env pb.Envelope, addr string) {
 }
 
 func (m *MessageRetriever) processQueue() {
+	m.queueLock.Lock()
+	defer m.queueLock.Unlock()
 	processMessages := func(queue []offlineMessage) {
 		for _, om := range queue {
 			err := m.handleMessage(om.env, nil)
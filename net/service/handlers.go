@@ -12,11 +12,11 @@ import (
 	"github.com/OpenBazaar/openbazaar-go/core"
 	"github.com/OpenBazaar/openbazaar-go/net"
 	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
-	hd "github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
@@ -34,6 +34,10 @@ func (service *OpenBazaarService) HandlerForMsgType(t pb.Message_MessageType) fu
 		return service.handleOfflineAck
 	case pb.Message_OFFLINE_RELAY:
 		return service.handleOfflineRelay
+	case pb.Message_RELAY_REGISTER:
+		return service.handleRelayRegister
+	case pb.Message_RELAY_STORE:
+		return service.handleRelayStore
 	case pb.Message_ORDER:
 		return service.handleOrder
 	case pb.Message_ORDER_CONFIRMATION:
@@ -60,6 +64,8 @@ func (service *OpenBazaarService) HandlerForMsgType(t pb.Message_MessageType) fu
 		return service.handleModeratorAdd
 	case pb.Message_MODERATOR_REMOVE:
 		return service.handleModeratorRemove
+	case pb.Message_BID:
+		return service.handleBid
 	default:
 		return nil
 	}
@@ -67,7 +73,22 @@ func (service *OpenBazaarService) HandlerForMsgType(t pb.Message_MessageType) fu
 
 func (service *OpenBazaarService) handlePing(peer peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
 	log.Debugf("Received PING message from %s", peer.Pretty())
-	return pmes, nil
+	// Older nodes send an empty PING and will simply ignore the capabilities in our response
+	var c *pb.Capabilities
+	if pmes.Payload != nil {
+		c = new(pb.Capabilities)
+		if err := ptypes.UnmarshalAny(pmes.Payload, c); err != nil {
+			c = nil
+		}
+	}
+	if _, err := service.node.RecordCapabilities(peer, c); err != nil {
+		log.Error(err)
+	}
+	a, err := ptypes.MarshalAny(service.node.LocalCapabilities())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Message{MessageType: pb.Message_PING, Payload: a}, nil
 }
 
 func (service *OpenBazaarService) handleFollow(peer peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
@@ -106,7 +127,7 @@ func (service *OpenBazaarService) handleOfflineAck(p peer.ID, pmes *pb.Message,
 	if pointer.CancelID == nil || pointer.CancelID.Pretty() != p.Pretty() {
 		return nil, errors.New("Peer is not authorized to delete pointer")
 	}
-	err = service.datastore.Pointers().Delete(pid)
+	err = service.node.OfflineMessageReceived(pid, repo.OutboxAcknowledged)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +136,23 @@ func (service *OpenBazaarService) handleOfflineAck(p peer.ID, pmes *pb.Message,
 }
 
 func (service *OpenBazaarService) handleOfflineRelay(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	if pmes.Payload == nil {
+		return nil, errors.New("Payload is nil")
+	}
+
+	// Messages pushed by one of our relays go through the retriever like any other offline message
+	rm := new(pb.RelayedMessage)
+	if err := ptypes.UnmarshalAny(pmes.Payload, rm); err == nil {
+		if !service.node.IsOfflineRelay(p) {
+			return nil, errors.New("Peer is not one of our relays")
+		}
+		if service.node.MessageRetriever == nil {
+			return nil, errors.New("Message retriever is not running")
+		}
+		log.Debugf("Received relayed message from %s", p.Pretty())
+		return nil, service.node.MessageRetriever.HandleRelayedMessage(rm)
+	}
+
 	// This acts very similarly to attemptDecrypt&handleMessage in the Offline Message Retreiver
 	// However it does not send an ACK, or worry about message ordering
 
@@ -167,6 +205,44 @@ func (service *OpenBazaarService) handleOfflineRelay(p peer.ID, pmes *pb.Message
 	return nil, nil
 }
 
+func relayErrorResponse(err error) *pb.Message {
+	return &pb.Message{
+		MessageType: pb.Message_ERROR,
+		Payload:     &any.Any{Value: []byte(err.Error())},
+	}
+}
+
+func (service *OpenBazaarService) handleRelayRegister(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	err := service.node.RegisterRelayRecipient(p)
+	if err != nil {
+		return relayErrorResponse(err), nil
+	}
+	// Deliver what we're holding before responding so the peer has it before it checks the DHT
+	err = service.node.PushRelayedMessages(p)
+	if err != nil {
+		log.Errorf("Error pushing relayed messages to %s: %s", p.Pretty(), err.Error())
+	}
+	log.Debugf("Received RELAY_REGISTER message from %s", p.Pretty())
+	return &pb.Message{MessageType: pb.Message_RELAY_REGISTER}, nil
+}
+
+func (service *OpenBazaarService) handleRelayStore(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	if pmes.Payload == nil {
+		return relayErrorResponse(errors.New("Payload is nil")), nil
+	}
+	rm := new(pb.RelayedMessage)
+	err := ptypes.UnmarshalAny(pmes.Payload, rm)
+	if err != nil {
+		return relayErrorResponse(errors.New("Could not unmarshal relayed message")), nil
+	}
+	err = service.node.StoreRelayedMessage(p, rm)
+	if err != nil {
+		return relayErrorResponse(err), nil
+	}
+	log.Debugf("Received RELAY_STORE message from %s", p.Pretty())
+	return &pb.Message{MessageType: pb.Message_RELAY_STORE}, nil
+}
+
 func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
 	offline, _ := options.(bool)
 	errorResponse := func(error string) *pb.Message {
@@ -437,25 +513,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 		if err != nil {
 			return nil, err
 		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := service.node.Wallet.MasterPrivateKey()
-		if err != nil {
-			return nil, err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return nil, err
-		}
-		hdKey := hd.NewExtendedKey(
-			service.node.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
-
-		buyerKey, err := hdKey.Child(0)
+		buyerKey, err := service.node.EscrowKey(chaincode)
 		if err != nil {
 			return nil, err
 		}
@@ -499,25 +557,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 		if err != nil {
 			return nil, err
 		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := service.node.Wallet.MasterPrivateKey()
-		if err != nil {
-			return nil, err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return nil, err
-		}
-		hdKey := hd.NewExtendedKey(
-			service.node.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
-
-		buyerKey, err := hdKey.Child(0)
+		buyerKey, err := service.node.EscrowKey(chaincode)
 		if err != nil {
 			return nil, err
 		}
@@ -575,6 +615,10 @@ func (service *OpenBazaarService) handleRefund(p peer.ID, pmes *pb.Message, opti
 		return nil, net.OutOfOrderMessage
 	}
 
+	if err := service.node.ValidateRefund(contract, rc.Refund, records); err != nil {
+		return nil, err
+	}
+
 	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
 		var ins []spvwallet.TransactionInput
 		var outValue int64
@@ -590,41 +634,21 @@ func (service *OpenBazaarService) handleRefund(p peer.ID, pmes *pb.Message, opti
 			}
 		}
 
-		refundAddress, err := service.node.Wallet.DecodeAddress(contract.BuyerOrder.RefundAddress)
-		if err != nil {
-			return nil, err
+		// Refunds from older vendors don't record an amount and always return everything
+		refund := rc.Refund
+		if refund.Amount == 0 {
+			refund = &pb.Refund{Amount: uint64(outValue)}
 		}
-		var output spvwallet.TransactionOutput
-		outputScript, err := service.node.Wallet.AddressToScript(refundAddress)
+		outputs, err := service.node.RefundOutputs(contract, refund, outValue)
 		if err != nil {
 			return nil, err
 		}
-		output.ScriptPubKey = outputScript
-		output.Value = outValue
 
 		chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
 		if err != nil {
 			return nil, err
 		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := service.node.Wallet.MasterPrivateKey()
-		if err != nil {
-			return nil, err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return nil, err
-		}
-		hdKey := hd.NewExtendedKey(
-			service.node.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
-
-		buyerKey, err := hdKey.Child(0)
+		buyerKey, err := service.node.EscrowKey(chaincode)
 		if err != nil {
 			return nil, err
 		}
@@ -633,7 +657,7 @@ func (service *OpenBazaarService) handleRefund(p peer.ID, pmes *pb.Message, opti
 			return nil, err
 		}
 
-		buyerSignatures, err := service.node.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, buyerKey, redeemScript, contract.BuyerOrder.RefundFee)
+		buyerSignatures, err := service.node.Wallet.CreateMultisigSignature(ins, outputs, buyerKey, redeemScript, contract.BuyerOrder.RefundFee)
 		if err != nil {
 			return nil, err
 		}
@@ -642,20 +666,31 @@ func (service *OpenBazaarService) handleRefund(p peer.ID, pmes *pb.Message, opti
 			sig := spvwallet.Signature{InputIndex: s.InputIndex, Signature: s.Signature}
 			vendorSignatures = append(vendorSignatures, sig)
 		}
-		_, err = service.node.Wallet.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.BuyerOrder.RefundFee, true)
+		_, err = service.node.Wallet.Multisign(ins, outputs, buyerSignatures, vendorSignatures, redeemScript, contract.BuyerOrder.RefundFee, true)
 		if err != nil {
 			return nil, err
 		}
 	}
 	contract.Refund = rc.Refund
+	var sigs []*pb.Signature
 	for _, sig := range contract.Signatures {
+		if sig.Section != pb.Signature_REFUND {
+			sigs = append(sigs, sig)
+		}
+	}
+	for _, sig := range rc.Signatures {
 		if sig.Section == pb.Signature_REFUND {
-			contract.Signatures = append(contract.Signatures, sig)
+			sigs = append(sigs, sig)
 		}
 	}
+	contract.Signatures = sigs
 
-	// Set message state to refunded
-	service.datastore.Purchases().Put(contract.Refund.OrderID, *contract, pb.OrderState_REFUNDED, false)
+	// Set message state to refunded, or partially refunded if the vendor is still holding funds
+	state := pb.OrderState_REFUNDED
+	if contract.Refund.Partial {
+		state = pb.OrderState_PARTIALLY_REFUNDED
+	}
+	service.datastore.Purchases().Put(contract.Refund.OrderID, *contract, state, false)
 
 	var thumbnailTiny string
 	var thumbnailSmall string
@@ -985,7 +1020,7 @@ func (service *OpenBazaarService) handleChat(p peer.ID, pmes *pb.Message, option
 			PeerId:  p.Pretty(),
 			Subject: chat.Subject,
 		}
-		service.broadcast <- notifications.Serialize(n)
+		service.broadcast <- n
 		return nil, nil
 	}
 	if chat.Flag == pb.Chat_READ {
@@ -1079,3 +1114,58 @@ func (service *OpenBazaarService) handleModeratorRemove(peer peer.ID, pmes *pb.M
 
 	return nil, nil
 }
+
+func (service *OpenBazaarService) handleBid(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	offline, _ := options.(bool)
+	errorResponse := func(error string) *pb.Message {
+		a := &any.Any{Value: []byte(error)}
+		m := &pb.Message{
+			MessageType: pb.Message_ERROR,
+			Payload:     a,
+		}
+		return m
+	}
+	// Bids must be accepted or rejected while the bidder waits so we can't process them offline
+	if offline {
+		return nil, errors.New("Bids cannot be sent as offline messages")
+	}
+	if pmes.Payload == nil {
+		return nil, errors.New("Payload is nil")
+	}
+	bid := new(pb.SignedBid)
+	err := ptypes.UnmarshalAny(pmes.Payload, bid)
+	if err != nil {
+		return errorResponse("Could not unmarshal bid"), err
+	}
+	if bid.Bid == nil || bid.Bid.BuyerID == nil || bid.Bid.BuyerID.PeerID != p.Pretty() {
+		return errorResponse("Bid was not sent by the bidder"), nil
+	}
+
+	service.bidLock.Lock()
+	defer service.bidLock.Unlock()
+	err = service.node.ValidateBid(bid)
+	if err != nil {
+		log.Error(err)
+		return errorResponse(err.Error()), nil
+	}
+	err = service.datastore.Bids().Put(bid)
+	if err != nil {
+		log.Error(err)
+		return errorResponse("Error saving bid"), nil
+	}
+
+	var title, thumbnailTiny, thumbnailSmall string
+	sl, err := service.node.GetListingFromSlug(bid.Bid.Slug)
+	if err == nil {
+		title = sl.Listing.Item.Title
+		if len(sl.Listing.Item.Images) > 0 {
+			thumbnailTiny = sl.Listing.Item.Images[0].Tiny
+			thumbnailSmall = sl.Listing.Item.Images[0].Small
+		}
+	}
+	n := notifications.BidNotification{"bid", bid.Bid.Slug, title, p.Pretty(), bid.Bid.Amount, notifications.Thumbnail{thumbnailTiny, thumbnailSmall}}
+	service.broadcast <- n
+	service.datastore.Notifications().Put(n, n.Type, time.Now())
+	log.Debugf("Received BID message from %s", p.Pretty())
+	return pmes, nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	inet "gx/ipfs/QmRscs8KxrSmSv4iuevHv8JfuUzHBMoqiaHzxfDRiksd6e/go-libp2p-net"
 	host "gx/ipfs/QmUywuGNZoUKV8B9iyvup9bPkLiMrhTsyVMkeSXW5VxAfC/go-libp2p-host"
 	ps "gx/ipfs/QmXZSd1qR5BxZkPyuwfT5jpqQFScZccoZvDneXsKzCNHWX/go-libp2p-peerstore"
@@ -16,6 +17,7 @@ import (
 	"github.com/OpenBazaar/openbazaar-go/core"
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/ipfs/go-ipfs/commands"
 	ctxio "github.com/jbenet/go-context/io"
 	"github.com/op/go-logging"
@@ -36,6 +38,7 @@ type OpenBazaarService struct {
 	node      *core.OpenBazaarNode
 	sender    map[peer.ID]*messageSender
 	senderlk  sync.Mutex
+	bidLock   sync.Mutex
 }
 
 func New(node *core.OpenBazaarNode, ctx commands.Context, datastore repo.Datastore) *OpenBazaarService {
@@ -117,16 +120,19 @@ func (service *OpenBazaarService) handleNewMessage(s inet.Stream, incoming bool)
 
 		// Get handler for this msg type
 		handler := service.HandlerForMsgType(pmes.MessageType)
+		var rpmes *pb.Message
+		var err error
 		if handler == nil {
-			log.Debug("Got back nil handler from handlerForMsgType")
-			continue
-		}
-
-		// Dispatch handler
-		rpmes, err := handler(mPeer, pmes, nil)
-		if err != nil {
-			log.Debugf("handle message error: %s", err)
-			continue
+			// Tell the sender we don't understand the message rather than leaving it to time out
+			log.Debugf("Received unsupported %s message from %s", pmes.MessageType.String(), mPeer.Pretty())
+			rpmes = unsupportedMessageResponse(pmes.MessageType)
+		} else {
+			// Dispatch handler
+			rpmes, err = handler(mPeer, pmes, nil)
+			if err != nil {
+				log.Debugf("handle message error: %s", err)
+				continue
+			}
 		}
 
 		// If nil response, return it before serializing
@@ -146,6 +152,11 @@ func (service *OpenBazaarService) handleNewMessage(s inet.Stream, incoming bool)
 	}
 }
 
+func unsupportedMessageResponse(t pb.Message_MessageType) *pb.Message {
+	msg := fmt.Sprintf("Unsupported message type %s, this node speaks protocol version %d", t.String(), core.ProtocolVersion)
+	return &pb.Message{MessageType: pb.Message_ERROR, Payload: &any.Any{Value: []byte(msg)}}
+}
+
 func (service *OpenBazaarService) SendRequest(ctx context.Context, p peer.ID, pmes *pb.Message) (*pb.Message, error) {
 	log.Debugf("Sending %s request to %s", pmes.MessageType.String(), p.Pretty())
 	ms := service.messageSenderForPeer(p, nil)
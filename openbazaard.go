@@ -26,6 +26,7 @@ import (
 
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	bstk "github.com/OpenBazaar/go-blockstackclient"
 	"github.com/OpenBazaar/openbazaar-go/api"
 	"github.com/OpenBazaar/openbazaar-go/bitcoin"
@@ -42,10 +43,12 @@ import (
 	"github.com/OpenBazaar/openbazaar-go/repo/db"
 	sto "github.com/OpenBazaar/openbazaar-go/storage"
 	"github.com/OpenBazaar/openbazaar-go/storage/dropbox"
+	"github.com/OpenBazaar/openbazaar-go/storage/s3"
 	"github.com/OpenBazaar/openbazaar-go/storage/selfhosted"
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/base58"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/fatih/color"
 	"github.com/ipfs/go-ipfs/commands"
 	ipfscore "github.com/ipfs/go-ipfs/core"
@@ -62,6 +65,7 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/natefinch/lumberjack"
 	"github.com/op/go-logging"
+	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/ssh/terminal"
 	"golang.org/x/net/proxy"
 	"gx/ipfs/QmPsBptED6X43GYg3347TAUruN3UfsAhaGTP9xbinYX7uf/go-libp2p-interface-pnet"
@@ -98,6 +102,19 @@ type Init struct {
 	Testnet            bool   `short:"t" long:"testnet" description:"use the test network"`
 	Force              bool   `short:"f" long:"force" description:"force overwrite existing repo (dangerous!)"`
 	WalletCreationDate string `short:"w" long:"walletcreationdate" description:"specify the date the seed was created. if omitted the wallet will sync from the oldest checkpoint."`
+	WatchOnly          string `long:"watchonly" description:"initialize a watch-only node from the key file created by the watchonlykey command"`
+}
+type WatchOnlyKey struct {
+	Mnemonic string `short:"m" long:"mnemonic" description:"the mnemonic seed of the wallet. if omitted it is read from stdin"`
+	Testnet  bool   `short:"t" long:"testnet" description:"use the test network"`
+	Output   string `short:"o" long:"output" description:"write the key file here instead of to stdout"`
+}
+type SignTx struct {
+	Mnemonic string `short:"m" long:"mnemonic" description:"the mnemonic seed of the wallet. if omitted it is read from stdin"`
+	Testnet  bool   `short:"t" long:"testnet" description:"use the test network"`
+	Input    string `short:"i" long:"input" description:"the unsigned transaction file exported by the watch-only node"`
+	Output   string `short:"o" long:"output" description:"write the signed transaction here instead of to stdout"`
+	Yes      bool   `short:"y" long:"yes" description:"sign without asking for confirmation"`
 }
 type Status struct {
 	DataDir string `short:"d" long:"datadir" description:"specify the data directory to be used"`
@@ -122,7 +139,8 @@ type Start struct {
 	DualStack            bool     `long:"dualstack" description:"Automatically configure the daemon to run as a Tor hidden service IN ADDITION to using the clear internet. Requires Tor to be running. WARNING: this mode is not private"`
 	DisableWallet        bool     `long:"disablewallet" description:"disable the wallet functionality of the node"`
 	DisableExchangeRates bool     `long:"disableexchangerates" description:"disable the exchange rate service to prevent api queries"`
-	Storage              string   `long:"storage" description:"set the outgoing message storage option [self-hosted, dropbox] default=self-hosted"`
+	ExchangeRateMaxAge   int      `long:"exchangeratemaxage" description:"refuse to price orders with exchange rates older than this many minutes default=60"`
+	Storage              string   `long:"storage" description:"set the outgoing message storage option [self-hosted, dropbox, s3] default=self-hosted"`
 }
 type Opts struct {
 	Version bool `short:"v" long:"version" description:"Print the version number and exit"`
@@ -138,6 +156,8 @@ var stopServer Stop
 var restartServer Restart
 var encryptDatabase EncryptDatabase
 var decryptDatabase DecryptDatabase
+var watchOnlyKey WatchOnlyKey
+var signTx SignTx
 var setAPICreds SetAPICreds
 var status Status
 var opts Opts
@@ -195,6 +215,14 @@ func main() {
 		"decrypt your database",
 		"This command decrypts the database containing your bitcoin private keys, identity key, and contracts.\n [Warning] doing so may put your bitcoins at risk.",
 		&decryptDatabase)
+	parser.AddCommand("watchonlykey",
+		"export the keys for a watch-only node",
+		"Creates the key file used to initialize a watch-only node from the mnemonic seed. It holds only the public bitcoin keys. Run this on the offline machine.",
+		&watchOnlyKey)
+	parser.AddCommand("signtx",
+		"sign a transaction exported by a watch-only node",
+		"Signs a transaction exported by a watch-only node with the mnemonic seed. The outputs, fee or peer ID being signed are shown and must be confirmed unless --yes is given. Import the output into the node to broadcast it. Run this on the offline machine.",
+		&signTx)
 	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
 		fmt.Println(core.VERSION)
 		return
@@ -357,6 +385,18 @@ func (x *Init) Execute(args []string) error {
 		}
 	}
 
+	if x.WatchOnly != "" {
+		if x.Mnemonic != "" {
+			return errors.New("A watch-only node cannot be initialized with a mnemonic")
+		}
+		_, err = initializeWatchOnlyRepo(repoPath, x.Password, x.WatchOnly, x.Testnet, creationDate)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("OpenBazaar watch-only repo initialized at %s\n", repoPath)
+		return nil
+	}
+
 	_, err = initializeRepo(repoPath, x.Password, x.Mnemonic, x.Testnet, creationDate)
 	if err == repo.ErrRepoExists && x.Force {
 		reader := bufio.NewReader(os.Stdin)
@@ -496,6 +536,16 @@ func (x *Start) Execute(args []string) error {
 		log.Error(err)
 		return err
 	}
+	offlineRelayStrings, err := repo.GetOfflineRelays(configFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	relayConfig, err := repo.GetRelayConfig(configFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
 
 	// IPFS node setup
 	r, err := fsrepo.Open(repoPath)
@@ -714,6 +764,19 @@ func (x *Start) Execute(args []string) error {
 		log.Error(err)
 		return err
 	}
+	var watchOnly *bitcoin.WatchOnlyKey
+	watchOnlyKey, err := sqliteDB.Config().GetWatchOnlyKey()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if watchOnlyKey != nil {
+		watchOnly = new(bitcoin.WatchOnlyKey)
+		if err := json.Unmarshal(watchOnlyKey, watchOnly); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
 	var params chaincfg.Params
 	if x.Testnet {
 		params = chaincfg.TestNet3Params
@@ -767,12 +830,27 @@ func (x *Start) Execute(args []string) error {
 			Proxy:        torDialer,
 			Logger:       ml,
 		}
-		wallet, err = spvwallet.NewSPVWallet(spvwalletConfig)
+		if watchOnly != nil {
+			spvwalletConfig.MasterPublicKey, spvwalletConfig.AccountPublicKey, err = watchOnly.Keys()
+			if err != nil {
+				log.Error(err)
+				return err
+			}
+		}
+		spv, err := spvwallet.NewSPVWallet(spvwalletConfig)
 		if err != nil {
 			log.Error(err)
 			return err
 		}
+		wallet = spv
+		if watchOnly != nil {
+			log.Notice("Running a watch-only wallet. Transactions must be signed offline.")
+			wallet = core.NewWatchOnlyWallet(spv, sqliteDB.UnsignedTransactions())
+		}
 	case "bitcoind":
+		if watchOnly != nil {
+			return errors.New("Watch-only nodes must use the spvwallet")
+		}
 		if walletCfg.Binary == "" {
 			return errors.New("The path to the bitcoind binary must be specified in the config file when using bitcoind")
 		}
@@ -798,6 +876,17 @@ func (x *Start) Execute(args []string) error {
 		}
 	}
 
+	// Offline message relays
+	var offlineRelays []peer.ID
+	for _, relay := range offlineRelayStrings {
+		id, err := peer.IDB58Decode(relay)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		offlineRelays = append(offlineRelays, id)
+	}
+
 	// Authenticated gateway
 	gatewayMaddr, err := ma.NewMultiaddr(cfg.Addresses.Gateway)
 	if err != nil {
@@ -873,6 +962,18 @@ func (x *Start) Execute(args []string) error {
 			log.Error(err)
 			return err
 		}
+	} else if x.Storage == "s3" {
+		s3Cfg, err := repo.GetS3Config(configFile)
+		if err != nil {
+			err = errors.New("S3 storage not set in config file")
+			log.Error(err)
+			return err
+		}
+		storage, err = s3.NewS3Storage(s3Cfg, torDialer)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
 	} else {
 		err = errors.New("Invalid storage option")
 		log.Error(err)
@@ -881,7 +982,7 @@ func (x *Start) Execute(args []string) error {
 
 	var exchangeRates bitcoin.ExchangeRates
 	if !x.DisableExchangeRates {
-		exchangeRates = exchange.NewBitcoinPriceFetcher(torDialer)
+		exchangeRates = exchange.NewBitcoinPriceFetcher(torDialer, time.Duration(x.ExchangeRateMaxAge)*time.Minute, sqliteDB.ExchangeRateHistory())
 	}
 
 	// Set up the ban manager
@@ -909,11 +1010,13 @@ func (x *Start) Execute(args []string) error {
 		RootHash:          ipath.Path(e.Value).String(),
 		RepoPath:          repoPath,
 		Datastore:         sqliteDB,
-		Wallet:            wallet,
+		Wallet:            core.NewAuditedWallet(core.NewCoinControlWallet(wallet, sqliteDB.FrozenCoins()), sqliteDB.AuditLog()),
 		MessageStorage:    storage,
 		Resolver:          bstk.NewBlockStackClient(resolverUrl, torDialer),
 		ExchangeRates:     exchangeRates,
 		CrosspostGateways: gatewayUrls,
+		OfflineRelays:     offlineRelays,
+		RelayConfig:       relayConfig,
 		TorDialer:         torDialer,
 		UserAgent:         core.USERAGENT,
 		BanManager:        bm,
@@ -934,12 +1037,17 @@ func (x *Start) Execute(args []string) error {
 
 	go func() {
 		core.Node.Service = service.New(core.Node, ctx, sqliteDB)
-		MR := ret.NewMessageRetriever(sqliteDB, ctx, nd, bm, core.Node.Service, 14, torDialer, core.Node.CrosspostGateways, core.Node.SendOfflineAck)
+		core.Node.StartRelay()
+		MR := ret.NewMessageRetriever(sqliteDB, ctx, nd, bm, core.Node.Service, 14, torDialer, core.Node.CrosspostGateways, core.Node.OfflineRelays, core.Node.SendOfflineAck)
 		go MR.Run()
 		core.Node.MessageRetriever = MR
 		PR := rep.NewPointerRepublisher(nd, sqliteDB, core.Node.IsModerator)
 		go PR.Run()
 		core.Node.PointerRepublisher = PR
+		MC := sto.NewMessageCollector(sqliteDB, storage)
+		go MC.Run()
+		core.Node.MessageCollector = MC
+		go core.Node.UpdateTagPointers()
 		if !x.DisableWallet {
 			MR.Wait()
 			TL := lis.NewTransactionListener(core.Node.Datastore, core.Node.Broadcast, core.Node.Wallet)
@@ -948,8 +1056,12 @@ func (x *Start) Execute(args []string) error {
 			wallet.AddTransactionListener(WL.OnTransactionReceived)
 			log.Info("Starting bitcoin wallet")
 			su := bitcoin.NewStatusUpdater(wallet, core.Node.Broadcast, nd.Context())
+			su.AddBalanceListener(core.Node.OnWalletBalance)
 			go su.Start()
 			go wallet.Start()
+			go core.Node.RunCrowdFundSettler()
+			go core.Node.RunEscrowTimeoutNotifier()
+			go core.Node.RunOrderCompleter()
 		}
 		core.Node.UpdateFollow()
 		core.Node.SeedNode()
@@ -979,6 +1091,163 @@ func initializeRepo(dataDir, password, mnemonic string, testnet bool, creationDa
 	return sqliteDB, nil
 }
 
+func initializeWatchOnlyRepo(dataDir, password, keyFile string, testnet bool, creationDate time.Time) (*db.SQLiteDatastore, error) {
+	b, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	var key bitcoin.WatchOnlyKey
+	if err := json.Unmarshal(b, &key); err != nil {
+		return nil, err
+	}
+	if _, _, err := key.Keys(); err != nil {
+		return nil, err
+	}
+	// The identity is our own, the offline wallet only signs its peer ID
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	fmt.Print("Generating Ed25519 keypair...")
+	identityKey, err := ipfs.IdentityKeyFromSeed(seed, 4096)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Print("Done\n")
+	identity, err := ipfs.IdentityFromKey(identityKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteDB, err := db.Create(dataDir, password, testnet)
+	if err != nil {
+		return sqliteDB, err
+	}
+	err = repo.DoInitWatchOnly(dataDir, testnet, password, identityKey, creationDate, sqliteDB.Config().Init)
+	if err != nil {
+		return sqliteDB, err
+	}
+
+	// A signature in the key file would be for some other peer ID
+	key.PeerIDSignature = ""
+	ser, err := json.Marshal(key)
+	if err != nil {
+		return sqliteDB, err
+	}
+	if err := sqliteDB.Config().SetWatchOnlyKey(ser); err != nil {
+		return sqliteDB, err
+	}
+	req := bitcoin.NewPeerIDSigningRequest(identity.PeerID)
+	pkg, err := json.Marshal(req)
+	if err != nil {
+		return sqliteDB, err
+	}
+	err = sqliteDB.UnsignedTransactions().Put(repo.UnsignedTransaction{
+		ID:        req.ID,
+		Intent:    bitcoin.PeerIDSigningType,
+		Timestamp: time.Now(),
+		Package:   pkg,
+	})
+	if err != nil {
+		return sqliteDB, err
+	}
+	fmt.Printf("Peer ID %s must be signed by the offline wallet before listings or orders can be made. Export unsigned transaction %s, sign it with the signtx command and import it.\n", identity.PeerID, req.ID)
+	return sqliteDB, nil
+}
+
+func (x *WatchOnlyKey) Execute(args []string) error {
+	masterKey, err := offlineMasterKey(x.Mnemonic, x.Testnet)
+	if err != nil {
+		return err
+	}
+	key, err := bitcoin.NewWatchOnlyKey(masterKey)
+	if err != nil {
+		return err
+	}
+	ser, err := json.MarshalIndent(key, "", "    ")
+	if err != nil {
+		return err
+	}
+	return writeOfflineOutput(x.Output, ser)
+}
+
+func (x *SignTx) Execute(args []string) error {
+	if x.Input == "" {
+		return errors.New("The unsigned transaction file must be specified")
+	}
+	b, err := ioutil.ReadFile(x.Input)
+	if err != nil {
+		return err
+	}
+	var utx bitcoin.UnsignedTransaction
+	if err := json.Unmarshal(b, &utx); err != nil {
+		return err
+	}
+	params := &chaincfg.MainNetParams
+	if x.Testnet {
+		params = &chaincfg.TestNet3Params
+	}
+
+	// The node which exported the transaction may be compromised so show what is being signed
+	summary, err := utx.Summary(params)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(os.Stderr, summary.String())
+	if !x.Yes {
+		fmt.Fprint(os.Stderr, "Sign? [y/N]: ")
+		resp, _ := offlineInput.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(resp)) != "y" {
+			return errors.New("Not signed")
+		}
+	}
+	masterKey, err := offlineMasterKey(x.Mnemonic, x.Testnet)
+	if err != nil {
+		return err
+	}
+	signed, err := bitcoin.SignTransaction(&utx, masterKey, params)
+	if err != nil {
+		return err
+	}
+	ser, err := json.MarshalIndent(signed, "", "    ")
+	if err != nil {
+		return err
+	}
+	return writeOfflineOutput(x.Output, ser)
+}
+
+// Shared by the offline commands so answers piped to stdin aren't lost between prompts
+var offlineInput = bufio.NewReader(os.Stdin)
+
+// Derive the wallet's master key the same way as the spvwallet. The mnemonic is read from stdin if not given.
+func offlineMasterKey(mnemonic string, testnet bool) (*hd.ExtendedKey, error) {
+	if mnemonic == "" {
+		fmt.Fprint(os.Stderr, "Enter the mnemonic seed: ")
+		resp, _ := offlineInput.ReadString('\n')
+		mnemonic = strings.TrimSpace(resp)
+	}
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("Invalid mnemonic")
+	}
+	params := &chaincfg.MainNetParams
+	if testnet {
+		params = &chaincfg.TestNet3Params
+	}
+	masterKey, err := hd.NewMaster(bip39.NewSeed(mnemonic, ""), params)
+	if err != nil {
+		return nil, err
+	}
+	return masterKey, nil
+}
+
+func writeOfflineOutput(output string, b []byte) error {
+	if output == "" {
+		fmt.Println(string(b))
+		return nil
+	}
+	return ioutil.WriteFile(output, b, 0600)
+}
+
 // Prints the addresses of the host
 func printSwarmAddrs(node *ipfscore.IpfsNode) {
 	var addrs []string
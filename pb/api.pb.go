@@ -12,6 +12,7 @@ It is generated from these files:
 	message.proto
 	moderator.proto
 	orders.proto
+	posts.proto
 	profile.proto
 
 It has these top-level messages:
@@ -19,6 +20,7 @@ It has these top-level messages:
 	OrderRespApi
 	CaseRespApi
 	TransactionRecord
+	FiatValue
 	PeerAndProfile
 	PeerAndProfileWithID
 	RatingWithID
@@ -40,11 +42,17 @@ It has these top-level messages:
 	ID
 	Signature
 	SignedListing
+	Bid
+	SignedBid
 	Message
 	Envelope
+	RelayedMessage
+	Capabilities
 	Chat
 	Moderator
 	DisputeUpdate
+	Post
+	SignedPost
 	Profile
 */
 package pb
@@ -255,6 +263,7 @@ type TransactionRecord struct {
 	Confirmations uint32                     `protobuf:"varint,3,opt,name=confirmations" json:"confirmations,omitempty"`
 	Height        uint32                     `protobuf:"varint,4,opt,name=height" json:"height,omitempty"`
 	Timestamp     *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=timestamp" json:"timestamp,omitempty"`
+	FiatValue     *FiatValue                 `protobuf:"bytes,6,opt,name=fiatValue" json:"fiatValue,omitempty"`
 }
 
 func (m *TransactionRecord) Reset()                    { *m = TransactionRecord{} }
@@ -297,6 +306,54 @@ func (m *TransactionRecord) GetTimestamp() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *TransactionRecord) GetFiatValue() *FiatValue {
+	if m != nil {
+		return m.FiatValue
+	}
+	return nil
+}
+
+// The value of a transaction in the user's local currency at the time it was made
+type FiatValue struct {
+	CurrencyCode  string                     `protobuf:"bytes,1,opt,name=currencyCode" json:"currencyCode,omitempty"`
+	Amount        float64                    `protobuf:"fixed64,2,opt,name=amount" json:"amount,omitempty"`
+	Rate          float64                    `protobuf:"fixed64,3,opt,name=rate" json:"rate,omitempty"`
+	RateTimestamp *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=rateTimestamp" json:"rateTimestamp,omitempty"`
+}
+
+func (m *FiatValue) Reset()                    { *m = FiatValue{} }
+func (m *FiatValue) String() string            { return proto.CompactTextString(m) }
+func (*FiatValue) ProtoMessage()               {}
+func (*FiatValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *FiatValue) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
+func (m *FiatValue) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *FiatValue) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *FiatValue) GetRateTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.RateTimestamp
+	}
+	return nil
+}
+
 type PeerAndProfile struct {
 	PeerId  string   `protobuf:"bytes,1,opt,name=peerId" json:"peerId,omitempty"`
 	Profile *Profile `protobuf:"bytes,2,opt,name=profile" json:"profile,omitempty"`
@@ -305,7 +362,7 @@ type PeerAndProfile struct {
 func (m *PeerAndProfile) Reset()                    { *m = PeerAndProfile{} }
 func (m *PeerAndProfile) String() string            { return proto.CompactTextString(m) }
 func (*PeerAndProfile) ProtoMessage()               {}
-func (*PeerAndProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*PeerAndProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *PeerAndProfile) GetPeerId() string {
 	if m != nil {
@@ -330,7 +387,7 @@ type PeerAndProfileWithID struct {
 func (m *PeerAndProfileWithID) Reset()                    { *m = PeerAndProfileWithID{} }
 func (m *PeerAndProfileWithID) String() string            { return proto.CompactTextString(m) }
 func (*PeerAndProfileWithID) ProtoMessage()               {}
-func (*PeerAndProfileWithID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*PeerAndProfileWithID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *PeerAndProfileWithID) GetId() string {
 	if m != nil {
@@ -362,7 +419,7 @@ type RatingWithID struct {
 func (m *RatingWithID) Reset()                    { *m = RatingWithID{} }
 func (m *RatingWithID) String() string            { return proto.CompactTextString(m) }
 func (*RatingWithID) ProtoMessage()               {}
-func (*RatingWithID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*RatingWithID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *RatingWithID) GetId() string {
 	if m != nil {
@@ -390,6 +447,7 @@ func init() {
 	proto.RegisterType((*OrderRespApi)(nil), "OrderRespApi")
 	proto.RegisterType((*CaseRespApi)(nil), "CaseRespApi")
 	proto.RegisterType((*TransactionRecord)(nil), "TransactionRecord")
+	proto.RegisterType((*FiatValue)(nil), "FiatValue")
 	proto.RegisterType((*PeerAndProfile)(nil), "PeerAndProfile")
 	proto.RegisterType((*PeerAndProfileWithID)(nil), "PeerAndProfileWithID")
 	proto.RegisterType((*RatingWithID)(nil), "RatingWithID")
@@ -398,45 +456,49 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 625 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0x13, 0x31,
-	0x10, 0x56, 0xfe, 0x93, 0x49, 0x13, 0x84, 0x55, 0xa1, 0x55, 0x24, 0x68, 0x58, 0x71, 0xc8, 0x69,
-	0x8b, 0xca, 0xa5, 0xe2, 0x56, 0x52, 0x90, 0x2a, 0x01, 0xad, 0x4c, 0x05, 0x12, 0x9c, 0x9c, 0xf5,
-	0x24, 0xb1, 0x94, 0xd8, 0x2b, 0xdb, 0x5b, 0xd1, 0x97, 0xe1, 0x2d, 0x78, 0x20, 0xde, 0x04, 0xd9,
-	0xeb, 0x4d, 0x13, 0xd2, 0x6d, 0xc5, 0xcd, 0x33, 0xf3, 0xcd, 0x37, 0xb3, 0x33, 0xdf, 0x2c, 0xf4,
-	0x58, 0x26, 0x92, 0x4c, 0x2b, 0xab, 0x46, 0x4f, 0x52, 0x25, 0xad, 0x66, 0xa9, 0x35, 0xc1, 0x71,
-	0xa0, 0x34, 0x47, 0x5d, 0x5a, 0x83, 0x4c, 0xab, 0xb9, 0x58, 0x61, 0x30, 0x8f, 0x16, 0x4a, 0x2d,
-	0x56, 0x78, 0xec, 0xad, 0x59, 0x3e, 0x3f, 0xb6, 0x62, 0x8d, 0xc6, 0xb2, 0x75, 0x56, 0x00, 0xe2,
-	0xd7, 0xd0, 0x9e, 0xaa, 0x3c, 0x53, 0x92, 0x10, 0x68, 0x2e, 0x99, 0x59, 0x46, 0xb5, 0x71, 0x6d,
-	0xd2, 0xa3, 0xfe, 0xed, 0x7c, 0xa9, 0xe2, 0x18, 0xd5, 0x0b, 0x9f, 0x7b, 0xc7, 0x7f, 0xea, 0x70,
-	0x70, 0xe9, 0x4a, 0x52, 0x34, 0xd9, 0x59, 0x26, 0x48, 0x02, 0xdd, 0xb2, 0x27, 0x9f, 0xdc, 0x3f,
-	0x21, 0x09, 0x15, 0x29, 0xd3, 0x5c, 0x30, 0x39, 0x0d, 0x11, 0xba, 0xc1, 0x90, 0x97, 0xd0, 0x32,
-	0x96, 0xd9, 0x82, 0x75, 0x78, 0xd2, 0x4f, 0x3c, 0xdb, 0x17, 0xe7, 0xa2, 0x45, 0xc4, 0xd5, 0xd5,
-	0xc8, 0x78, 0xd4, 0x18, 0xd7, 0x26, 0x5d, 0xea, 0xdf, 0xe4, 0x19, 0xb4, 0xe7, 0xb9, 0xe4, 0xc8,
-	0xa3, 0xa6, 0xf7, 0x06, 0x8b, 0x24, 0x40, 0x72, 0xe9, 0x10, 0xd3, 0x25, 0xb3, 0x9f, 0xd0, 0x18,
-	0xb6, 0x40, 0x13, 0xb5, 0xc6, 0xb5, 0x49, 0x93, 0xde, 0x13, 0x21, 0x14, 0x46, 0x19, 0xbb, 0x5d,
-	0xa3, 0xb4, 0x67, 0x9c, 0x6b, 0x34, 0xe6, 0x5a, 0x33, 0x69, 0x58, 0x6a, 0x85, 0x92, 0x26, 0x6a,
-	0x8f, 0x1b, 0xfe, 0x03, 0xb6, 0x9c, 0x14, 0x53, 0xa5, 0x39, 0x7d, 0x20, 0x8b, 0x7c, 0x86, 0x48,
-	0xa3, 0xeb, 0x67, 0x3f, 0x18, 0x75, 0xc2, 0x48, 0xf6, 0x19, 0x2b, 0x73, 0xe2, 0x5f, 0x4d, 0xe8,
-	0x4f, 0x99, 0xc1, 0x72, 0xc4, 0xa7, 0xd0, 0xdb, 0x2c, 0x2e, 0xcc, 0x78, 0x94, 0x14, 0xab, 0x4d,
-	0xca, 0xd5, 0x26, 0xd7, 0x25, 0x82, 0xde, 0x81, 0xc9, 0x29, 0x0c, 0x66, 0xf9, 0x2d, 0xea, 0x72,
-	0x0f, 0x7e, 0xe8, 0xf7, 0x6f, 0x68, 0x17, 0x48, 0xde, 0xc2, 0xf0, 0x06, 0x25, 0x57, 0x77, 0xa9,
-	0x8d, 0xca, 0xd4, 0x7f, 0x90, 0xe4, 0x1c, 0x9e, 0xef, 0x90, 0x7d, 0x65, 0x2b, 0xc1, 0x99, 0xfb,
-	0xb4, 0xf7, 0x5a, 0x2b, 0x6d, 0xa2, 0xe6, 0xb8, 0x31, 0xe9, 0xd1, 0x87, 0x41, 0xe4, 0x03, 0xbc,
-	0xd8, 0xe5, 0xdd, 0xa3, 0x69, 0x79, 0x9a, 0x47, 0x50, 0x77, 0x82, 0x6b, 0x3f, 0x2a, 0xb8, 0xce,
-	0x96, 0xe0, 0xc6, 0xd0, 0xf7, 0xfd, 0x5d, 0x66, 0x28, 0x91, 0x47, 0x5d, 0x1f, 0xda, 0x76, 0x91,
-	0x43, 0x68, 0xa5, 0x2b, 0x26, 0xd6, 0x51, 0xcf, 0xdf, 0x47, 0x61, 0x54, 0x08, 0x12, 0x2a, 0x05,
-	0x79, 0x02, 0xa0, 0xd1, 0xa8, 0x55, 0xee, 0xe5, 0xd2, 0x0f, 0x43, 0x3e, 0x17, 0x26, 0xcb, 0xad,
-	0x53, 0x40, 0x88, 0xd0, 0x2d, 0x54, 0xfc, 0xbb, 0x06, 0x4f, 0xf7, 0x04, 0xe5, 0xbe, 0xc2, 0xfe,
-	0x14, 0xbc, 0x3c, 0x61, 0xf7, 0x76, 0x3d, 0xde, 0xb0, 0x55, 0x5e, 0x5c, 0x5b, 0x83, 0x16, 0x06,
-	0x79, 0x05, 0x83, 0x54, 0xc9, 0xb9, 0xd0, 0x6b, 0x56, 0xe8, 0xde, 0xed, 0x76, 0x40, 0x77, 0x9d,
-	0xee, 0xe4, 0x96, 0x28, 0x16, 0x4b, 0xeb, 0x4f, 0x6e, 0x40, 0x83, 0xb5, 0x2b, 0xc7, 0xd6, 0x7f,
-	0xc8, 0x31, 0xfe, 0x08, 0xc3, 0x2b, 0x44, 0x7d, 0x26, 0xf9, 0x55, 0xf1, 0x9f, 0x72, 0x35, 0x32,
-	0x44, 0x7d, 0x51, 0x76, 0x1d, 0x2c, 0x12, 0x43, 0x27, 0xfc, 0xca, 0x82, 0x64, 0xbb, 0x49, 0x48,
-	0xa1, 0x65, 0x20, 0x9e, 0xc1, 0xe1, 0x2e, 0xdb, 0x37, 0x61, 0x97, 0x17, 0xe7, 0x64, 0x08, 0xf5,
-	0xcd, 0x14, 0xea, 0x82, 0x6f, 0xd5, 0xa8, 0x57, 0xd5, 0x68, 0x54, 0xd5, 0xf8, 0x01, 0x07, 0x94,
-	0x59, 0x21, 0x17, 0x15, 0xdc, 0x23, 0xe8, 0x6a, 0x1f, 0xdf, 0xb0, 0x6f, 0x6c, 0x72, 0x04, 0xed,
-	0xe2, 0x1d, 0xe8, 0x3b, 0x49, 0x41, 0x45, 0x83, 0xfb, 0x5d, 0xf3, 0x7b, 0x3d, 0x9b, 0xcd, 0xda,
-	0x7e, 0x66, 0x6f, 0xfe, 0x06, 0x00, 0x00, 0xff, 0xff, 0x3d, 0x96, 0xc3, 0x9b, 0xe6, 0x05, 0x00,
-	0x00,
+	// 695 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4b, 0x6b, 0x1b, 0x49,
+	0x10, 0x46, 0x6f, 0xa9, 0xf4, 0x58, 0xb6, 0x31, 0xcb, 0x20, 0xd8, 0xb5, 0x76, 0xd8, 0x83, 0x4e,
+	0xe3, 0x45, 0xb9, 0x98, 0x9c, 0xe2, 0xc8, 0x31, 0x18, 0x92, 0xd8, 0x74, 0x4c, 0x02, 0xc9, 0xa9,
+	0x35, 0xdd, 0x92, 0x1a, 0xa4, 0xee, 0xa1, 0xbb, 0xc7, 0xc4, 0x3f, 0x26, 0xf9, 0x6b, 0x21, 0xff,
+	0x24, 0xf4, 0x63, 0x46, 0x9a, 0x28, 0xb2, 0xc9, 0x69, 0xaa, 0xbe, 0xaa, 0xfa, 0xaa, 0xba, 0x1e,
+	0x03, 0x3d, 0x92, 0xf1, 0x24, 0x53, 0xd2, 0xc8, 0xf1, 0x1f, 0xa9, 0x14, 0x46, 0x91, 0xd4, 0xe8,
+	0x00, 0x0c, 0xa4, 0xa2, 0x4c, 0x15, 0xda, 0x30, 0x53, 0x72, 0xc9, 0x37, 0x2c, 0xa8, 0xa7, 0x2b,
+	0x29, 0x57, 0x1b, 0x76, 0xe6, 0xb4, 0x45, 0xbe, 0x3c, 0x33, 0x7c, 0xcb, 0xb4, 0x21, 0xdb, 0xcc,
+	0x3b, 0xc4, 0xff, 0x43, 0x7b, 0x2e, 0xf3, 0x4c, 0x0a, 0x84, 0xa0, 0xb9, 0x26, 0x7a, 0x1d, 0xd5,
+	0x26, 0xb5, 0x69, 0x0f, 0x3b, 0xd9, 0x62, 0xa9, 0xa4, 0x2c, 0xaa, 0x7b, 0xcc, 0xca, 0xf1, 0xf7,
+	0x3a, 0x0c, 0x6e, 0x6c, 0x4a, 0xcc, 0x74, 0x76, 0x91, 0x71, 0x94, 0x40, 0xb7, 0xa8, 0xc9, 0x05,
+	0xf7, 0x67, 0x28, 0xc1, 0x3c, 0x25, 0x8a, 0x72, 0x22, 0xe6, 0xc1, 0x82, 0x4b, 0x1f, 0xf4, 0x2f,
+	0xb4, 0xb4, 0x21, 0xc6, 0xb3, 0x8e, 0x66, 0xfd, 0xc4, 0xb1, 0xbd, 0xb3, 0x10, 0xf6, 0x16, 0x9b,
+	0x57, 0x31, 0x42, 0xa3, 0xc6, 0xa4, 0x36, 0xed, 0x62, 0x27, 0xa3, 0xbf, 0xa0, 0xbd, 0xcc, 0x05,
+	0x65, 0x34, 0x6a, 0x3a, 0x34, 0x68, 0x28, 0x01, 0x94, 0x0b, 0xeb, 0x31, 0x5f, 0x13, 0xf3, 0x86,
+	0x69, 0x4d, 0x56, 0x4c, 0x47, 0xad, 0x49, 0x6d, 0xda, 0xc4, 0xbf, 0xb0, 0x20, 0x0c, 0xe3, 0x8c,
+	0x3c, 0x6c, 0x99, 0x30, 0x17, 0x94, 0x2a, 0xa6, 0xf5, 0x9d, 0x22, 0x42, 0x93, 0xd4, 0x70, 0x29,
+	0x74, 0xd4, 0x9e, 0x34, 0xdc, 0x03, 0xf6, 0x40, 0xcc, 0x52, 0xa9, 0x28, 0x7e, 0x24, 0x0a, 0xbd,
+	0x85, 0x48, 0x31, 0x5b, 0xcf, 0xa1, 0x31, 0xea, 0x84, 0x96, 0x1c, 0x32, 0x1e, 0x8d, 0x89, 0xbf,
+	0x36, 0xa1, 0x3f, 0x27, 0x9a, 0x15, 0x2d, 0x3e, 0x87, 0x5e, 0x39, 0xb8, 0xd0, 0xe3, 0x71, 0xe2,
+	0x47, 0x9b, 0x14, 0xa3, 0x4d, 0xee, 0x0a, 0x0f, 0xbc, 0x73, 0x46, 0xe7, 0x30, 0x5c, 0xe4, 0x0f,
+	0x4c, 0x15, 0x73, 0x88, 0xea, 0xa1, 0x9c, 0xc3, 0x09, 0x55, 0x1d, 0xd1, 0x73, 0x18, 0xdd, 0x33,
+	0x41, 0xe5, 0x2e, 0xb4, 0x71, 0x34, 0xf4, 0x27, 0x4f, 0x74, 0x09, 0x7f, 0x57, 0xc8, 0xde, 0x93,
+	0x0d, 0xa7, 0xc4, 0x3e, 0xed, 0x95, 0x52, 0x52, 0xe9, 0xa8, 0x39, 0x69, 0x4c, 0x7b, 0xf8, 0x71,
+	0x27, 0x74, 0x05, 0xff, 0x54, 0x79, 0x0f, 0x68, 0x5a, 0x8e, 0xe6, 0x09, 0xaf, 0xdd, 0xc2, 0xb5,
+	0x9f, 0x5c, 0xb8, 0xce, 0xde, 0xc2, 0x4d, 0xa0, 0xef, 0xea, 0xbb, 0xc9, 0x98, 0x60, 0x34, 0xea,
+	0x3a, 0xd3, 0x3e, 0x84, 0x4e, 0xa0, 0x95, 0x6e, 0x08, 0xdf, 0x46, 0x3d, 0x77, 0x1f, 0x5e, 0x39,
+	0xb2, 0x90, 0x70, 0x74, 0x21, 0x67, 0x00, 0x8a, 0x69, 0xb9, 0xc9, 0xdd, 0xba, 0xf4, 0x43, 0x93,
+	0x2f, 0xb9, 0xce, 0x72, 0xc3, 0x70, 0x69, 0xc1, 0x7b, 0x5e, 0xf1, 0xb7, 0x1a, 0xfc, 0x79, 0xb0,
+	0x50, 0xf6, 0x15, 0xe6, 0x33, 0xa7, 0xc5, 0x09, 0x5b, 0xd9, 0xd6, 0x78, 0x4f, 0x36, 0xb9, 0xbf,
+	0xb6, 0x06, 0xf6, 0x0a, 0xfa, 0x0f, 0x86, 0xa9, 0x14, 0x4b, 0xae, 0xb6, 0xc4, 0xef, 0xbd, 0x9d,
+	0xed, 0x10, 0x57, 0x41, 0x7b, 0x72, 0x6b, 0xc6, 0x57, 0x6b, 0xe3, 0x4e, 0x6e, 0x88, 0x83, 0x56,
+	0x5d, 0xc7, 0xd6, 0xef, 0xac, 0xe3, 0x14, 0x7a, 0x4b, 0x4e, 0xec, 0x88, 0x72, 0x3f, 0x8e, 0xfe,
+	0x0c, 0x92, 0xab, 0x02, 0xc1, 0x3b, 0x63, 0xfc, 0xa5, 0x06, 0xbd, 0xd2, 0x80, 0x62, 0x18, 0xa4,
+	0xb9, 0x52, 0x4c, 0xa4, 0x0f, 0x73, 0xfb, 0x43, 0xf2, 0x2f, 0xac, 0x60, 0xb6, 0x5a, 0xb2, 0x95,
+	0xb9, 0xf0, 0x3b, 0x5e, 0xc3, 0x41, 0x73, 0xb3, 0xb5, 0xd3, 0x6f, 0x38, 0xd4, 0xc9, 0xe8, 0x05,
+	0x0c, 0xed, 0xb7, 0xac, 0x31, 0x6a, 0x3e, 0xf9, 0x8a, 0x6a, 0x40, 0xfc, 0x1a, 0x46, 0xb7, 0x8c,
+	0xa9, 0x0b, 0x41, 0x6f, 0xfd, 0x1f, 0xd7, 0xe6, 0xcf, 0x18, 0x53, 0xd7, 0x45, 0xff, 0x83, 0x86,
+	0x62, 0xe8, 0x84, 0x9f, 0x72, 0x38, 0xbe, 0x6e, 0x12, 0x42, 0x70, 0x61, 0x88, 0x17, 0x70, 0x52,
+	0x65, 0xfb, 0xc0, 0xcd, 0xfa, 0xfa, 0x12, 0x8d, 0xa0, 0x5e, 0xce, 0xb3, 0xce, 0xe9, 0x5e, 0x8e,
+	0xfa, 0xb1, 0x1c, 0x8d, 0x63, 0x39, 0x3e, 0xc1, 0x00, 0x13, 0xc3, 0xc5, 0xea, 0x08, 0xf7, 0x18,
+	0xba, 0xca, 0xd9, 0x4b, 0xf6, 0x52, 0x47, 0xa7, 0xd0, 0xf6, 0x72, 0xa0, 0xef, 0x24, 0x9e, 0x0a,
+	0x07, 0xf8, 0x65, 0xf3, 0x63, 0x3d, 0x5b, 0x2c, 0xda, 0xae, 0x6f, 0xcf, 0x7e, 0x0c, 0x00, 0xb0,
+	0x9f, 0xd8, 0x5e, 0xb0, 0x06, 0x00, 0x00,
 }
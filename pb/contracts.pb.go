@@ -283,6 +283,8 @@ type Listing struct {
 	Moderators         []string                  `protobuf:"bytes,8,rep,name=moderators" json:"moderators,omitempty"`
 	TermsAndConditions string                    `protobuf:"bytes,9,opt,name=termsAndConditions" json:"termsAndConditions,omitempty"`
 	RefundPolicy       string                    `protobuf:"bytes,10,opt,name=refundPolicy" json:"refundPolicy,omitempty"`
+	Auction            *Listing_Auction          `protobuf:"bytes,11,opt,name=auction" json:"auction,omitempty"`
+	CrowdFund          *Listing_CrowdFund        `protobuf:"bytes,12,opt,name=crowdFund" json:"crowdFund,omitempty"`
 }
 
 func (m *Listing) Reset()                    { *m = Listing{} }
@@ -360,14 +362,29 @@ func (m *Listing) GetRefundPolicy() string {
 	return ""
 }
 
+func (m *Listing) GetAuction() *Listing_Auction {
+	if m != nil {
+		return m.Auction
+	}
+	return nil
+}
+
+func (m *Listing) GetCrowdFund() *Listing_CrowdFund {
+	if m != nil {
+		return m.CrowdFund
+	}
+	return nil
+}
+
 type Listing_Metadata struct {
-	Version          uint32                        `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
-	ContractType     Listing_Metadata_ContractType `protobuf:"varint,2,opt,name=contractType,enum=Listing_Metadata_ContractType" json:"contractType,omitempty"`
-	Format           Listing_Metadata_Format       `protobuf:"varint,3,opt,name=format,enum=Listing_Metadata_Format" json:"format,omitempty"`
-	Expiry           *google_protobuf.Timestamp    `protobuf:"bytes,4,opt,name=expiry" json:"expiry,omitempty"`
-	AcceptedCurrency string                        `protobuf:"bytes,5,opt,name=acceptedCurrency" json:"acceptedCurrency,omitempty"`
-	PricingCurrency  string                        `protobuf:"bytes,6,opt,name=pricingCurrency" json:"pricingCurrency,omitempty"`
-	Language         string                        `protobuf:"bytes,7,opt,name=language" json:"language,omitempty"`
+	Version            uint32                        `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	ContractType       Listing_Metadata_ContractType `protobuf:"varint,2,opt,name=contractType,enum=Listing_Metadata_ContractType" json:"contractType,omitempty"`
+	Format             Listing_Metadata_Format       `protobuf:"varint,3,opt,name=format,enum=Listing_Metadata_Format" json:"format,omitempty"`
+	Expiry             *google_protobuf.Timestamp    `protobuf:"bytes,4,opt,name=expiry" json:"expiry,omitempty"`
+	AcceptedCurrency   string                        `protobuf:"bytes,5,opt,name=acceptedCurrency" json:"acceptedCurrency,omitempty"`
+	PricingCurrency    string                        `protobuf:"bytes,6,opt,name=pricingCurrency" json:"pricingCurrency,omitempty"`
+	Language           string                        `protobuf:"bytes,7,opt,name=language" json:"language,omitempty"`
+	EscrowTimeoutHours uint32                        `protobuf:"varint,8,opt,name=escrowTimeoutHours" json:"escrowTimeoutHours,omitempty"`
 }
 
 func (m *Listing_Metadata) Reset()                    { *m = Listing_Metadata{} }
@@ -424,6 +441,13 @@ func (m *Listing_Metadata) GetLanguage() string {
 	return ""
 }
 
+func (m *Listing_Metadata) GetEscrowTimeoutHours() uint32 {
+	if m != nil {
+		return m.EscrowTimeoutHours
+	}
+	return 0
+}
+
 type Listing_Item struct {
 	Title          string                 `protobuf:"bytes,1,opt,name=title" json:"title,omitempty"`
 	Description    string                 `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
@@ -1062,6 +1086,62 @@ func _Listing_Coupon_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+type Listing_Auction struct {
+	EndTime      *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=endTime" json:"endTime,omitempty"`
+	ReservePrice uint64                     `protobuf:"varint,2,opt,name=reservePrice" json:"reservePrice,omitempty"`
+	BidIncrement uint64                     `protobuf:"varint,3,opt,name=bidIncrement" json:"bidIncrement,omitempty"`
+}
+
+func (m *Listing_Auction) Reset()                    { *m = Listing_Auction{} }
+func (m *Listing_Auction) String() string            { return proto.CompactTextString(m) }
+func (*Listing_Auction) ProtoMessage()               {}
+func (*Listing_Auction) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1, 5} }
+
+func (m *Listing_Auction) GetEndTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *Listing_Auction) GetReservePrice() uint64 {
+	if m != nil {
+		return m.ReservePrice
+	}
+	return 0
+}
+
+func (m *Listing_Auction) GetBidIncrement() uint64 {
+	if m != nil {
+		return m.BidIncrement
+	}
+	return 0
+}
+
+type Listing_CrowdFund struct {
+	Goal     uint64                     `protobuf:"varint,1,opt,name=goal" json:"goal,omitempty"`
+	Deadline *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=deadline" json:"deadline,omitempty"`
+}
+
+func (m *Listing_CrowdFund) Reset()                    { *m = Listing_CrowdFund{} }
+func (m *Listing_CrowdFund) String() string            { return proto.CompactTextString(m) }
+func (*Listing_CrowdFund) ProtoMessage()               {}
+func (*Listing_CrowdFund) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1, 6} }
+
+func (m *Listing_CrowdFund) GetGoal() uint64 {
+	if m != nil {
+		return m.Goal
+	}
+	return 0
+}
+
+func (m *Listing_CrowdFund) GetDeadline() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
 type Order struct {
 	RefundAddress        string                     `protobuf:"bytes,1,opt,name=refundAddress" json:"refundAddress,omitempty"`
 	RefundFee            uint64                     `protobuf:"varint,2,opt,name=refundFee" json:"refundFee,omitempty"`
@@ -1213,6 +1293,7 @@ type Order_Item struct {
 	ShippingOption *Order_Item_ShippingOption `protobuf:"bytes,4,opt,name=shippingOption" json:"shippingOption,omitempty"`
 	Memo           string                     `protobuf:"bytes,5,opt,name=memo" json:"memo,omitempty"`
 	CouponCodes    []string                   `protobuf:"bytes,6,rep,name=couponCodes" json:"couponCodes,omitempty"`
+	Bid            *SignedBid                 `protobuf:"bytes,7,opt,name=bid" json:"bid,omitempty"`
 }
 
 func (m *Order_Item) Reset()                    { *m = Order_Item{} }
@@ -1262,6 +1343,13 @@ func (m *Order_Item) GetCouponCodes() []string {
 	return nil
 }
 
+func (m *Order_Item) GetBid() *SignedBid {
+	if m != nil {
+		return m.Bid
+	}
+	return nil
+}
+
 type Order_Item_Option struct {
 	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
@@ -1311,12 +1399,13 @@ func (m *Order_Item_ShippingOption) GetService() string {
 }
 
 type Order_Payment struct {
-	Method       Order_Payment_Method `protobuf:"varint,1,opt,name=method,enum=Order_Payment_Method" json:"method,omitempty"`
-	Moderator    string               `protobuf:"bytes,2,opt,name=moderator" json:"moderator,omitempty"`
-	Amount       uint64               `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
-	Chaincode    string               `protobuf:"bytes,4,opt,name=chaincode" json:"chaincode,omitempty"`
-	Address      string               `protobuf:"bytes,5,opt,name=address" json:"address,omitempty"`
-	RedeemScript string               `protobuf:"bytes,6,opt,name=redeemScript" json:"redeemScript,omitempty"`
+	Method             Order_Payment_Method `protobuf:"varint,1,opt,name=method,enum=Order_Payment_Method" json:"method,omitempty"`
+	Moderator          string               `protobuf:"bytes,2,opt,name=moderator" json:"moderator,omitempty"`
+	Amount             uint64               `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+	Chaincode          string               `protobuf:"bytes,4,opt,name=chaincode" json:"chaincode,omitempty"`
+	Address            string               `protobuf:"bytes,5,opt,name=address" json:"address,omitempty"`
+	RedeemScript       string               `protobuf:"bytes,6,opt,name=redeemScript" json:"redeemScript,omitempty"`
+	EscrowTimeoutHours uint32               `protobuf:"varint,7,opt,name=escrowTimeoutHours" json:"escrowTimeoutHours,omitempty"`
 }
 
 func (m *Order_Payment) Reset()                    { *m = Order_Payment{} }
@@ -1366,6 +1455,13 @@ func (m *Order_Payment) GetRedeemScript() string {
 	return ""
 }
 
+func (m *Order_Payment) GetEscrowTimeoutHours() uint32 {
+	if m != nil {
+		return m.EscrowTimeoutHours
+	}
+	return 0
+}
+
 type OrderConfirmation struct {
 	OrderID   string                     `protobuf:"bytes,1,opt,name=orderID" json:"orderID,omitempty"`
 	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
@@ -2112,6 +2208,10 @@ type Refund struct {
 	Sigs              []*BitcoinSignature        `protobuf:"bytes,3,rep,name=sigs" json:"sigs,omitempty"`
 	RefundTransaction *Refund_TransactionInfo    `protobuf:"bytes,4,opt,name=refundTransaction" json:"refundTransaction,omitempty"`
 	Memo              string                     `protobuf:"bytes,5,opt,name=memo" json:"memo,omitempty"`
+	Amount            uint64                     `protobuf:"varint,6,opt,name=amount" json:"amount,omitempty"`
+	TotalRefunded     uint64                     `protobuf:"varint,7,opt,name=totalRefunded" json:"totalRefunded,omitempty"`
+	Items             []*Refund_Item             `protobuf:"bytes,8,rep,name=items" json:"items,omitempty"`
+	Partial           bool                       `protobuf:"varint,9,opt,name=partial" json:"partial,omitempty"`
 }
 
 func (m *Refund) Reset()                    { *m = Refund{} }
@@ -2154,6 +2254,34 @@ func (m *Refund) GetMemo() string {
 	return ""
 }
 
+func (m *Refund) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Refund) GetTotalRefunded() uint64 {
+	if m != nil {
+		return m.TotalRefunded
+	}
+	return 0
+}
+
+func (m *Refund) GetItems() []*Refund_Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Refund) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
 type Refund_TransactionInfo struct {
 	Txid  string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
 	Value uint64 `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
@@ -2178,6 +2306,30 @@ func (m *Refund_TransactionInfo) GetValue() uint64 {
 	return 0
 }
 
+type Refund_Item struct {
+	Index    uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Quantity uint32 `protobuf:"varint,2,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *Refund_Item) Reset()                    { *m = Refund_Item{} }
+func (m *Refund_Item) String() string            { return proto.CompactTextString(m) }
+func (*Refund_Item) ProtoMessage()               {}
+func (*Refund_Item) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14, 1} }
+
+func (m *Refund_Item) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Refund_Item) GetQuantity() uint32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
 type ID struct {
 	PeerID       string      `protobuf:"bytes,1,opt,name=peerID" json:"peerID,omitempty"`
 	BlockchainID string      `protobuf:"bytes,2,opt,name=blockchainID" json:"blockchainID,omitempty"`
@@ -2298,6 +2450,86 @@ func (m *SignedListing) GetSignature() []byte {
 	return nil
 }
 
+type Bid struct {
+	ListingHash string                     `protobuf:"bytes,1,opt,name=listingHash" json:"listingHash,omitempty"`
+	VendorID    string                     `protobuf:"bytes,2,opt,name=vendorID" json:"vendorID,omitempty"`
+	Slug        string                     `protobuf:"bytes,3,opt,name=slug" json:"slug,omitempty"`
+	BuyerID     *ID                        `protobuf:"bytes,4,opt,name=buyerID" json:"buyerID,omitempty"`
+	Amount      uint64                     `protobuf:"varint,5,opt,name=amount" json:"amount,omitempty"`
+	Timestamp   *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *Bid) Reset()                    { *m = Bid{} }
+func (m *Bid) String() string            { return proto.CompactTextString(m) }
+func (*Bid) ProtoMessage()               {}
+func (*Bid) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
+
+func (m *Bid) GetListingHash() string {
+	if m != nil {
+		return m.ListingHash
+	}
+	return ""
+}
+
+func (m *Bid) GetVendorID() string {
+	if m != nil {
+		return m.VendorID
+	}
+	return ""
+}
+
+func (m *Bid) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *Bid) GetBuyerID() *ID {
+	if m != nil {
+		return m.BuyerID
+	}
+	return nil
+}
+
+func (m *Bid) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Bid) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type SignedBid struct {
+	Bid       *Bid   `protobuf:"bytes,1,opt,name=bid" json:"bid,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedBid) Reset()                    { *m = SignedBid{} }
+func (m *SignedBid) String() string            { return proto.CompactTextString(m) }
+func (*SignedBid) ProtoMessage()               {}
+func (*SignedBid) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
+
+func (m *SignedBid) GetBid() *Bid {
+	if m != nil {
+		return m.Bid
+	}
+	return nil
+}
+
+func (m *SignedBid) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RicardianContract)(nil), "RicardianContract")
 	proto.RegisterType((*Listing)(nil), "Listing")
@@ -2313,6 +2545,8 @@ func init() {
 	proto.RegisterType((*Listing_ShippingOption_ShippingRules_Rule)(nil), "Listing.ShippingOption.ShippingRules.Rule")
 	proto.RegisterType((*Listing_Tax)(nil), "Listing.Tax")
 	proto.RegisterType((*Listing_Coupon)(nil), "Listing.Coupon")
+	proto.RegisterType((*Listing_Auction)(nil), "Listing.Auction")
+	proto.RegisterType((*Listing_CrowdFund)(nil), "Listing.CrowdFund")
 	proto.RegisterType((*Order)(nil), "Order")
 	proto.RegisterType((*Order_Shipping)(nil), "Order.Shipping")
 	proto.RegisterType((*Order_Item)(nil), "Order.Item")
@@ -2339,10 +2573,13 @@ func init() {
 	proto.RegisterType((*Outpoint)(nil), "Outpoint")
 	proto.RegisterType((*Refund)(nil), "Refund")
 	proto.RegisterType((*Refund_TransactionInfo)(nil), "Refund.TransactionInfo")
+	proto.RegisterType((*Refund_Item)(nil), "Refund.Item")
 	proto.RegisterType((*ID)(nil), "ID")
 	proto.RegisterType((*ID_Pubkeys)(nil), "ID.Pubkeys")
 	proto.RegisterType((*Signature)(nil), "Signature")
 	proto.RegisterType((*SignedListing)(nil), "SignedListing")
+	proto.RegisterType((*Bid)(nil), "Bid")
+	proto.RegisterType((*SignedBid)(nil), "SignedBid")
 	proto.RegisterEnum("Listing_Metadata_ContractType", Listing_Metadata_ContractType_name, Listing_Metadata_ContractType_value)
 	proto.RegisterEnum("Listing_Metadata_Format", Listing_Metadata_Format_name, Listing_Metadata_Format_value)
 	proto.RegisterEnum("Listing_ShippingOption_ShippingType", Listing_ShippingOption_ShippingType_name, Listing_ShippingOption_ShippingType_value)
@@ -2354,204 +2591,221 @@ func init() {
 func init() { proto.RegisterFile("contracts.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 3183 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x5a, 0xbd, 0x73, 0x24, 0x47,
-	0x15, 0xbf, 0xd9, 0xef, 0x7d, 0x5a, 0x49, 0xab, 0x3e, 0xdd, 0xdd, 0x7a, 0x31, 0xbe, 0xbb, 0xad,
-	0xf3, 0x71, 0x3e, 0x9f, 0xc7, 0x67, 0x91, 0x5c, 0x61, 0x0a, 0x7b, 0xb5, 0xb3, 0x3a, 0x8d, 0x4f,
-	0x27, 0xad, 0x7b, 0x57, 0x36, 0x26, 0x51, 0x8d, 0x66, 0x5a, 0xab, 0xe1, 0x66, 0x67, 0xd6, 0x33,
-	0x3d, 0xb2, 0x44, 0x46, 0x15, 0x01, 0x45, 0x42, 0x42, 0x95, 0x03, 0xfe, 0x0a, 0x0a, 0x22, 0x88,
-	0x70, 0x44, 0x42, 0x42, 0x44, 0x06, 0x45, 0x4c, 0x00, 0x11, 0x01, 0x09, 0xd5, 0x5f, 0xf3, 0xb5,
-	0xab, 0xfb, 0x30, 0x45, 0x11, 0x69, 0xde, 0xef, 0xbd, 0xee, 0xed, 0x7e, 0xfd, 0x3e, 0xbb, 0x05,
-	0xeb, 0x76, 0xe0, 0xd3, 0xd0, 0xb2, 0x69, 0xa4, 0xcf, 0xc3, 0x80, 0x06, 0x5d, 0x64, 0x07, 0xb1,
-	0x4f, 0xc3, 0x0b, 0x3b, 0x70, 0x88, 0xc2, 0x6e, 0x4e, 0x83, 0x60, 0xea, 0x91, 0x77, 0x39, 0x75,
-	0x1c, 0x9f, 0xbc, 0x4b, 0xdd, 0x19, 0x89, 0xa8, 0x35, 0x9b, 0x0b, 0x81, 0xde, 0xef, 0x2b, 0xb0,
-	0x81, 0x5d, 0xdb, 0x0a, 0x1d, 0xd7, 0xf2, 0x07, 0x72, 0x46, 0xf4, 0x10, 0xd6, 0xce, 0x88, 0xef,
-	0x04, 0xe1, 0x9e, 0x1b, 0x51, 0xd7, 0x9f, 0x46, 0x1d, 0xed, 0x56, 0xf9, 0xde, 0xca, 0x56, 0x43,
-	0x97, 0x00, 0x2e, 0xf0, 0xd1, 0x5d, 0x80, 0xe3, 0xf8, 0x82, 0x84, 0x07, 0xa1, 0x43, 0xc2, 0x4e,
-	0xe9, 0x96, 0x76, 0x6f, 0x65, 0xab, 0xa6, 0x73, 0x0a, 0x67, 0x38, 0x68, 0x0f, 0x6e, 0x88, 0x91,
-	0x9c, 0x1c, 0x04, 0xfe, 0x89, 0x1b, 0xce, 0x2c, 0xea, 0x06, 0x7e, 0xa7, 0xcc, 0x07, 0x21, 0x7d,
-	0x81, 0x83, 0x2f, 0x1b, 0x82, 0x4c, 0xb8, 0x9e, 0x61, 0xed, 0xc4, 0xde, 0x89, 0xeb, 0x79, 0x33,
-	0xe2, 0xd3, 0x4e, 0x85, 0xaf, 0x77, 0x43, 0x2f, 0x32, 0xf0, 0x25, 0x03, 0x90, 0x01, 0x9b, 0xe9,
-	0x32, 0x07, 0xc1, 0x6c, 0xee, 0x11, 0xbe, 0xaa, 0x2a, 0x5f, 0x55, 0x5b, 0x2f, 0xe0, 0x78, 0xa9,
-	0x34, 0xea, 0x41, 0xdd, 0x71, 0xa3, 0x79, 0x4c, 0x49, 0xa7, 0xc6, 0x07, 0x36, 0x74, 0x43, 0xd0,
-	0x58, 0x31, 0xd0, 0x87, 0xb0, 0x21, 0x3f, 0x31, 0x89, 0x02, 0x2f, 0xe6, 0x3f, 0x53, 0x97, 0x9b,
-	0x37, 0x8a, 0x1c, 0xbc, 0x28, 0x9c, 0x99, 0xa1, 0x6f, 0xdb, 0x64, 0x4e, 0x2d, 0xdf, 0x26, 0x9d,
-	0x46, 0x7e, 0x86, 0x94, 0x83, 0x17, 0x85, 0xd1, 0x4d, 0xa8, 0x85, 0xe4, 0x24, 0xf6, 0x9d, 0x4e,
-	0x93, 0x0f, 0xab, 0xeb, 0x98, 0x93, 0x58, 0xc2, 0xe8, 0x3e, 0x40, 0xe4, 0x4e, 0x7d, 0x8b, 0xc6,
-	0x21, 0x89, 0x3a, 0xc0, 0xb5, 0x09, 0xfa, 0x58, 0x41, 0x38, 0xc3, 0xed, 0x7d, 0x75, 0x1d, 0xea,
-	0xd2, 0x10, 0x10, 0x82, 0x4a, 0xe4, 0xc5, 0xd3, 0x8e, 0x76, 0x4b, 0xbb, 0xd7, 0xc4, 0xfc, 0x1b,
-	0xdd, 0x84, 0x86, 0x50, 0xba, 0x69, 0x48, 0xcb, 0x28, 0xeb, 0xa6, 0x81, 0x13, 0x10, 0xbd, 0x03,
-	0x8d, 0x19, 0xa1, 0x96, 0x63, 0x51, 0x4b, 0x5a, 0xc1, 0x86, 0x32, 0x34, 0xfd, 0xa9, 0x64, 0xe0,
-	0x44, 0x04, 0xdd, 0x86, 0x8a, 0x4b, 0xc9, 0xac, 0x53, 0xe1, 0xa2, 0xab, 0x89, 0xa8, 0x49, 0xc9,
-	0x0c, 0x73, 0x16, 0xea, 0xc3, 0x7a, 0x74, 0xea, 0xce, 0xe7, 0xae, 0x3f, 0x3d, 0x98, 0x33, 0x9d,
-	0x45, 0x9d, 0x2a, 0xdf, 0xc3, 0x8d, 0x44, 0x7a, 0x9c, 0xe3, 0xe3, 0xa2, 0x3c, 0xea, 0x41, 0x95,
-	0x5a, 0xe7, 0x24, 0xea, 0xd4, 0xf8, 0xc0, 0x56, 0x32, 0x70, 0x62, 0x9d, 0x63, 0xc1, 0x42, 0x6f,
-	0x41, 0xdd, 0x0e, 0xe2, 0x39, 0x9b, 0xbe, 0xce, 0xa5, 0xd6, 0x13, 0xa9, 0x01, 0xc7, 0xb1, 0xe2,
-	0xa3, 0x37, 0x00, 0x66, 0x81, 0x43, 0x42, 0x8b, 0x06, 0x61, 0xd4, 0x69, 0xdc, 0x2a, 0xdf, 0x6b,
-	0xe2, 0x0c, 0x82, 0x74, 0x40, 0x94, 0x84, 0xb3, 0xa8, 0xef, 0x3b, 0x83, 0xc0, 0x77, 0x5c, 0xb1,
-	0xe8, 0x26, 0x57, 0xe3, 0x12, 0x0e, 0xea, 0x41, 0x4b, 0x1c, 0xd5, 0x28, 0xf0, 0x5c, 0xfb, 0xa2,
-	0x03, 0x5c, 0x32, 0x87, 0x75, 0x7f, 0x5b, 0x86, 0x86, 0xd2, 0x1f, 0xea, 0x40, 0xfd, 0x8c, 0x84,
-	0x11, 0x33, 0x36, 0x76, 0x38, 0xab, 0x58, 0x91, 0x68, 0x1b, 0x5a, 0x2a, 0x96, 0x4c, 0x2e, 0xe6,
-	0x84, 0x9f, 0xd1, 0xda, 0xd6, 0x1b, 0x0b, 0x47, 0xa0, 0x0f, 0x32, 0x52, 0x38, 0x37, 0x06, 0x3d,
-	0x84, 0xda, 0x49, 0xc0, 0xdc, 0x92, 0x1f, 0xe0, 0xda, 0x56, 0x67, 0x71, 0xf4, 0x0e, 0xe7, 0x63,
-	0x29, 0x87, 0xb6, 0xa0, 0x46, 0xce, 0xe7, 0x6e, 0x78, 0x21, 0xcf, 0xb1, 0xab, 0x8b, 0x58, 0xa5,
-	0xab, 0x58, 0xa5, 0x4f, 0x54, 0xac, 0xc2, 0x52, 0x12, 0xdd, 0x87, 0xb6, 0xc5, 0x8d, 0x98, 0x38,
-	0x83, 0x38, 0x0c, 0x89, 0x6f, 0x5f, 0x70, 0x07, 0x6d, 0xe2, 0x05, 0x1c, 0xdd, 0x83, 0xf5, 0x79,
-	0xe8, 0xda, 0xae, 0x3f, 0x4d, 0x44, 0x6b, 0x5c, 0xb4, 0x08, 0xa3, 0x2e, 0x34, 0x3c, 0xcb, 0x9f,
-	0xc6, 0xd6, 0x94, 0x70, 0x3f, 0x6c, 0xe2, 0x84, 0xee, 0x8d, 0xa0, 0x95, 0xdd, 0x35, 0xda, 0x80,
-	0xd5, 0xd1, 0xee, 0x67, 0x63, 0x73, 0xd0, 0xdf, 0x3b, 0x7a, 0x7c, 0x70, 0x60, 0xb4, 0xaf, 0xa0,
-	0x36, 0xb4, 0x0c, 0xf3, 0xb1, 0x39, 0x51, 0x88, 0x86, 0x56, 0xa0, 0x3e, 0x1e, 0xe2, 0x4f, 0xcc,
-	0xc1, 0xb0, 0x5d, 0x42, 0x6b, 0x00, 0x03, 0x7c, 0xf0, 0xa9, 0x71, 0xb4, 0x73, 0xb8, 0x6f, 0xb4,
-	0xcb, 0xbd, 0xbb, 0x50, 0x13, 0x9a, 0x40, 0xeb, 0xb0, 0xb2, 0x63, 0x7e, 0x7f, 0x68, 0x1c, 0x8d,
-	0x30, 0x13, 0xbd, 0xc2, 0xc6, 0xf5, 0x0f, 0x07, 0x13, 0xf3, 0x60, 0xbf, 0xad, 0x75, 0xff, 0x52,
-	0x83, 0x0a, 0xb3, 0x68, 0xb4, 0x09, 0x55, 0xea, 0x52, 0x8f, 0x48, 0x9f, 0x12, 0x04, 0xba, 0x05,
-	0x2b, 0x0e, 0x89, 0xec, 0xd0, 0xe5, 0xe6, 0xca, 0xcf, 0xac, 0x89, 0xb3, 0x10, 0xba, 0x0b, 0x6b,
-	0xf3, 0x30, 0xb0, 0x49, 0x14, 0xb9, 0xfe, 0x94, 0xe9, 0x92, 0x1f, 0x4d, 0x13, 0x17, 0x50, 0x36,
-	0x3f, 0xd3, 0x08, 0xe1, 0xe7, 0x50, 0xc1, 0x82, 0x60, 0x8e, 0xec, 0x47, 0x27, 0x5f, 0x70, 0xf5,
-	0x36, 0x30, 0xff, 0x66, 0x18, 0xb5, 0xa6, 0xc2, 0x23, 0x9a, 0x98, 0x7f, 0xa3, 0xb7, 0xa1, 0xe6,
-	0xce, 0xac, 0x29, 0x51, 0x1e, 0x70, 0x35, 0xe7, 0x8e, 0xba, 0xc9, 0x78, 0x58, 0x8a, 0x30, 0x27,
-	0xb0, 0x2d, 0x4a, 0xa6, 0x41, 0xe8, 0x92, 0xc4, 0x09, 0x52, 0x84, 0x2d, 0x65, 0x1a, 0x5a, 0x33,
-	0x61, 0xf7, 0x25, 0x2c, 0x08, 0xf4, 0x3a, 0x34, 0x6d, 0x65, 0xf8, 0xd2, 0xce, 0x53, 0x00, 0xe9,
-	0x50, 0x0f, 0xa4, 0x8b, 0xaf, 0xf0, 0x15, 0x6c, 0xe6, 0x57, 0x20, 0xfd, 0x5b, 0x09, 0xa1, 0x37,
-	0xa1, 0x12, 0x3d, 0x8b, 0xa3, 0x4e, 0x4b, 0x66, 0x88, 0x9c, 0xf0, 0xf8, 0x59, 0x8c, 0x39, 0xbb,
-	0xfb, 0x95, 0x06, 0x35, 0x31, 0x94, 0xab, 0xc2, 0x9a, 0x29, 0xfd, 0xf3, 0xef, 0x97, 0x50, 0xff,
-	0x23, 0x68, 0x9c, 0x59, 0xa1, 0x6b, 0xf9, 0x34, 0xea, 0x94, 0xf9, 0x6f, 0xbd, 0xbe, 0x6c, 0x61,
-	0xfa, 0x27, 0x42, 0x08, 0x27, 0xd2, 0xdd, 0x5d, 0xa8, 0x4b, 0x70, 0xe9, 0x4f, 0xbf, 0x05, 0x55,
-	0xae, 0x4e, 0x19, 0x4b, 0x97, 0x2a, 0x5c, 0x48, 0x74, 0x7f, 0xac, 0x41, 0x79, 0xfc, 0x2c, 0x66,
-	0xc1, 0x42, 0xce, 0x3e, 0x08, 0x66, 0xc7, 0x01, 0xcf, 0xe6, 0xab, 0x38, 0x87, 0x31, 0x2d, 0xcf,
-	0xc3, 0xc0, 0x89, 0x6d, 0x2a, 0xc3, 0x74, 0x13, 0xa7, 0x00, 0xe3, 0x46, 0x71, 0x68, 0x9f, 0x5a,
-	0xe1, 0x54, 0xd8, 0x51, 0x19, 0xa7, 0x00, 0xf3, 0xa0, 0xcf, 0x63, 0xcb, 0xa7, 0x2e, 0x15, 0xde,
-	0x5c, 0xc6, 0x09, 0xdd, 0xfd, 0x52, 0x83, 0x2a, 0x5f, 0x14, 0x93, 0x3a, 0x71, 0x3d, 0x92, 0xd9,
-	0x50, 0x42, 0x33, 0x5e, 0x10, 0xba, 0x53, 0xd7, 0xb7, 0x3c, 0xf9, 0xe3, 0x09, 0xcd, 0xac, 0xc2,
-	0x4b, 0x7e, 0xb7, 0x89, 0x05, 0x81, 0xae, 0x43, 0x6d, 0x46, 0x1c, 0x37, 0x16, 0x79, 0xa0, 0x89,
-	0x25, 0xc5, 0xa4, 0xa3, 0x99, 0xe5, 0x79, 0x32, 0x30, 0x08, 0x82, 0x9b, 0xae, 0xeb, 0xab, 0x10,
-	0xc0, 0xbf, 0xbb, 0xbf, 0xae, 0xc1, 0x5a, 0x3e, 0x0b, 0x2c, 0xd5, 0xf7, 0x23, 0xa8, 0xd0, 0x34,
-	0x2c, 0xde, 0xb9, 0x24, 0x81, 0x24, 0x24, 0x0f, 0x8e, 0x7c, 0x04, 0xba, 0x0b, 0xf5, 0x90, 0x4c,
-	0xb9, 0x69, 0x32, 0x0b, 0x58, 0xdb, 0x6a, 0xb1, 0xb4, 0xc0, 0x6a, 0xb4, 0x41, 0xe0, 0x10, 0xac,
-	0x98, 0xe8, 0x09, 0xac, 0xaa, 0xec, 0x83, 0x63, 0x8f, 0x44, 0x32, 0x22, 0xbe, 0xf9, 0xa2, 0x9f,
-	0xe2, 0xc2, 0x38, 0x3f, 0x16, 0xbd, 0x0f, 0x8d, 0x88, 0x84, 0x67, 0xae, 0x4d, 0x54, 0xce, 0xbb,
-	0x79, 0xe9, 0x3c, 0x42, 0x0e, 0x27, 0x03, 0xba, 0x16, 0xd4, 0x25, 0xb8, 0x54, 0x15, 0x49, 0xa8,
-	0x28, 0x65, 0x43, 0xc5, 0x03, 0xd8, 0x20, 0x11, 0x75, 0x67, 0x16, 0x25, 0x8e, 0x41, 0x3c, 0xf7,
-	0x8c, 0x84, 0x17, 0xf2, 0xac, 0x16, 0x19, 0xdd, 0x9f, 0x95, 0x61, 0x35, 0xb7, 0x01, 0xf4, 0x11,
-	0x34, 0xc2, 0xd8, 0x23, 0x3c, 0xf7, 0x68, 0x5c, 0xc9, 0xfa, 0x4b, 0xed, 0x5c, 0xc7, 0x72, 0x14,
-	0x4e, 0xc6, 0xa3, 0x0f, 0xa1, 0x1a, 0x72, 0x15, 0x96, 0xf8, 0xd6, 0xef, 0xbf, 0xfc, 0x44, 0x58,
-	0x0c, 0xec, 0x4e, 0xa0, 0xc2, 0x48, 0x66, 0x91, 0x33, 0xd7, 0xc7, 0x96, 0x3f, 0x25, 0x32, 0x61,
-	0x26, 0x34, 0xe7, 0x59, 0xe7, 0x82, 0x57, 0x92, 0x3c, 0x49, 0xa7, 0x3a, 0x2a, 0x67, 0x74, 0xd4,
-	0xfb, 0x85, 0x06, 0x0d, 0xb5, 0x5c, 0x74, 0x0d, 0x36, 0x3e, 0x3e, 0xec, 0xef, 0x4f, 0xcc, 0xc9,
-	0x67, 0x47, 0x86, 0x39, 0x1e, 0x1c, 0x1c, 0xee, 0x4f, 0xda, 0x57, 0xd0, 0x37, 0xe0, 0xc6, 0xce,
-	0x5e, 0x7f, 0x72, 0xb4, 0x33, 0x1c, 0x1e, 0x25, 0x7c, 0xdc, 0xdf, 0x7f, 0x3c, 0x6c, 0x6b, 0xe8,
-	0x35, 0xb8, 0x96, 0x30, 0x3f, 0x1d, 0x9a, 0x8f, 0x77, 0x27, 0x92, 0x55, 0x62, 0xac, 0xc1, 0xc1,
-	0xd3, 0x6d, 0x73, 0x7f, 0x68, 0x1c, 0x8d, 0x77, 0xcd, 0xd1, 0xc8, 0xdc, 0x7f, 0x7c, 0xd4, 0x37,
-	0x8c, 0x76, 0x19, 0xbd, 0x01, 0xdd, 0x45, 0xd6, 0xf8, 0x70, 0x7b, 0x82, 0xfb, 0x83, 0x49, 0xbb,
-	0xd2, 0x7b, 0x0f, 0x5a, 0x59, 0xbb, 0x65, 0xb9, 0x6c, 0xef, 0x80, 0xe5, 0xb6, 0x91, 0x39, 0x78,
-	0x72, 0x38, 0x6a, 0x5f, 0x29, 0x26, 0x29, 0xad, 0xfb, 0x73, 0x0d, 0xca, 0x13, 0xeb, 0x9c, 0xd5,
-	0x13, 0xd4, 0x3a, 0x4f, 0x0e, 0xad, 0x89, 0x15, 0x89, 0x1e, 0x00, 0x50, 0xeb, 0x1c, 0x4b, 0xcb,
-	0x2f, 0x2d, 0xb1, 0xfc, 0x0c, 0x9f, 0x45, 0x52, 0x6a, 0x9d, 0xab, 0x55, 0x70, 0xad, 0x35, 0x70,
-	0x16, 0x62, 0x59, 0x63, 0x4e, 0x42, 0x9b, 0xf8, 0x94, 0x45, 0xbd, 0x0a, 0x4f, 0x0d, 0x19, 0x84,
-	0x87, 0x6a, 0x51, 0x6e, 0x5d, 0x92, 0x2b, 0x37, 0xa1, 0x72, 0x6a, 0x45, 0xa7, 0x22, 0xb0, 0xec,
-	0x5e, 0xc1, 0x9c, 0x42, 0x77, 0xa0, 0xe5, 0xb8, 0x11, 0x6f, 0x9a, 0xd8, 0xa2, 0x84, 0xc5, 0xee,
-	0x5e, 0xc1, 0x39, 0x14, 0xdd, 0x87, 0x75, 0xf9, 0x53, 0x86, 0x84, 0x79, 0x60, 0x29, 0xed, 0x6a,
-	0xb8, 0xc8, 0x40, 0x77, 0x61, 0x95, 0x9f, 0x76, 0x22, 0xc9, 0xa2, 0x4d, 0x65, 0x57, 0xc3, 0x79,
-	0x78, 0xbb, 0x06, 0x15, 0xd6, 0xa4, 0x6d, 0x03, 0x34, 0xd4, 0x6f, 0xf5, 0x7e, 0xd5, 0x84, 0xaa,
-	0x68, 0x91, 0xee, 0xc0, 0xaa, 0xa8, 0xe2, 0xfa, 0x8e, 0x13, 0x92, 0x28, 0x92, 0x7b, 0xc9, 0x83,
-	0x2c, 0x20, 0x0b, 0x60, 0x87, 0x28, 0x77, 0x4c, 0x01, 0xf4, 0x36, 0x34, 0xa2, 0xac, 0x46, 0x59,
-	0x65, 0xca, 0x67, 0x4f, 0x0d, 0x3f, 0x11, 0x40, 0xdf, 0x84, 0x3a, 0x6f, 0x66, 0x4c, 0x43, 0x06,
-	0x1e, 0x5e, 0x9e, 0x2b, 0x0c, 0x3d, 0x82, 0x66, 0xd2, 0x35, 0xca, 0x76, 0xe8, 0x79, 0xb5, 0x5a,
-	0x2a, 0x8c, 0x6e, 0x43, 0x95, 0x55, 0xe3, 0xaa, 0x84, 0x5e, 0x91, 0x4b, 0xe0, 0x75, 0xba, 0xe0,
-	0xa0, 0x7b, 0x50, 0x9f, 0x5b, 0x17, 0xbc, 0x65, 0x13, 0x2d, 0xd0, 0x9a, 0x14, 0x1a, 0x09, 0x14,
-	0x2b, 0x36, 0xb3, 0x82, 0xd0, 0x62, 0xae, 0xfc, 0x84, 0x5c, 0x88, 0xda, 0xa1, 0x85, 0x33, 0x08,
-	0xda, 0x82, 0x4d, 0xcb, 0xa3, 0x24, 0xf4, 0x2d, 0x4a, 0x58, 0xc9, 0x66, 0xd9, 0xd4, 0xf4, 0x4f,
-	0x02, 0x59, 0x42, 0x2f, 0xe5, 0x75, 0xff, 0xa4, 0x41, 0x23, 0x31, 0xb3, 0xeb, 0x50, 0x63, 0x2a,
-	0x99, 0x04, 0x52, 0xe1, 0x92, 0x62, 0x86, 0x6e, 0xc9, 0x93, 0x10, 0x99, 0x49, 0x91, 0x2c, 0x44,
-	0xda, 0x2c, 0xe5, 0x89, 0x58, 0xc7, 0xbf, 0x79, 0xfa, 0xa1, 0x16, 0x25, 0x32, 0x2b, 0x09, 0x82,
-	0x9b, 0x70, 0x10, 0x51, 0xcb, 0xe3, 0x96, 0x26, 0x32, 0x53, 0x06, 0x61, 0x99, 0x42, 0x76, 0xef,
-	0xdc, 0x66, 0x16, 0x32, 0x85, 0x64, 0xb2, 0x44, 0x2e, 0x7f, 0x7c, 0x3f, 0xa0, 0xbc, 0xe6, 0xe2,
-	0x55, 0x7f, 0x16, 0xeb, 0xfe, 0xb5, 0x24, 0x0b, 0xc7, 0x5b, 0xb0, 0xe2, 0x89, 0xe8, 0xb7, 0xcb,
-	0xac, 0x5f, 0xec, 0x2a, 0x0b, 0xe5, 0xf2, 0xb6, 0x8c, 0x63, 0x8a, 0x46, 0x0f, 0xd2, 0xba, 0x4a,
-	0x94, 0x2f, 0x28, 0x73, 0x7c, 0x0b, 0x55, 0xd5, 0x36, 0xac, 0xe5, 0x1b, 0xa8, 0xa4, 0xaa, 0xcf,
-	0x0c, 0x2a, 0xb4, 0x5c, 0x85, 0x11, 0x4c, 0x9d, 0x33, 0x32, 0x0b, 0xa4, 0x7a, 0xf8, 0x37, 0xdb,
-	0x83, 0xe8, 0xa0, 0x98, 0x1e, 0x54, 0xe5, 0x99, 0x85, 0xba, 0x5b, 0xcf, 0xad, 0xd3, 0x36, 0xa1,
-	0x7a, 0x66, 0x79, 0x31, 0x91, 0x47, 0x27, 0x88, 0xee, 0xf7, 0x5e, 0x2a, 0xf1, 0x77, 0xa0, 0x2e,
-	0x13, 0xa3, 0x3a, 0x78, 0x49, 0x76, 0x7f, 0x52, 0x82, 0xba, 0x34, 0x50, 0xf4, 0x0e, 0xab, 0x43,
-	0xe8, 0x69, 0xe0, 0xc8, 0xdc, 0x75, 0x2d, 0x6f, 0xc0, 0xac, 0xff, 0x39, 0x0d, 0x1c, 0x2c, 0x85,
-	0x98, 0xdf, 0x26, 0x5d, 0x9f, 0x2a, 0xb3, 0x12, 0x80, 0xd9, 0xa0, 0x35, 0xe3, 0xa1, 0x43, 0x64,
-	0x0f, 0x49, 0xf1, 0x12, 0xf8, 0xd4, 0x72, 0x7d, 0x16, 0x36, 0xa4, 0x65, 0xa5, 0x40, 0xd6, 0x42,
-	0xab, 0x79, 0x0b, 0xe5, 0x5d, 0xa2, 0x43, 0xc8, 0x6c, 0xcc, 0xeb, 0x52, 0x59, 0xfe, 0xe4, 0xb0,
-	0xde, 0x23, 0xa8, 0x89, 0x35, 0xa2, 0xab, 0xb0, 0xde, 0x37, 0x0c, 0x3c, 0x1c, 0x8f, 0x8f, 0xf0,
-	0xf0, 0xe3, 0xc3, 0xe1, 0x98, 0x65, 0x25, 0x80, 0x9a, 0x61, 0xe2, 0xe1, 0x60, 0xd2, 0xd6, 0xd0,
-	0x2a, 0x34, 0x9f, 0x1e, 0x18, 0x43, 0xdc, 0x9f, 0x0c, 0x8d, 0x76, 0xa9, 0xf7, 0x2f, 0x0d, 0x36,
-	0x16, 0x2f, 0x65, 0x3a, 0x50, 0x0f, 0x18, 0x68, 0x1a, 0x2a, 0x31, 0x48, 0x32, 0x1f, 0x49, 0x4a,
-	0xaf, 0x12, 0x49, 0x58, 0x2f, 0x23, 0xf4, 0xa9, 0x82, 0xa2, 0xea, 0x65, 0x72, 0x28, 0x6b, 0xfa,
-	0x42, 0xf2, 0x79, 0x4c, 0x22, 0x4a, 0x9c, 0xbe, 0x50, 0xa4, 0xe8, 0x6a, 0x8a, 0x30, 0xfa, 0x2e,
-	0xb4, 0x45, 0xf0, 0x18, 0xa7, 0xd7, 0x1c, 0xa2, 0x5c, 0x6a, 0xeb, 0x38, 0xcf, 0xc0, 0x0b, 0x92,
-	0xbd, 0x9f, 0x6a, 0xb0, 0x22, 0x2e, 0xb7, 0xc8, 0x0f, 0x89, 0x4d, 0xff, 0x27, 0x7b, 0x66, 0x8d,
-	0x8a, 0x3b, 0x55, 0xde, 0xb7, 0xa1, 0x6f, 0xbb, 0xd4, 0x0e, 0x5c, 0x3f, 0x5d, 0x16, 0x67, 0xf7,
-	0xfe, 0xae, 0xc1, 0x7a, 0x61, 0xc1, 0xe8, 0xc3, 0xcc, 0x85, 0x8a, 0xc6, 0x7f, 0xf3, 0x4e, 0x71,
-	0x53, 0xfa, 0x24, 0xb4, 0xfc, 0xc8, 0xb2, 0xd9, 0x91, 0x2d, 0xb9, 0x63, 0x61, 0xf5, 0xbe, 0x12,
-	0xe5, 0xcb, 0x6e, 0xe1, 0x14, 0xe8, 0x5e, 0xc0, 0xd5, 0x25, 0xc3, 0x33, 0x01, 0x67, 0x9c, 0xde,
-	0x01, 0x65, 0x21, 0x9e, 0xb5, 0x54, 0xc8, 0x56, 0xd3, 0x26, 0x00, 0xb3, 0xd6, 0xc4, 0x15, 0x98,
-	0x40, 0x99, 0x0b, 0xe4, 0xb0, 0xde, 0x08, 0xda, 0x45, 0x45, 0xb0, 0xe8, 0xea, 0xfa, 0xf3, 0x98,
-	0x9a, 0xbe, 0x43, 0xce, 0x65, 0xb1, 0x96, 0x41, 0x9e, 0xbf, 0x99, 0xde, 0x6f, 0xaa, 0xd0, 0x5e,
-	0xb8, 0x0e, 0x4c, 0x0e, 0xd4, 0xc9, 0x1f, 0xa8, 0x93, 0xdc, 0x70, 0x95, 0x32, 0x37, 0x5c, 0xb9,
-	0x43, 0x2e, 0xbf, 0xca, 0x21, 0xef, 0x43, 0x7b, 0x7e, 0x7a, 0x11, 0xb9, 0xb6, 0xe5, 0x25, 0xa5,
-	0xb3, 0xb8, 0xbb, 0xec, 0x2d, 0xdc, 0x5d, 0xea, 0xa3, 0x82, 0x24, 0x5e, 0x18, 0x8b, 0x9e, 0xc0,
-	0xba, 0xe3, 0x4e, 0x5d, 0x9a, 0x99, 0x4e, 0x58, 0xf5, 0xed, 0xc5, 0xe9, 0x8c, 0xbc, 0x20, 0x2e,
-	0x8e, 0x44, 0x0f, 0xa1, 0x36, 0xb7, 0x2e, 0x82, 0x98, 0xca, 0xcb, 0xcc, 0xce, 0x92, 0x25, 0x71,
-	0x3e, 0x96, 0x72, 0xe8, 0x3b, 0xb0, 0x5e, 0xf0, 0x15, 0x99, 0xd6, 0x17, 0x9d, 0xaa, 0x28, 0xc8,
-	0x43, 0x70, 0x40, 0xc5, 0x45, 0x26, 0x0b, 0xc1, 0x01, 0x25, 0xdd, 0x09, 0xb4, 0x8b, 0x9b, 0xe6,
-	0x61, 0x99, 0x05, 0x6f, 0x12, 0xaa, 0xa3, 0x91, 0x24, 0x8b, 0x12, 0x34, 0xb4, 0xec, 0x67, 0xae,
-	0x3f, 0xdd, 0x8f, 0x67, 0xc7, 0x44, 0x05, 0xd8, 0x02, 0xda, 0xfd, 0x00, 0xd6, 0x0b, 0x7b, 0x47,
-	0x6d, 0x28, 0xc7, 0xa1, 0x27, 0x27, 0x64, 0x9f, 0x2c, 0x37, 0xce, 0xad, 0x28, 0xfa, 0x22, 0x08,
-	0x1d, 0xd5, 0x91, 0x2a, 0x9a, 0xf5, 0xd5, 0x35, 0xb1, 0xf3, 0xc4, 0x4b, 0xb5, 0xe7, 0x7a, 0x29,
-	0x2b, 0xea, 0x84, 0x8a, 0xfa, 0xb9, 0x52, 0x22, 0x0f, 0xa2, 0xfb, 0xd0, 0x16, 0xc0, 0x0e, 0x21,
-	0x23, 0x12, 0x6e, 0x5f, 0x50, 0xd5, 0x46, 0x2c, 0xe0, 0xbd, 0xdf, 0x69, 0xb0, 0x5e, 0xbc, 0x7e,
-	0xbe, 0xdc, 0x6a, 0xbf, 0x7e, 0x18, 0x7a, 0x0f, 0x40, 0xfc, 0xf6, 0xf8, 0xb9, 0xc1, 0x28, 0x23,
-	0x84, 0x6e, 0x43, 0x5d, 0x1c, 0x6e, 0x24, 0x6d, 0xb9, 0x2e, 0x4f, 0x1f, 0x2b, 0xbc, 0xf7, 0xc7,
-	0x0a, 0xd4, 0x04, 0x86, 0xb6, 0x54, 0x61, 0x67, 0xa4, 0xe1, 0x0a, 0xc9, 0x01, 0xf2, 0x0f, 0xe3,
-	0xe0, 0x8c, 0xd4, 0x0b, 0xc2, 0xd3, 0x3f, 0xca, 0x00, 0x38, 0x27, 0x9c, 0x06, 0x1d, 0xad, 0x18,
-	0x74, 0x5e, 0x78, 0x3b, 0xad, 0x43, 0x53, 0x7c, 0x8f, 0x5d, 0x55, 0x4c, 0x2f, 0x5a, 0x73, 0x2a,
-	0xf2, 0xa2, 0x72, 0xfa, 0x75, 0x68, 0xf2, 0xcf, 0x7d, 0x56, 0x6e, 0x88, 0x74, 0x9d, 0x02, 0xcc,
-	0xea, 0x38, 0xc1, 0x7e, 0xab, 0xc6, 0x97, 0x9a, 0xd0, 0xb9, 0xf0, 0xc8, 0xf8, 0xf5, 0x42, 0x78,
-	0x64, 0x32, 0xb9, 0x73, 0x6e, 0xbc, 0xca, 0x39, 0x33, 0xdb, 0x39, 0x23, 0xa1, 0xe5, 0x79, 0xbc,
-	0x64, 0x5e, 0xc5, 0x8a, 0x64, 0x9c, 0xcf, 0x63, 0xcb, 0x63, 0x45, 0x22, 0x08, 0x8e, 0x24, 0x8b,
-	0xb7, 0x60, 0x2b, 0x9c, 0x9b, 0xbb, 0x05, 0xbb, 0x03, 0xab, 0x8e, 0xf4, 0xb1, 0xf1, 0x9c, 0x10,
-	0xa7, 0xd3, 0xe2, 0x32, 0x79, 0x90, 0xa5, 0x6d, 0x3b, 0x8e, 0x68, 0x30, 0x23, 0xa1, 0xbc, 0x7e,
-	0xe8, 0xac, 0x72, 0xb9, 0x22, 0xcc, 0x0a, 0xa4, 0x90, 0x9c, 0xb9, 0xe4, 0x8b, 0xce, 0x9a, 0x28,
-	0xd2, 0x05, 0xd5, 0xfb, 0xb3, 0x06, 0x75, 0xf9, 0xf2, 0x91, 0xd7, 0x81, 0xf6, 0x2a, 0x3a, 0xd8,
-	0x84, 0xaa, 0xed, 0x59, 0xee, 0x4c, 0x55, 0x8b, 0x9c, 0x58, 0xf4, 0xdd, 0xf2, 0x32, 0xdf, 0xfd,
-	0x16, 0x34, 0x83, 0x98, 0xce, 0x03, 0xd7, 0xa7, 0xca, 0xec, 0x9b, 0xfa, 0x81, 0x44, 0x70, 0xca,
-	0x43, 0x3a, 0xa0, 0x88, 0x84, 0xae, 0xe5, 0xb9, 0x3f, 0x22, 0x8e, 0xba, 0x5c, 0xe6, 0x96, 0xd0,
-	0xc2, 0x4b, 0x38, 0xbd, 0x7f, 0x56, 0x60, 0x63, 0xe1, 0x59, 0xe8, 0xbf, 0xd8, 0x64, 0x26, 0x48,
-	0x94, 0xf2, 0x41, 0x82, 0x75, 0x29, 0x61, 0x30, 0x0f, 0x22, 0xe2, 0x6c, 0xab, 0xae, 0x26, 0x83,
-	0xf0, 0x16, 0x2c, 0x7d, 0xb2, 0x12, 0x65, 0x68, 0x06, 0x41, 0xef, 0x25, 0xf9, 0x42, 0xb4, 0x89,
-	0xaf, 0x2d, 0x3e, 0x67, 0x15, 0x13, 0xc6, 0x43, 0xb8, 0x9a, 0xd8, 0x6f, 0xe2, 0x53, 0xa2, 0xce,
-	0x6f, 0xe1, 0x65, 0xac, 0xee, 0xdf, 0x4a, 0xaf, 0x1a, 0x7b, 0x6f, 0x43, 0x8d, 0x17, 0x03, 0xea,
-	0x52, 0x28, 0x73, 0x2c, 0x92, 0x81, 0xb6, 0x61, 0x45, 0xbc, 0xe7, 0xc5, 0x74, 0x1e, 0x53, 0xe9,
-	0xe5, 0xb7, 0x2e, 0x5d, 0xbe, 0x2e, 0xe4, 0x70, 0x76, 0x10, 0x32, 0xa0, 0x25, 0xdf, 0x16, 0xc5,
-	0x24, 0x95, 0x97, 0x9c, 0x24, 0x37, 0x0a, 0x7d, 0x04, 0xeb, 0xc9, 0xae, 0xe5, 0x44, 0xd5, 0x97,
-	0x9c, 0xa8, 0x38, 0xb0, 0xfb, 0x08, 0x6a, 0x72, 0x56, 0xd6, 0xdb, 0x8a, 0x0e, 0x40, 0xf5, 0xb6,
-	0x9c, 0xca, 0xf4, 0x1b, 0xa5, 0x6c, 0xbf, 0xd1, 0x73, 0x13, 0x93, 0xcb, 0x3c, 0x1a, 0x7e, 0x7d,
-	0x93, 0xeb, 0x42, 0xc3, 0xf6, 0xa4, 0x59, 0xc9, 0x5c, 0xaa, 0xe8, 0xde, 0x47, 0xd0, 0x50, 0xc7,
-	0xc1, 0x4a, 0x80, 0xd3, 0xb4, 0x55, 0x15, 0xd7, 0x34, 0x9b, 0x50, 0x75, 0x79, 0x5d, 0x27, 0x1a,
-	0x54, 0x41, 0xa4, 0x7d, 0x9d, 0xbc, 0x65, 0xe3, 0x44, 0xef, 0x97, 0x25, 0xa8, 0x89, 0x87, 0xcc,
-	0xff, 0x63, 0x45, 0x8e, 0x86, 0xb0, 0x21, 0x6e, 0x62, 0x32, 0x35, 0xb2, 0xb4, 0x86, 0x1b, 0xf2,
-	0x9d, 0x35, 0x5b, 0x7d, 0x9b, 0xfe, 0x49, 0x80, 0x17, 0x47, 0x2c, 0x6b, 0x87, 0xbb, 0xef, 0xc3,
-	0x7a, 0x61, 0x24, 0xbf, 0xd9, 0x3e, 0x77, 0x55, 0xc2, 0xe7, 0xdf, 0xf9, 0xae, 0x37, 0xd1, 0xce,
-	0x1f, 0x34, 0x28, 0x99, 0x06, 0x3b, 0xf3, 0x39, 0xc9, 0x28, 0x46, 0x52, 0x2c, 0xbd, 0x1c, 0x7b,
-	0x81, 0xfd, 0x8c, 0xf7, 0x95, 0xc9, 0x1b, 0x40, 0x0e, 0x43, 0x6f, 0x42, 0x7d, 0x1e, 0x1f, 0x3f,
-	0x23, 0x17, 0x91, 0xf4, 0x91, 0x15, 0xdd, 0x34, 0xf4, 0x91, 0x80, 0xb0, 0xe2, 0xb1, 0x40, 0x71,
-	0x9c, 0xe8, 0x86, 0x6f, 0xbd, 0x85, 0x33, 0x48, 0xf7, 0x03, 0xa8, 0xcb, 0x31, 0xcc, 0x34, 0x5c,
-	0x87, 0x88, 0x2b, 0x08, 0x91, 0x9b, 0x13, 0x9a, 0x9d, 0xa1, 0x1c, 0x24, 0x73, 0xbc, 0x22, 0x7b,
-	0xff, 0xd6, 0xa0, 0x99, 0x56, 0x8e, 0x0f, 0x58, 0xa3, 0x2e, 0xd4, 0x2c, 0x7a, 0x70, 0x94, 0xbe,
-	0x54, 0xeb, 0x63, 0xc1, 0xc1, 0x4a, 0x84, 0x55, 0x89, 0x49, 0xa9, 0xc0, 0x2a, 0xa9, 0x48, 0x4e,
-	0x5e, 0x40, 0x7b, 0x5f, 0x6a, 0x50, 0x97, 0x83, 0xd1, 0x0a, 0xd4, 0xf7, 0xcc, 0xf1, 0xc4, 0xdc,
-	0x7f, 0xdc, 0xbe, 0x82, 0x9a, 0x50, 0x3d, 0xc0, 0xc6, 0x10, 0xb7, 0x35, 0x74, 0x1d, 0x10, 0xff,
-	0x3c, 0x1a, 0x1c, 0xec, 0xef, 0x98, 0xf8, 0x69, 0x9f, 0x3f, 0xde, 0x95, 0xd0, 0x35, 0xd8, 0x10,
-	0xf8, 0xce, 0xe1, 0xde, 0x8e, 0xb9, 0xb7, 0xf7, 0x74, 0xb8, 0x3f, 0x69, 0x97, 0xd1, 0x26, 0xb4,
-	0x95, 0xf8, 0xd3, 0xd1, 0xde, 0x90, 0x0b, 0x57, 0xd8, 0xe4, 0x86, 0x39, 0x1e, 0x1d, 0x4e, 0x86,
-	0xed, 0x2a, 0x9b, 0x51, 0x12, 0x47, 0x78, 0x38, 0x3e, 0xd8, 0x3b, 0xe4, 0x42, 0x35, 0xd6, 0x86,
-	0xe3, 0x21, 0x7f, 0x42, 0xac, 0xf7, 0x08, 0xac, 0xb2, 0xfd, 0x11, 0x47, 0xbd, 0xba, 0xf7, 0xa0,
-	0x2e, 0xbb, 0x2c, 0xe9, 0x97, 0xe9, 0x3f, 0x6a, 0x28, 0x46, 0xe2, 0x5b, 0xa5, 0x8c, 0x6f, 0xe5,
-	0xca, 0xa8, 0x72, 0xa1, 0x8c, 0xda, 0xae, 0xfc, 0xa0, 0x34, 0x3f, 0x3e, 0xae, 0x71, 0x9f, 0xf8,
-	0xf6, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x7d, 0x14, 0x5f, 0x67, 0x70, 0x22, 0x00, 0x00,
+	// 3442 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5a, 0xcb, 0x73, 0x1b, 0xc7,
+	0x99, 0xd7, 0xe0, 0x8d, 0x8f, 0x20, 0x09, 0xb6, 0x68, 0x09, 0xc6, 0x6a, 0x2d, 0x09, 0x25, 0x6b,
+	0x65, 0x59, 0x1e, 0xcb, 0xdc, 0xad, 0x2d, 0xd5, 0x7a, 0x6b, 0x6d, 0x10, 0x00, 0x45, 0x58, 0x14,
+	0x09, 0x37, 0x40, 0x7b, 0xbd, 0x17, 0xd6, 0x70, 0xa6, 0x09, 0xce, 0x6a, 0x30, 0x03, 0xcf, 0x83,
+	0x22, 0x73, 0xcb, 0x29, 0xa9, 0x5c, 0x72, 0x49, 0x95, 0xff, 0x10, 0xfb, 0x96, 0x53, 0x72, 0xf2,
+	0x25, 0x87, 0xe4, 0x94, 0x5b, 0xaa, 0x72, 0x4c, 0xe5, 0x90, 0x9c, 0x72, 0x48, 0x55, 0x92, 0xfa,
+	0xfa, 0x31, 0x2f, 0x80, 0x7a, 0x38, 0x95, 0xca, 0x6d, 0xbe, 0xdf, 0xf7, 0x75, 0xa3, 0x1f, 0xdf,
+	0xbb, 0x01, 0xeb, 0xa6, 0xe7, 0x86, 0xbe, 0x61, 0x86, 0x81, 0x3e, 0xf7, 0xbd, 0xd0, 0x6b, 0x13,
+	0xd3, 0x8b, 0xdc, 0xd0, 0xbf, 0x30, 0x3d, 0x8b, 0x29, 0xec, 0xe6, 0xd4, 0xf3, 0xa6, 0x0e, 0x7b,
+	0x9f, 0x53, 0xc7, 0xd1, 0xc9, 0xfb, 0xa1, 0x3d, 0x63, 0x41, 0x68, 0xcc, 0xe6, 0x42, 0xa0, 0xf3,
+	0xb3, 0x12, 0x6c, 0x50, 0xdb, 0x34, 0x7c, 0xcb, 0x36, 0xdc, 0x9e, 0x9c, 0x91, 0x3c, 0x84, 0xb5,
+	0x33, 0xe6, 0x5a, 0x9e, 0xbf, 0x67, 0x07, 0xa1, 0xed, 0x4e, 0x83, 0x96, 0x76, 0xab, 0x78, 0x6f,
+	0x65, 0xab, 0xa6, 0x4b, 0x80, 0xe6, 0xf8, 0xe4, 0x2e, 0xc0, 0x71, 0x74, 0xc1, 0xfc, 0x03, 0xdf,
+	0x62, 0x7e, 0xab, 0x70, 0x4b, 0xbb, 0xb7, 0xb2, 0x55, 0xd1, 0x39, 0x45, 0x53, 0x1c, 0xb2, 0x07,
+	0xd7, 0xc5, 0x48, 0x4e, 0xf6, 0x3c, 0xf7, 0xc4, 0xf6, 0x67, 0x46, 0x68, 0x7b, 0x6e, 0xab, 0xc8,
+	0x07, 0x11, 0x7d, 0x81, 0x43, 0x2f, 0x1b, 0x42, 0x86, 0x70, 0x2d, 0xc5, 0xda, 0x89, 0x9c, 0x13,
+	0xdb, 0x71, 0x66, 0xcc, 0x0d, 0x5b, 0x25, 0xbe, 0xde, 0x0d, 0x3d, 0xcf, 0xa0, 0x97, 0x0c, 0x20,
+	0x7d, 0xd8, 0x4c, 0x96, 0xd9, 0xf3, 0x66, 0x73, 0x87, 0xf1, 0x55, 0x95, 0xf9, 0xaa, 0x9a, 0x7a,
+	0x0e, 0xa7, 0x4b, 0xa5, 0x49, 0x07, 0xaa, 0x96, 0x1d, 0xcc, 0xa3, 0x90, 0xb5, 0x2a, 0x7c, 0x60,
+	0x4d, 0xef, 0x0b, 0x9a, 0x2a, 0x06, 0xf9, 0x18, 0x36, 0xe4, 0x27, 0x65, 0x81, 0xe7, 0x44, 0xfc,
+	0x67, 0xaa, 0x72, 0xf3, 0xfd, 0x3c, 0x87, 0x2e, 0x0a, 0xa7, 0x66, 0xe8, 0x9a, 0x26, 0x9b, 0x87,
+	0x86, 0x6b, 0xb2, 0x56, 0x2d, 0x3b, 0x43, 0xc2, 0xa1, 0x8b, 0xc2, 0xe4, 0x26, 0x54, 0x7c, 0x76,
+	0x12, 0xb9, 0x56, 0xab, 0xce, 0x87, 0x55, 0x75, 0xca, 0x49, 0x2a, 0x61, 0x72, 0x1f, 0x20, 0xb0,
+	0xa7, 0xae, 0x11, 0x46, 0x3e, 0x0b, 0x5a, 0xc0, 0x4f, 0x13, 0xf4, 0xb1, 0x82, 0x68, 0x8a, 0xdb,
+	0xf9, 0xfa, 0x4d, 0xa8, 0x4a, 0x45, 0x20, 0x04, 0x4a, 0x81, 0x13, 0x4d, 0x5b, 0xda, 0x2d, 0xed,
+	0x5e, 0x9d, 0xf2, 0x6f, 0x72, 0x13, 0x6a, 0xe2, 0xd0, 0x87, 0x7d, 0xa9, 0x19, 0x45, 0x7d, 0xd8,
+	0xa7, 0x31, 0x48, 0xde, 0x83, 0xda, 0x8c, 0x85, 0x86, 0x65, 0x84, 0x86, 0xd4, 0x82, 0x0d, 0xa5,
+	0x68, 0xfa, 0x53, 0xc9, 0xa0, 0xb1, 0x08, 0xb9, 0x0d, 0x25, 0x3b, 0x64, 0xb3, 0x56, 0x89, 0x8b,
+	0xae, 0xc6, 0xa2, 0xc3, 0x90, 0xcd, 0x28, 0x67, 0x91, 0x2e, 0xac, 0x07, 0xa7, 0xf6, 0x7c, 0x6e,
+	0xbb, 0xd3, 0x83, 0x39, 0x9e, 0x59, 0xd0, 0x2a, 0xf3, 0x3d, 0x5c, 0x8f, 0xa5, 0xc7, 0x19, 0x3e,
+	0xcd, 0xcb, 0x93, 0x0e, 0x94, 0x43, 0xe3, 0x9c, 0x05, 0xad, 0x0a, 0x1f, 0xd8, 0x88, 0x07, 0x4e,
+	0x8c, 0x73, 0x2a, 0x58, 0xe4, 0x1d, 0xa8, 0x9a, 0x5e, 0x34, 0xc7, 0xe9, 0xab, 0x5c, 0x6a, 0x3d,
+	0x96, 0xea, 0x71, 0x9c, 0x2a, 0x3e, 0x79, 0x0b, 0x60, 0xe6, 0x59, 0xcc, 0x37, 0x42, 0xcf, 0x0f,
+	0x5a, 0xb5, 0x5b, 0xc5, 0x7b, 0x75, 0x9a, 0x42, 0x88, 0x0e, 0x24, 0x64, 0xfe, 0x2c, 0xe8, 0xba,
+	0x56, 0xcf, 0x73, 0x2d, 0x5b, 0x2c, 0xba, 0xce, 0x8f, 0x71, 0x09, 0x87, 0x74, 0xa0, 0x21, 0xae,
+	0x6a, 0xe4, 0x39, 0xb6, 0x79, 0xd1, 0x02, 0x2e, 0x99, 0xc1, 0xc8, 0x7d, 0xa8, 0x1a, 0x91, 0xc9,
+	0xf5, 0x6b, 0x45, 0xaa, 0xb1, 0x5a, 0x5e, 0x57, 0xe0, 0x54, 0x09, 0x90, 0x87, 0x50, 0x37, 0x7d,
+	0xef, 0xb9, 0xb5, 0x83, 0x4a, 0xd1, 0x90, 0xba, 0x14, 0x6f, 0x46, 0x71, 0x68, 0x22, 0xd4, 0xfe,
+	0x5d, 0x11, 0x6a, 0xea, 0x76, 0x48, 0x0b, 0xaa, 0x67, 0xcc, 0x0f, 0xf0, 0xa7, 0xf0, 0xea, 0x57,
+	0xa9, 0x22, 0xc9, 0x36, 0x34, 0x94, 0xa7, 0x9a, 0x5c, 0xcc, 0x19, 0xd7, 0x80, 0xb5, 0xad, 0xb7,
+	0x16, 0x2e, 0x58, 0xef, 0xa5, 0xa4, 0x68, 0x66, 0x0c, 0x79, 0x08, 0x95, 0x13, 0x0f, 0x8d, 0x9e,
+	0xab, 0xc7, 0xda, 0x56, 0x6b, 0x71, 0xf4, 0x0e, 0xe7, 0x53, 0x29, 0x47, 0xb6, 0xa0, 0xc2, 0xce,
+	0xe7, 0xb6, 0x7f, 0x21, 0xb5, 0xa4, 0xad, 0x0b, 0x4f, 0xa8, 0x2b, 0x4f, 0xa8, 0x4f, 0x94, 0x27,
+	0xa4, 0x52, 0x92, 0xdc, 0x87, 0xa6, 0xc1, 0x4d, 0x84, 0x59, 0xbd, 0xc8, 0xf7, 0x99, 0x6b, 0x5e,
+	0x70, 0xf3, 0xaf, 0xd3, 0x05, 0x9c, 0xdc, 0x83, 0xf5, 0xb9, 0x6f, 0x9b, 0xb6, 0x3b, 0x8d, 0x45,
+	0x2b, 0x5c, 0x34, 0x0f, 0x93, 0x36, 0xd4, 0x1c, 0xc3, 0x9d, 0x46, 0xc6, 0x94, 0x71, 0x2b, 0xaf,
+	0xd3, 0x98, 0xc6, 0x4b, 0x67, 0x01, 0x9e, 0x28, 0x2e, 0xc6, 0x8b, 0xc2, 0x5d, 0x2f, 0xe2, 0xca,
+	0x81, 0x07, 0xb8, 0x84, 0xd3, 0x19, 0x41, 0x23, 0x7d, 0x4a, 0x64, 0x03, 0x56, 0x47, 0xbb, 0x5f,
+	0x8c, 0x87, 0xbd, 0xee, 0xde, 0xd1, 0xe3, 0x83, 0x83, 0x7e, 0xf3, 0x0a, 0x69, 0x42, 0xa3, 0x3f,
+	0x7c, 0x3c, 0x9c, 0x28, 0x44, 0x23, 0x2b, 0x50, 0x1d, 0x0f, 0xe8, 0x67, 0xc3, 0xde, 0xa0, 0x59,
+	0x20, 0x6b, 0x00, 0x3d, 0x7a, 0xf0, 0x79, 0xff, 0x68, 0xe7, 0x70, 0xbf, 0xdf, 0x2c, 0x76, 0xee,
+	0x42, 0x45, 0x9c, 0x1c, 0x59, 0x87, 0x95, 0x9d, 0xe1, 0xff, 0x0e, 0xfa, 0x47, 0x23, 0x8a, 0xa2,
+	0x57, 0x70, 0x5c, 0xf7, 0xb0, 0x37, 0x19, 0x1e, 0xec, 0x37, 0xb5, 0xf6, 0x6f, 0x2a, 0x50, 0x42,
+	0xfb, 0x22, 0x9b, 0x50, 0x0e, 0xed, 0xd0, 0x61, 0xd2, 0xc2, 0x05, 0x41, 0x6e, 0xc1, 0x8a, 0x85,
+	0xeb, 0xb5, 0xb9, 0xf1, 0xf0, 0x3b, 0xae, 0xd3, 0x34, 0x44, 0xee, 0xc2, 0xda, 0xdc, 0xf7, 0x4c,
+	0x16, 0x04, 0xb6, 0x3b, 0xc5, 0x4d, 0xf1, 0xab, 0xac, 0xd3, 0x1c, 0x8a, 0xf3, 0xe3, 0x09, 0x32,
+	0x7e, 0x6f, 0x25, 0x2a, 0x08, 0x74, 0x2b, 0x6e, 0x70, 0xf2, 0x9c, 0x5f, 0x47, 0x8d, 0xf2, 0x6f,
+	0xc4, 0x42, 0x63, 0x2a, 0xec, 0xb3, 0x4e, 0xf9, 0x37, 0x79, 0x17, 0x2a, 0xf6, 0xcc, 0x98, 0x32,
+	0x65, 0x8f, 0x57, 0x33, 0xce, 0x41, 0x1f, 0x22, 0x8f, 0x4a, 0x11, 0x34, 0x49, 0xd3, 0x08, 0xd9,
+	0xd4, 0xf3, 0x6d, 0x16, 0x9b, 0x64, 0x82, 0xe0, 0x52, 0xa6, 0xbe, 0x31, 0x13, 0x56, 0x58, 0xa0,
+	0x82, 0x20, 0x37, 0xa0, 0x6e, 0x2a, 0x33, 0x94, 0x56, 0x97, 0x00, 0x44, 0x87, 0xaa, 0x27, 0x1d,
+	0xce, 0x0a, 0x5f, 0xc1, 0x66, 0x76, 0x05, 0xd2, 0xdb, 0x28, 0x21, 0xf2, 0x36, 0x94, 0x82, 0x67,
+	0x51, 0xd0, 0x6a, 0xc8, 0x78, 0x95, 0x11, 0x1e, 0x3f, 0x8b, 0x28, 0x67, 0xb7, 0x7f, 0xae, 0x41,
+	0x45, 0x0c, 0xe5, 0x47, 0x61, 0xcc, 0xd4, 0xf9, 0xf3, 0xef, 0x57, 0x38, 0xfe, 0x47, 0x50, 0x3b,
+	0x33, 0x7c, 0xdb, 0x70, 0xc3, 0xa0, 0x55, 0xe4, 0xbf, 0x75, 0x63, 0xd9, 0xc2, 0xf4, 0xcf, 0x84,
+	0x10, 0x8d, 0xa5, 0xdb, 0xbb, 0x50, 0x95, 0xe0, 0xd2, 0x9f, 0x7e, 0x07, 0xca, 0xfc, 0x38, 0xa5,
+	0x67, 0x5f, 0x7a, 0xe0, 0x42, 0xa2, 0xfd, 0x7d, 0x0d, 0x8a, 0xe3, 0x67, 0x11, 0xba, 0x2e, 0x39,
+	0x7b, 0xcf, 0x9b, 0x1d, 0x7b, 0x3c, 0xb7, 0x58, 0xa5, 0x19, 0x0c, 0x4f, 0x79, 0xee, 0x7b, 0x56,
+	0x64, 0x86, 0x32, 0x68, 0xd4, 0x69, 0x02, 0x20, 0x37, 0x88, 0x7c, 0xf3, 0xd4, 0xf0, 0xa7, 0x42,
+	0x8f, 0x8a, 0x34, 0x01, 0xd0, 0xe2, 0xbe, 0x8c, 0x0c, 0x37, 0xb4, 0x43, 0x61, 0xfd, 0x45, 0x1a,
+	0xd3, 0xed, 0xaf, 0x34, 0x28, 0xf3, 0x45, 0xa1, 0xd4, 0x89, 0xed, 0xb0, 0xd4, 0x86, 0x62, 0x1a,
+	0x79, 0x9e, 0x6f, 0x4f, 0x6d, 0xd7, 0x70, 0xe4, 0x8f, 0xc7, 0x34, 0x6a, 0x85, 0x13, 0xff, 0x6e,
+	0x9d, 0x0a, 0x82, 0x5c, 0x83, 0xca, 0x8c, 0x59, 0x76, 0x24, 0xa2, 0x52, 0x9d, 0x4a, 0x0a, 0xa5,
+	0x83, 0x99, 0xe1, 0x38, 0xd2, 0x91, 0x08, 0x82, 0xab, 0xae, 0xed, 0x2a, 0x97, 0xc1, 0xbf, 0xdb,
+	0x5f, 0x57, 0x60, 0x2d, 0x1b, 0x93, 0x96, 0x9e, 0xf7, 0x23, 0x28, 0x85, 0x89, 0x1b, 0xbd, 0x73,
+	0x49, 0x38, 0x8b, 0x49, 0xee, 0x4c, 0xf9, 0x08, 0x72, 0x17, 0xaa, 0x3e, 0x9b, 0x72, 0xd5, 0x44,
+	0x0d, 0x58, 0xdb, 0x6a, 0xe8, 0x3d, 0x91, 0x31, 0xf6, 0x3c, 0x8b, 0x51, 0xc5, 0x24, 0x4f, 0x60,
+	0x55, 0xc5, 0x42, 0x1a, 0x39, 0x2c, 0x90, 0x1e, 0xf4, 0xed, 0x97, 0xfd, 0x14, 0x17, 0xa6, 0xd9,
+	0xb1, 0xe4, 0x43, 0xa8, 0x05, 0xcc, 0x3f, 0xb3, 0x4d, 0xa6, 0x22, 0xf0, 0xcd, 0x4b, 0xe7, 0x11,
+	0x72, 0x34, 0x1e, 0xd0, 0x36, 0xa0, 0x2a, 0xc1, 0xa5, 0x47, 0x11, 0xbb, 0x8a, 0x42, 0xda, 0x55,
+	0x3c, 0x80, 0x0d, 0x16, 0x84, 0xf6, 0xcc, 0x08, 0x99, 0xd5, 0x67, 0x8e, 0x7d, 0xc6, 0xfc, 0x0b,
+	0x79, 0x57, 0x8b, 0x8c, 0xf6, 0x8f, 0x8a, 0xb0, 0x9a, 0xd9, 0x00, 0xf9, 0x04, 0x6a, 0x7e, 0xe4,
+	0x30, 0x1e, 0xab, 0x34, 0x7e, 0xc8, 0xfa, 0x2b, 0xed, 0x5c, 0xa7, 0x72, 0x14, 0x8d, 0xc7, 0x93,
+	0x8f, 0xa1, 0xec, 0xf3, 0x23, 0x2c, 0xf0, 0xad, 0xdf, 0x7f, 0xf5, 0x89, 0xa8, 0x18, 0xd8, 0x9e,
+	0x40, 0x09, 0x49, 0xd4, 0xc8, 0x99, 0xed, 0x52, 0xc3, 0x9d, 0x32, 0x19, 0x60, 0x63, 0x9a, 0xf3,
+	0x8c, 0x73, 0xc1, 0x2b, 0x48, 0x9e, 0xa4, 0x93, 0x33, 0x2a, 0xa6, 0xce, 0xa8, 0xf3, 0x13, 0x0d,
+	0x6a, 0x6a, 0xb9, 0xe4, 0x0d, 0xd8, 0xf8, 0xf4, 0xb0, 0xbb, 0x3f, 0x19, 0x4e, 0xbe, 0x38, 0xea,
+	0x0f, 0xc7, 0xbd, 0x83, 0xc3, 0xfd, 0x49, 0xf3, 0x0a, 0xf9, 0x17, 0xb8, 0xbe, 0xb3, 0xd7, 0x9d,
+	0x1c, 0xed, 0x0c, 0x06, 0x47, 0x31, 0x9f, 0x76, 0xf7, 0x1f, 0x0f, 0x9a, 0x1a, 0x79, 0x13, 0xde,
+	0x88, 0x99, 0x9f, 0x0f, 0x86, 0x8f, 0x77, 0x27, 0x92, 0x55, 0x40, 0x56, 0xef, 0xe0, 0xe9, 0xf6,
+	0x70, 0x7f, 0xd0, 0x3f, 0x1a, 0xef, 0x0e, 0x47, 0xa3, 0xe1, 0xfe, 0xe3, 0xa3, 0x6e, 0xbf, 0xdf,
+	0x2c, 0x92, 0xb7, 0xa0, 0xbd, 0xc8, 0x1a, 0x1f, 0x6e, 0x4f, 0x68, 0xb7, 0x37, 0x69, 0x96, 0x3a,
+	0x1f, 0x40, 0x23, 0xad, 0xb7, 0x18, 0xcb, 0xf6, 0x0e, 0x30, 0xb6, 0x8d, 0x86, 0xbd, 0x27, 0x87,
+	0xa3, 0xe6, 0x95, 0x7c, 0x90, 0xd2, 0xda, 0x3f, 0xd6, 0xa0, 0x38, 0x31, 0xce, 0x31, 0xff, 0x08,
+	0x8d, 0xf3, 0xf8, 0xd2, 0xea, 0x54, 0x91, 0xe4, 0x01, 0x40, 0x68, 0x9c, 0x53, 0xa9, 0xf9, 0x85,
+	0x25, 0x9a, 0x9f, 0xe2, 0xa3, 0x27, 0x0d, 0x8d, 0x73, 0xb5, 0x0a, 0x7e, 0x6a, 0x35, 0x9a, 0x86,
+	0x30, 0x6a, 0xcc, 0x99, 0x6f, 0x32, 0x37, 0x44, 0xaf, 0x57, 0xe2, 0xa1, 0x21, 0x85, 0x70, 0x57,
+	0x2d, 0x92, 0xbf, 0x4b, 0x62, 0xe5, 0x26, 0x94, 0x4e, 0x8d, 0xe0, 0x54, 0x38, 0x96, 0xdd, 0x2b,
+	0x94, 0x53, 0xe4, 0x0e, 0x34, 0x2c, 0x3b, 0xe0, 0x25, 0x1c, 0x2e, 0x4a, 0x68, 0xec, 0xee, 0x15,
+	0x9a, 0x41, 0xc9, 0x7d, 0x58, 0x97, 0x3f, 0xd5, 0x97, 0x30, 0x77, 0x2c, 0x85, 0x5d, 0x8d, 0xe6,
+	0x19, 0xe4, 0x2e, 0xac, 0xf2, 0xdb, 0x8e, 0x25, 0xd1, 0xdb, 0x94, 0x76, 0x35, 0x9a, 0x85, 0xb7,
+	0x2b, 0x50, 0xc2, 0x92, 0x71, 0x1b, 0xa0, 0xa6, 0x7e, 0xab, 0xfd, 0x03, 0x0d, 0xaa, 0x32, 0x45,
+	0x24, 0xff, 0x01, 0x55, 0xe6, 0x5a, 0x3c, 0x64, 0x6b, 0x2f, 0xcd, 0xa5, 0x94, 0xa8, 0xc8, 0x4f,
+	0xd1, 0x92, 0xd9, 0x28, 0x65, 0xa3, 0x19, 0x0c, 0x65, 0x8e, 0x6d, 0x6b, 0xe8, 0x9a, 0x3e, 0xe3,
+	0x45, 0x9b, 0xd0, 0xd1, 0x0c, 0xd6, 0xfe, 0x1c, 0xea, 0x71, 0xf6, 0x89, 0x5e, 0x60, 0xea, 0x19,
+	0x0e, 0x5f, 0x47, 0x89, 0xf2, 0x6f, 0xf2, 0x9f, 0x50, 0xb3, 0x98, 0x61, 0x39, 0xb6, 0xab, 0x62,
+	0xd0, 0x8b, 0xd6, 0x17, 0xcb, 0x76, 0xfe, 0x5a, 0x87, 0xb2, 0xa8, 0x49, 0xef, 0xc0, 0xaa, 0x48,
+	0x9b, 0xbb, 0x96, 0xe5, 0xb3, 0x20, 0x90, 0xd7, 0x95, 0x05, 0x31, 0xe6, 0x08, 0x60, 0x87, 0xa9,
+	0xdd, 0x24, 0x00, 0x79, 0x17, 0x6a, 0x41, 0x5a, 0x69, 0xb0, 0x14, 0xe0, 0xb3, 0x27, 0xb6, 0x1d,
+	0x0b, 0x90, 0x7f, 0x85, 0x2a, 0xaf, 0x1e, 0x87, 0xfd, 0x56, 0x29, 0xa9, 0x87, 0x14, 0x46, 0x1e,
+	0x41, 0x3d, 0x2e, 0xd3, 0x5b, 0xe5, 0x97, 0x6e, 0x29, 0x11, 0x26, 0xb7, 0xa1, 0x8c, 0xe5, 0x8f,
+	0xaa, 0x59, 0x56, 0xe4, 0x12, 0x78, 0x61, 0x24, 0x38, 0xe4, 0x1e, 0x54, 0xe7, 0xc6, 0x05, 0x3f,
+	0x6e, 0x51, 0x73, 0xae, 0x49, 0xa1, 0x91, 0x40, 0xa9, 0x62, 0xa3, 0xa2, 0xfb, 0x06, 0x7a, 0xab,
+	0x27, 0xec, 0x42, 0xa4, 0x47, 0x0d, 0x9a, 0x42, 0xc8, 0x16, 0x6c, 0x1a, 0x4e, 0xc8, 0x7c, 0xd7,
+	0x08, 0x19, 0x66, 0xa5, 0x86, 0x19, 0x0e, 0xdd, 0x13, 0x4f, 0xd6, 0x2c, 0x4b, 0x79, 0xed, 0x5f,
+	0x69, 0x50, 0x8b, 0x2d, 0xe9, 0x1a, 0x54, 0xf0, 0x48, 0x26, 0x9e, 0x3c, 0x70, 0x49, 0xa1, 0x2d,
+	0x1b, 0xf2, 0x26, 0x44, 0xf0, 0x55, 0x24, 0xde, 0xbf, 0x89, 0x51, 0x5d, 0xb8, 0x73, 0xfe, 0xcd,
+	0x23, 0x6c, 0x68, 0x84, 0x4c, 0x06, 0x5e, 0x41, 0x70, 0x2b, 0xf5, 0x82, 0xd0, 0x70, 0xb8, 0x31,
+	0x89, 0xe0, 0x9b, 0x42, 0x30, 0x18, 0xca, 0x76, 0x09, 0x37, 0x8b, 0x85, 0x60, 0x28, 0x99, 0xa8,
+	0xa2, 0xf2, 0xc7, 0xf7, 0xbd, 0x90, 0xa7, 0x95, 0xbc, 0xcc, 0x4a, 0x63, 0xed, 0xbf, 0x14, 0x64,
+	0x6e, 0x7c, 0x0b, 0x56, 0x1c, 0xe1, 0xe0, 0x77, 0xd1, 0xc0, 0xc5, 0xae, 0xd2, 0x50, 0x26, 0x35,
+	0x91, 0xae, 0x5a, 0xd1, 0xe4, 0x41, 0x92, 0x3a, 0x8a, 0x0c, 0x8d, 0xa4, 0xae, 0x6f, 0x21, 0x71,
+	0xdc, 0x86, 0xb5, 0x6c, 0xc5, 0x1a, 0x17, 0x3a, 0xa9, 0x41, 0xb9, 0x1a, 0x37, 0x37, 0x02, 0x8f,
+	0x73, 0xc6, 0x66, 0x9e, 0x3c, 0x1e, 0xfe, 0x8d, 0x7b, 0x10, 0x25, 0x2b, 0x9e, 0x83, 0x4a, 0xae,
+	0xd3, 0x10, 0xb9, 0x01, 0xc5, 0x63, 0xdb, 0x92, 0xda, 0x23, 0x7a, 0x02, 0xcc, 0xda, 0xb6, 0x2d,
+	0x8a, 0x70, 0x7b, 0xeb, 0x85, 0x89, 0xea, 0x26, 0x94, 0xcf, 0x0c, 0x27, 0x62, 0xf2, 0x62, 0x05,
+	0xd1, 0xfe, 0x9f, 0x57, 0xca, 0x7c, 0x5a, 0x50, 0x95, 0x99, 0x81, 0x52, 0x0b, 0x49, 0xb6, 0xbf,
+	0x29, 0x40, 0x55, 0xaa, 0x2f, 0x79, 0x0f, 0x13, 0xb1, 0xf0, 0xd4, 0xb3, 0x64, 0xf0, 0x7e, 0x23,
+	0xab, 0xde, 0x58, 0x30, 0x9e, 0x7a, 0x16, 0x95, 0x42, 0x68, 0xd5, 0x71, 0x11, 0xae, 0xf2, 0xcc,
+	0x18, 0x40, 0x0d, 0x35, 0x66, 0xdc, 0x77, 0x0a, 0xd7, 0x24, 0x29, 0x1c, 0x65, 0x9e, 0x1a, 0xb6,
+	0x8b, 0x7e, 0x53, 0xea, 0x5d, 0x02, 0xa4, 0xf5, 0xb7, 0x9c, 0xd5, 0x5f, 0xee, 0x14, 0x2d, 0xc6,
+	0x66, 0x63, 0x9e, 0x98, 0xcb, 0xfc, 0x2f, 0x83, 0x5d, 0x52, 0x13, 0x56, 0x2f, 0xad, 0x09, 0x1f,
+	0x41, 0x45, 0xec, 0x89, 0x5c, 0x85, 0xf5, 0x6e, 0xbf, 0x4f, 0x07, 0xe3, 0xf1, 0x11, 0x1d, 0x7c,
+	0x7a, 0x38, 0x18, 0x63, 0x18, 0x07, 0xa8, 0xf4, 0x87, 0x74, 0xd0, 0x9b, 0x34, 0x35, 0xb2, 0x0a,
+	0xf5, 0xa7, 0x07, 0xfd, 0x01, 0xed, 0x4e, 0x06, 0xfd, 0x66, 0xa1, 0xf3, 0x27, 0x0d, 0x36, 0x16,
+	0x7b, 0x6a, 0x2d, 0xa8, 0x7a, 0x08, 0x0e, 0xfb, 0x2a, 0x92, 0x4a, 0x32, 0xeb, 0x97, 0x0a, 0xaf,
+	0xe3, 0x97, 0xb0, 0xf8, 0x13, 0xe7, 0xaf, 0x5c, 0xac, 0x2a, 0xfe, 0x32, 0x28, 0x56, 0xd5, 0x3e,
+	0xfb, 0x32, 0x62, 0x41, 0xc8, 0xac, 0xae, 0x38, 0x78, 0x51, 0x06, 0xe6, 0x61, 0xf2, 0xdf, 0xd0,
+	0x14, 0xae, 0x68, 0x9c, 0x74, 0xa9, 0x44, 0x7e, 0xd9, 0xd4, 0x69, 0x96, 0x41, 0x17, 0x24, 0x3b,
+	0x3f, 0xd4, 0x60, 0x85, 0xef, 0x9c, 0xb2, 0xff, 0x67, 0x66, 0xf8, 0x0f, 0xd9, 0x33, 0x56, 0x76,
+	0xf6, 0x54, 0xd9, 0xf2, 0x86, 0xbe, 0x6d, 0x87, 0xa6, 0x67, 0xbb, 0xc9, 0xb2, 0x38, 0xbb, 0xf3,
+	0x7b, 0x0d, 0xd6, 0x73, 0x0b, 0x26, 0x1f, 0xa7, 0xfa, 0x61, 0x22, 0xe4, 0xde, 0xc9, 0x6f, 0x4a,
+	0x9f, 0xf8, 0x86, 0x1b, 0x18, 0x3c, 0x42, 0x2f, 0x69, 0x91, 0x61, 0x81, 0xa4, 0x44, 0xf9, 0xb2,
+	0x1b, 0x34, 0x01, 0xda, 0x17, 0x70, 0x75, 0xc9, 0xf0, 0x94, 0xfb, 0x1a, 0x27, 0x2d, 0xbc, 0x34,
+	0xc4, 0x63, 0xa0, 0x0a, 0x00, 0x6a, 0xda, 0x18, 0x40, 0xed, 0x8e, 0x4d, 0x07, 0x05, 0x8a, 0x5c,
+	0x20, 0x83, 0x75, 0x46, 0xd0, 0xcc, 0x1f, 0x04, 0xfa, 0x6a, 0xdb, 0x9d, 0x47, 0xe1, 0xd0, 0xb5,
+	0xd8, 0xb9, 0xcc, 0x6e, 0x53, 0xc8, 0x8b, 0x37, 0xd3, 0xf9, 0xa6, 0x0c, 0xcd, 0x85, 0x6e, 0x6e,
+	0x7c, 0xa1, 0x56, 0xf6, 0x42, 0xad, 0xb8, 0x41, 0x59, 0x48, 0x35, 0x28, 0x33, 0x97, 0x5c, 0x7c,
+	0x9d, 0x4b, 0xde, 0x87, 0xe6, 0xfc, 0xf4, 0x22, 0xb0, 0x4d, 0xc3, 0x89, 0x6b, 0x0d, 0xd1, 0x7a,
+	0xee, 0x2c, 0xb4, 0x9e, 0xf5, 0x51, 0x4e, 0x92, 0x2e, 0x8c, 0x25, 0x4f, 0x60, 0xdd, 0xb2, 0xa7,
+	0x76, 0x98, 0x9a, 0x4e, 0x68, 0xf5, 0xed, 0xc5, 0xe9, 0xfa, 0x59, 0x41, 0x9a, 0x1f, 0x89, 0x5d,
+	0xb3, 0xb9, 0x71, 0xe1, 0x45, 0xa1, 0xec, 0x45, 0xb7, 0x96, 0x2c, 0x89, 0xf3, 0xa9, 0x94, 0x23,
+	0xff, 0x05, 0xeb, 0x39, 0x5b, 0x91, 0x6e, 0x7e, 0xd1, 0xa8, 0xf2, 0x82, 0xdc, 0x65, 0x7b, 0xa1,
+	0xe8, 0x43, 0xa3, 0xcb, 0xf6, 0x42, 0xd6, 0x9e, 0x40, 0x33, 0xbf, 0x69, 0xee, 0xc6, 0xd1, 0xd9,
+	0x33, 0x5f, 0x5d, 0x8d, 0x24, 0xd1, 0x4b, 0x60, 0x6b, 0xeb, 0x99, 0xed, 0x4e, 0xf7, 0xa3, 0xd9,
+	0x31, 0x53, 0x0e, 0x39, 0x87, 0xb6, 0x3f, 0x82, 0xf5, 0xdc, 0xde, 0x49, 0x13, 0x8a, 0x91, 0xef,
+	0xc8, 0x09, 0xf1, 0x13, 0x23, 0xed, 0xdc, 0x08, 0x82, 0xe7, 0x9e, 0x6f, 0xa9, 0x12, 0x5e, 0xd1,
+	0xd8, 0x88, 0xa8, 0x88, 0x9d, 0xc7, 0x56, 0xaa, 0xbd, 0xd0, 0x4a, 0x31, 0x45, 0x14, 0x47, 0xd4,
+	0xcd, 0x24, 0x26, 0x59, 0x10, 0x1b, 0x88, 0x02, 0xd8, 0x61, 0x6c, 0xc4, 0xfc, 0xed, 0x8b, 0x50,
+	0xd5, 0x5d, 0x0b, 0x78, 0xe7, 0xa7, 0x1a, 0xac, 0xe7, 0x5f, 0x0f, 0x2e, 0xd7, 0xda, 0xef, 0xee,
+	0x86, 0x3e, 0x00, 0x10, 0xbf, 0x3d, 0x7e, 0xa1, 0x33, 0x4a, 0x09, 0x91, 0xdb, 0x50, 0x15, 0x97,
+	0x1b, 0x48, 0x5d, 0xae, 0xca, 0xdb, 0xa7, 0x0a, 0xef, 0xfc, 0xa2, 0x04, 0x15, 0x81, 0x91, 0x2d,
+	0x95, 0x26, 0xf6, 0x13, 0x77, 0x45, 0xe4, 0x00, 0x9d, 0xc6, 0x1c, 0x9a, 0x92, 0x7a, 0x89, 0x7b,
+	0xfa, 0x43, 0x11, 0x80, 0x66, 0x84, 0x13, 0xa7, 0xa3, 0xe5, 0x9d, 0xce, 0x4b, 0x1f, 0x17, 0x74,
+	0xa8, 0x8b, 0xef, 0xb1, 0xad, 0x52, 0xf3, 0x45, 0x6d, 0x4e, 0x44, 0x5e, 0x96, 0x9c, 0xdf, 0x80,
+	0x3a, 0xff, 0xdc, 0xc7, 0xf4, 0x44, 0x84, 0xf7, 0x04, 0x40, 0xad, 0xe3, 0x04, 0xfe, 0x56, 0x85,
+	0x2f, 0x35, 0xa6, 0x33, 0xee, 0x11, 0xf9, 0xd5, 0x9c, 0x7b, 0x44, 0x99, 0xcc, 0x3d, 0xd7, 0x5e,
+	0xe7, 0x9e, 0x51, 0x77, 0xce, 0x98, 0x8f, 0xad, 0xa6, 0xba, 0x68, 0xc0, 0x4b, 0x12, 0x39, 0x5f,
+	0x46, 0x86, 0x83, 0x29, 0x27, 0x08, 0x8e, 0x24, 0xf3, 0x6d, 0xc3, 0x15, 0xce, 0x4d, 0x43, 0xa8,
+	0xf7, 0x96, 0xb4, 0xb1, 0xf1, 0x9c, 0x31, 0xf1, 0x32, 0xb0, 0x4a, 0xb3, 0x20, 0x86, 0x6d, 0x33,
+	0x0a, 0x42, 0x6f, 0xc6, 0x7c, 0xd9, 0xaf, 0x69, 0xad, 0x72, 0xb9, 0x3c, 0x8c, 0x09, 0x95, 0xcf,
+	0xce, 0x6c, 0xf6, 0xbc, 0xb5, 0x26, 0x52, 0x7e, 0x41, 0x75, 0x7e, 0xad, 0x41, 0x55, 0x3e, 0x5c,
+	0x65, 0xcf, 0x40, 0x7b, 0x9d, 0x33, 0xd8, 0x84, 0xb2, 0xe9, 0x18, 0xf6, 0x4c, 0x65, 0x97, 0x9c,
+	0x58, 0xb4, 0xdd, 0xe2, 0x32, 0xdb, 0xfd, 0x37, 0xa8, 0x7b, 0x51, 0x38, 0xf7, 0x6c, 0x37, 0x54,
+	0x6a, 0x5f, 0xd7, 0x0f, 0x24, 0x42, 0x13, 0x1e, 0xe6, 0x67, 0x01, 0xf3, 0x6d, 0xc3, 0xb1, 0xbf,
+	0xc7, 0x2c, 0xd5, 0x8d, 0xe7, 0x9a, 0xd0, 0xa0, 0x4b, 0x38, 0x9d, 0x3f, 0x96, 0x60, 0x63, 0xe1,
+	0x55, 0xef, 0xef, 0xd8, 0x64, 0xca, 0x49, 0x14, 0xb2, 0x4e, 0x02, 0x6b, 0x1e, 0xdf, 0x9b, 0x7b,
+	0x01, 0xb3, 0xb6, 0x55, 0x8d, 0x94, 0x42, 0x90, 0xef, 0xc7, 0x2b, 0x90, 0x69, 0x6b, 0x0a, 0x21,
+	0x1f, 0xc4, 0xf1, 0x42, 0x14, 0x9d, 0x6f, 0x2e, 0xbe, 0x46, 0xe6, 0x03, 0xc6, 0x43, 0xb8, 0x1a,
+	0xeb, 0x6f, 0x6c, 0x53, 0xa2, 0x6a, 0x68, 0xd0, 0x65, 0xac, 0xf6, 0x6f, 0x0b, 0xaf, 0xeb, 0x7b,
+	0x6f, 0x43, 0x85, 0x27, 0x03, 0xaa, 0x8b, 0x96, 0xba, 0x16, 0xc9, 0x20, 0xdb, 0xb0, 0x22, 0x9e,
+	0x63, 0xa3, 0x70, 0x1e, 0x85, 0xd2, 0xca, 0x6f, 0x5d, 0xba, 0x7c, 0x5d, 0xc8, 0xd1, 0xf4, 0x20,
+	0xd2, 0x87, 0x86, 0x7c, 0x1a, 0x16, 0x93, 0x94, 0x5e, 0x71, 0x92, 0xcc, 0x28, 0xf2, 0x09, 0xac,
+	0xc7, 0xbb, 0x96, 0x13, 0x95, 0x5f, 0x71, 0xa2, 0xfc, 0xc0, 0xf6, 0x23, 0xa8, 0xc8, 0x59, 0xb1,
+	0x52, 0x16, 0x15, 0x83, 0xaa, 0x94, 0x39, 0x95, 0xaa, 0x4f, 0x0a, 0xe9, 0xfa, 0xa4, 0x63, 0xc7,
+	0x2a, 0x97, 0x7a, 0xf3, 0xfd, 0xee, 0x2a, 0xd7, 0x86, 0x9a, 0xe9, 0x48, 0xb5, 0x92, 0xb1, 0x54,
+	0xd1, 0x9d, 0x4f, 0xa0, 0xa6, 0xae, 0x03, 0x53, 0x80, 0xd3, 0xa4, 0xf0, 0xe5, 0xdf, 0x68, 0x93,
+	0x36, 0xcf, 0xeb, 0x44, 0xb9, 0x2b, 0x88, 0xa4, 0x0e, 0x94, 0x6d, 0x49, 0x4e, 0x74, 0x7e, 0x59,
+	0x84, 0x8a, 0x78, 0x87, 0xfe, 0x27, 0x66, 0xe4, 0x64, 0x00, 0x1b, 0xa2, 0xaf, 0x93, 0xca, 0x91,
+	0xa5, 0x36, 0x5c, 0x97, 0xcf, 0xe4, 0xe9, 0xec, 0x1b, 0xfb, 0x1a, 0x74, 0x71, 0xc4, 0xd2, 0xe2,
+	0x3a, 0xb9, 0xaf, 0x4a, 0xa6, 0x9e, 0xbc, 0x03, 0xab, 0xa1, 0x17, 0x1a, 0x8e, 0x98, 0x9d, 0x89,
+	0xe2, 0xba, 0x44, 0xb3, 0x20, 0xbe, 0x48, 0x8b, 0xee, 0x4e, 0x4d, 0xbe, 0x48, 0xcb, 0xc5, 0xa4,
+	0xdb, 0x3b, 0x2d, 0x6c, 0xef, 0xf8, 0xa1, 0x6d, 0x88, 0x30, 0x50, 0xa3, 0x8a, 0x6c, 0x7f, 0x08,
+	0xeb, 0xb9, 0x55, 0xe3, 0x12, 0xc3, 0x73, 0x5b, 0x25, 0x1b, 0xfc, 0x3b, 0x5b, 0xa1, 0xab, 0x9b,
+	0x69, 0x3f, 0x4a, 0x5e, 0xff, 0xec, 0x54, 0x96, 0x2e, 0x88, 0x17, 0x75, 0x35, 0x3a, 0xdf, 0x6a,
+	0x50, 0x18, 0xf6, 0x71, 0xe7, 0x73, 0x96, 0xba, 0x4e, 0x49, 0xf1, 0x16, 0xa0, 0xe3, 0x99, 0xcf,
+	0x78, 0xf5, 0x1c, 0x3f, 0xf5, 0x64, 0x30, 0xf2, 0x36, 0x54, 0xe7, 0xd1, 0xf1, 0x33, 0xec, 0x42,
+	0x09, 0xcb, 0x5e, 0xd1, 0x87, 0x7d, 0x7d, 0x24, 0x20, 0xaa, 0x78, 0xe8, 0xde, 0x8e, 0xe3, 0x1b,
+	0xe5, 0x17, 0xd6, 0xa0, 0x29, 0xa4, 0xfd, 0x11, 0x54, 0xe5, 0x18, 0x5c, 0xb0, 0x6d, 0x31, 0xb1,
+	0x60, 0x91, 0x51, 0xc4, 0x34, 0x9e, 0xa0, 0x1c, 0x24, 0x33, 0x13, 0x45, 0x76, 0xfe, 0xac, 0x41,
+	0x3d, 0xc9, 0x77, 0x1f, 0x60, 0x3b, 0x42, 0x28, 0x87, 0xe8, 0x34, 0x90, 0xe4, 0xef, 0x11, 0xfa,
+	0x98, 0xc9, 0xe7, 0x75, 0x29, 0x82, 0xb9, 0x6d, 0x9c, 0xe0, 0x60, 0xfe, 0x17, 0xc8, 0xc9, 0x73,
+	0x68, 0xe7, 0x2b, 0x0d, 0xdf, 0x3c, 0xc4, 0x98, 0x15, 0xa8, 0xee, 0x0d, 0xc7, 0x93, 0xe1, 0xfe,
+	0xe3, 0xe6, 0x15, 0x82, 0xdd, 0x4a, 0xda, 0x1f, 0xd0, 0xa6, 0x46, 0xae, 0x01, 0xe1, 0x9f, 0x47,
+	0xbd, 0x83, 0xfd, 0x9d, 0x21, 0x7d, 0xda, 0xe5, 0x6f, 0xb4, 0x05, 0x6c, 0xe4, 0x0b, 0x7c, 0xe7,
+	0x70, 0x6f, 0x67, 0xb8, 0xb7, 0xf7, 0x74, 0xb0, 0x3f, 0x69, 0x16, 0xc9, 0x26, 0x34, 0x95, 0xf8,
+	0xd3, 0xd1, 0xde, 0x80, 0x0b, 0x97, 0x70, 0xf2, 0xfe, 0x70, 0x3c, 0x3a, 0x9c, 0x0c, 0x9a, 0x65,
+	0x9c, 0x51, 0x12, 0x47, 0x74, 0x30, 0x3e, 0xd8, 0x3b, 0xe4, 0x42, 0x15, 0x6c, 0x1e, 0xd0, 0x01,
+	0x7f, 0x29, 0xae, 0x76, 0x18, 0xac, 0x8a, 0x56, 0x8f, 0xfa, 0xab, 0x47, 0x07, 0xaa, 0xb2, 0x36,
+	0x94, 0xde, 0x24, 0xf9, 0x77, 0x90, 0x62, 0xc4, 0x1e, 0xa1, 0x90, 0xf2, 0x08, 0x99, 0xe4, 0xaf,
+	0x98, 0x2f, 0xe7, 0xbe, 0xd5, 0xa0, 0xb8, 0x6d, 0x5b, 0xaf, 0xd6, 0x4b, 0xcb, 0x64, 0x7e, 0xf5,
+	0x54, 0xd2, 0xa7, 0xaa, 0xbc, 0x62, 0xaa, 0xca, 0x7b, 0x49, 0x62, 0x97, 0xd8, 0x66, 0x39, 0x63,
+	0x9b, 0x19, 0x7f, 0x53, 0x79, 0x0d, 0x7f, 0xd3, 0xe9, 0x42, 0x3d, 0x6e, 0x8e, 0x91, 0x6b, 0xa2,
+	0x6b, 0x26, 0x4e, 0xaa, 0xa4, 0xab, 0x7e, 0xd9, 0x8b, 0x53, 0xe1, 0xed, 0xd2, 0xff, 0x15, 0xe6,
+	0xc7, 0xc7, 0x15, 0xfe, 0x3b, 0xff, 0xfe, 0xb7, 0x01, 0x00, 0x56, 0x9d, 0x95, 0xe9, 0xf3, 0x25,
+	0x00, 0x00,
 }
@@ -36,6 +36,9 @@ const (
 	Message_OFFLINE_RELAY      Message_MessageType = 15
 	Message_MODERATOR_ADD      Message_MessageType = 16
 	Message_MODERATOR_REMOVE   Message_MessageType = 17
+	Message_BID                Message_MessageType = 18
+	Message_RELAY_REGISTER     Message_MessageType = 19
+	Message_RELAY_STORE        Message_MessageType = 20
 	Message_ERROR              Message_MessageType = 500
 )
 
@@ -58,6 +61,9 @@ var Message_MessageType_name = map[int32]string{
 	15:  "OFFLINE_RELAY",
 	16:  "MODERATOR_ADD",
 	17:  "MODERATOR_REMOVE",
+	18:  "BID",
+	19:  "RELAY_REGISTER",
+	20:  "RELAY_STORE",
 	500: "ERROR",
 }
 var Message_MessageType_value = map[string]int32{
@@ -79,6 +85,9 @@ var Message_MessageType_value = map[string]int32{
 	"OFFLINE_RELAY":      15,
 	"MODERATOR_ADD":      16,
 	"MODERATOR_REMOVE":   17,
+	"BID":                18,
+	"RELAY_REGISTER":     19,
+	"RELAY_STORE":        20,
 	"ERROR":              500,
 }
 
@@ -109,7 +118,7 @@ var Chat_Flag_value = map[string]int32{
 func (x Chat_Flag) String() string {
 	return proto.EnumName(Chat_Flag_name, int32(x))
 }
-func (Chat_Flag) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{2, 0} }
+func (Chat_Flag) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{4, 0} }
 
 type Message struct {
 	MessageType Message_MessageType   `protobuf:"varint,1,opt,name=messageType,enum=Message_MessageType" json:"messageType,omitempty"`
@@ -183,6 +192,89 @@ func (m *Envelope) GetSignature() []byte {
 	return nil
 }
 
+// An offline message held by a relay until the recipient connects
+type RelayedMessage struct {
+	Recipient  string `protobuf:"bytes,1,opt,name=recipient" json:"recipient,omitempty"`
+	PointerID  string `protobuf:"bytes,2,opt,name=pointerID" json:"pointerID,omitempty"`
+	Address    string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	Ciphertext []byte `protobuf:"bytes,4,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (m *RelayedMessage) Reset()                    { *m = RelayedMessage{} }
+func (m *RelayedMessage) String() string            { return proto.CompactTextString(m) }
+func (*RelayedMessage) ProtoMessage()               {}
+func (*RelayedMessage) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+func (m *RelayedMessage) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+func (m *RelayedMessage) GetPointerID() string {
+	if m != nil {
+		return m.PointerID
+	}
+	return ""
+}
+
+func (m *RelayedMessage) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RelayedMessage) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+// Sent with a PING and its response so each side learns what the other supports. Nodes
+// released before this existed answer a PING by echoing it back unchanged.
+type Capabilities struct {
+	PeerID          string   `protobuf:"bytes,1,opt,name=peerID" json:"peerID,omitempty"`
+	ProtocolVersion uint32   `protobuf:"varint,2,opt,name=protocolVersion" json:"protocolVersion,omitempty"`
+	Features        []string `protobuf:"bytes,3,rep,name=features" json:"features,omitempty"`
+	UserAgent       string   `protobuf:"bytes,4,opt,name=userAgent" json:"userAgent,omitempty"`
+}
+
+func (m *Capabilities) Reset()                    { *m = Capabilities{} }
+func (m *Capabilities) String() string            { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()               {}
+func (*Capabilities) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+
+func (m *Capabilities) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *Capabilities) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Capabilities) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *Capabilities) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
 type Chat struct {
 	MessageId string                     `protobuf:"bytes,1,opt,name=messageId" json:"messageId,omitempty"`
 	Subject   string                     `protobuf:"bytes,2,opt,name=subject" json:"subject,omitempty"`
@@ -194,7 +286,7 @@ type Chat struct {
 func (m *Chat) Reset()                    { *m = Chat{} }
 func (m *Chat) String() string            { return proto.CompactTextString(m) }
 func (*Chat) ProtoMessage()               {}
-func (*Chat) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+func (*Chat) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
 
 func (m *Chat) GetMessageId() string {
 	if m != nil {
@@ -234,6 +326,8 @@ func (m *Chat) GetFlag() Chat_Flag {
 func init() {
 	proto.RegisterType((*Message)(nil), "Message")
 	proto.RegisterType((*Envelope)(nil), "Envelope")
+	proto.RegisterType((*RelayedMessage)(nil), "RelayedMessage")
+	proto.RegisterType((*Capabilities)(nil), "Capabilities")
 	proto.RegisterType((*Chat)(nil), "Chat")
 	proto.RegisterEnum("Message_MessageType", Message_MessageType_name, Message_MessageType_value)
 	proto.RegisterEnum("Chat_Flag", Chat_Flag_name, Chat_Flag_value)
@@ -242,41 +336,51 @@ func init() {
 func init() { proto.RegisterFile("message.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 573 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x92, 0x4f, 0x6f, 0xd3, 0x4c,
-	0x10, 0xc6, 0xeb, 0xc4, 0xf9, 0x37, 0x4e, 0xdb, 0xed, 0xaa, 0x6f, 0x95, 0xb7, 0x42, 0x25, 0xca,
-	0x29, 0x5c, 0x5c, 0x29, 0x48, 0x88, 0xab, 0xb1, 0xd7, 0xc5, 0x60, 0x7b, 0xa3, 0x89, 0x03, 0x2a,
-	0x97, 0xc8, 0x21, 0xdb, 0x50, 0x48, 0x63, 0x53, 0x3b, 0x48, 0xf9, 0x90, 0x7c, 0x04, 0xbe, 0x05,
-	0x77, 0xd0, 0x6e, 0xbc, 0x24, 0x82, 0x93, 0x77, 0x7e, 0xcf, 0xa3, 0x99, 0xb1, 0x9e, 0x81, 0xe3,
-	0x07, 0x51, 0x14, 0xe9, 0x52, 0xd8, 0xf9, 0x63, 0x56, 0x66, 0x97, 0xff, 0x2f, 0xb3, 0x6c, 0xb9,
-	0x12, 0xd7, 0xaa, 0x9a, 0x6f, 0xee, 0xae, 0xd3, 0xf5, 0xb6, 0x92, 0x9e, 0xfe, 0x2d, 0x95, 0xf7,
-	0x0f, 0xa2, 0x28, 0xd3, 0x87, 0x7c, 0x67, 0x18, 0xfc, 0xaa, 0x43, 0x2b, 0xda, 0x75, 0xa3, 0x2f,
-	0xc0, 0xaa, 0x1a, 0x27, 0xdb, 0x5c, 0xf4, 0x8c, 0xbe, 0x31, 0x3c, 0x19, 0x9d, 0xdb, 0x95, 0xac,
-	0xbf, 0x52, 0xc3, 0x43, 0x23, 0xb5, 0xa1, 0x95, 0xa7, 0xdb, 0x55, 0x96, 0x2e, 0x7a, 0xb5, 0xbe,
-	0x31, 0xb4, 0x46, 0xe7, 0xf6, 0x6e, 0xac, 0xad, 0xc7, 0xda, 0xce, 0x7a, 0x8b, 0xda, 0x44, 0x9f,
-	0x40, 0xe7, 0x51, 0x7c, 0xdd, 0x88, 0xa2, 0x0c, 0x16, 0xbd, 0x7a, 0xdf, 0x18, 0x36, 0x70, 0x0f,
-	0xe8, 0x15, 0xc0, 0x7d, 0x81, 0xa2, 0xc8, 0xb3, 0x75, 0x21, 0x7a, 0x66, 0xdf, 0x18, 0xb6, 0xf1,
-	0x80, 0x0c, 0xbe, 0xd7, 0xc0, 0x3a, 0x58, 0x85, 0xb6, 0xc1, 0x1c, 0x07, 0xf1, 0x0d, 0x39, 0x92,
-	0x2f, 0xf7, 0xb5, 0x93, 0x10, 0x83, 0x02, 0x34, 0x7d, 0x1e, 0x86, 0xfc, 0x3d, 0xa9, 0xd1, 0x2e,
-	0xb4, 0xa7, 0x71, 0x55, 0xd5, 0x69, 0x07, 0x1a, 0x1c, 0x3d, 0x86, 0xc4, 0xa4, 0x04, 0xba, 0xea,
-	0x39, 0x43, 0xf6, 0x86, 0xb9, 0x09, 0x69, 0xec, 0x89, 0xeb, 0xc4, 0x2e, 0x0b, 0x49, 0x93, 0x5e,
-	0x00, 0xad, 0x08, 0x8f, 0xfd, 0x00, 0x23, 0x27, 0x09, 0x78, 0x4c, 0x5a, 0xf4, 0x3f, 0x38, 0xdb,
-	0x71, 0x7f, 0x1a, 0xfa, 0x41, 0x18, 0x46, 0x2c, 0x4e, 0x48, 0x9b, 0x9e, 0x03, 0xd1, 0xf6, 0x68,
-	0x1c, 0x32, 0x65, 0xee, 0xc8, 0xb6, 0x5e, 0x30, 0x19, 0x4f, 0x13, 0x36, 0xe3, 0x63, 0x16, 0x13,
-	0xa0, 0x14, 0x4e, 0x34, 0x99, 0x8e, 0x3d, 0x27, 0x61, 0xc4, 0xa2, 0x67, 0x70, 0xac, 0x99, 0x1b,
-	0xf2, 0x09, 0x23, 0x5d, 0xf9, 0x1b, 0xc8, 0xfc, 0x69, 0xec, 0x91, 0x63, 0x7a, 0x0a, 0x16, 0xf7,
-	0xfd, 0x30, 0x88, 0xd9, 0xcc, 0x71, 0xdf, 0x92, 0x13, 0xe9, 0xd7, 0x00, 0x59, 0xe8, 0xdc, 0x92,
-	0x53, 0x89, 0x22, 0xee, 0x31, 0x74, 0x12, 0x8e, 0x33, 0xc7, 0xf3, 0x08, 0x91, 0x1b, 0xed, 0x11,
-	0xb2, 0x88, 0xbf, 0x63, 0xe4, 0x8c, 0x02, 0x34, 0x18, 0x22, 0x47, 0xf2, 0xb3, 0x3e, 0x58, 0x40,
-	0x9b, 0xad, 0xbf, 0x89, 0x55, 0x96, 0x0b, 0x3a, 0x80, 0x56, 0x15, 0xac, 0x4a, 0xdf, 0x1a, 0xb5,
-	0x75, 0xea, 0xa8, 0x05, 0x7a, 0x01, 0xcd, 0x7c, 0x33, 0xff, 0x22, 0xb6, 0x2a, 0xec, 0x2e, 0x56,
-	0x95, 0x4c, 0xb5, 0xb8, 0x5f, 0xae, 0xd3, 0x72, 0xf3, 0x28, 0x54, 0xaa, 0x5d, 0xdc, 0x83, 0xc1,
-	0x0f, 0x03, 0x4c, 0xf7, 0x53, 0x5a, 0x4a, 0x5b, 0xd5, 0x29, 0x58, 0xa8, 0x21, 0x1d, 0xdc, 0x03,
-	0xda, 0x83, 0x56, 0xb1, 0x99, 0x7f, 0x16, 0x1f, 0x4b, 0xd5, 0xbd, 0x83, 0xba, 0x94, 0x8a, 0x5e,
-	0xad, 0xbe, 0x53, 0xf4, 0x42, 0x2f, 0xa1, 0xf3, 0xe7, 0xaa, 0xd5, 0xbd, 0x58, 0xa3, 0xcb, 0x7f,
-	0x0e, 0x30, 0xd1, 0x0e, 0xdc, 0x9b, 0xe9, 0x15, 0x98, 0x77, 0xab, 0x74, 0xd9, 0x6b, 0xa8, 0x4b,
-	0x07, 0x5b, 0x2e, 0x68, 0xfb, 0xab, 0x74, 0x89, 0x8a, 0x0f, 0x9e, 0x81, 0x29, 0x2b, 0x6a, 0x41,
-	0x2b, 0x62, 0x93, 0x89, 0x73, 0xc3, 0xc8, 0x91, 0x0c, 0x25, 0xb9, 0x55, 0x17, 0x67, 0xc8, 0x8b,
-	0x43, 0xe6, 0x78, 0xa4, 0xf6, 0xca, 0xfc, 0x50, 0xcb, 0xe7, 0xf3, 0xa6, 0x9a, 0xf7, 0xfc, 0x77,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xaa, 0x0a, 0x22, 0x77, 0xa1, 0x03, 0x00, 0x00,
+	// 734 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x94, 0xcf, 0x6e, 0xa3, 0x56,
+	0x14, 0xc6, 0x07, 0x1b, 0xc7, 0xe6, 0xe0, 0x24, 0x37, 0xb7, 0xe9, 0xc8, 0x8d, 0xaa, 0x69, 0xc4,
+	0xca, 0xdd, 0x30, 0x52, 0x2a, 0x55, 0xdd, 0x32, 0x70, 0x49, 0x69, 0xf9, 0x63, 0x1d, 0xe3, 0xa9,
+	0xa6, 0x1b, 0x0b, 0xc7, 0x37, 0x1e, 0x5a, 0x02, 0x14, 0x70, 0x55, 0xbf, 0x40, 0x17, 0x7d, 0x97,
+	0x3e, 0x52, 0xdf, 0xa2, 0xbb, 0x6e, 0xaa, 0x7b, 0x0d, 0xc1, 0xca, 0xec, 0xf8, 0x7e, 0xe7, 0x98,
+	0xef, 0x9c, 0xeb, 0xef, 0x02, 0xe7, 0x4f, 0xbc, 0xae, 0x93, 0x1d, 0x37, 0xcb, 0xaa, 0x68, 0x8a,
+	0x9b, 0x2f, 0x76, 0x45, 0xb1, 0xcb, 0xf8, 0x5b, 0xa9, 0x36, 0xfb, 0xc7, 0xb7, 0x49, 0x7e, 0x68,
+	0x4b, 0x5f, 0xbd, 0x2c, 0x35, 0xe9, 0x13, 0xaf, 0x9b, 0xe4, 0xa9, 0x3c, 0x36, 0x18, 0x7f, 0xab,
+	0x30, 0x0e, 0x8e, 0x6f, 0xa3, 0xdf, 0x82, 0xde, 0xbe, 0x38, 0x3e, 0x94, 0x7c, 0xa6, 0xdc, 0x2a,
+	0xf3, 0x8b, 0xbb, 0x6b, 0xb3, 0x2d, 0x9b, 0x41, 0x5f, 0xc3, 0xd3, 0x46, 0x6a, 0xc2, 0xb8, 0x4c,
+	0x0e, 0x59, 0x91, 0x6c, 0x67, 0x83, 0x5b, 0x65, 0xae, 0xdf, 0x5d, 0x9b, 0x47, 0x5b, 0xb3, 0xb3,
+	0x35, 0xad, 0xfc, 0x80, 0x5d, 0x13, 0xfd, 0x12, 0xb4, 0x8a, 0xff, 0xb6, 0xe7, 0x75, 0xe3, 0x6d,
+	0x67, 0xc3, 0x5b, 0x65, 0x3e, 0xc2, 0x1e, 0xd0, 0x37, 0x00, 0x69, 0x8d, 0xbc, 0x2e, 0x8b, 0xbc,
+	0xe6, 0x33, 0xf5, 0x56, 0x99, 0x4f, 0xf0, 0x84, 0x18, 0xff, 0x0d, 0x40, 0x3f, 0x19, 0x85, 0x4e,
+	0x40, 0x5d, 0x78, 0xe1, 0x3d, 0x79, 0x25, 0x9e, 0xec, 0xef, 0xad, 0x98, 0x28, 0x14, 0xe0, 0xcc,
+	0x8d, 0x7c, 0x3f, 0xfa, 0x89, 0x0c, 0xe8, 0x14, 0x26, 0xab, 0xb0, 0x55, 0x43, 0xaa, 0xc1, 0x28,
+	0x42, 0x87, 0x21, 0x51, 0x29, 0x81, 0xa9, 0x7c, 0x5c, 0x23, 0xfb, 0x81, 0xd9, 0x31, 0x19, 0xf5,
+	0xc4, 0xb6, 0x42, 0x9b, 0xf9, 0xe4, 0x8c, 0xbe, 0x06, 0xda, 0x92, 0x28, 0x74, 0x3d, 0x0c, 0xac,
+	0xd8, 0x8b, 0x42, 0x32, 0xa6, 0x9f, 0xc3, 0xd5, 0x91, 0xbb, 0x2b, 0xdf, 0xf5, 0x7c, 0x3f, 0x60,
+	0x61, 0x4c, 0x26, 0xf4, 0x1a, 0x48, 0xd7, 0x1e, 0x2c, 0x7c, 0x26, 0x9b, 0x35, 0xf1, 0x5a, 0xc7,
+	0x5b, 0x2e, 0x56, 0x31, 0x5b, 0x47, 0x0b, 0x16, 0x12, 0xa0, 0x14, 0x2e, 0x3a, 0xb2, 0x5a, 0x38,
+	0x56, 0xcc, 0x88, 0x4e, 0xaf, 0xe0, 0xbc, 0x63, 0xb6, 0x1f, 0x2d, 0x19, 0x99, 0x8a, 0x35, 0x90,
+	0xb9, 0xab, 0xd0, 0x21, 0xe7, 0xf4, 0x12, 0xf4, 0xc8, 0x75, 0x7d, 0x2f, 0x64, 0x6b, 0xcb, 0xfe,
+	0x91, 0x5c, 0x88, 0xfe, 0x0e, 0x20, 0xf3, 0xad, 0x0f, 0xe4, 0x52, 0xa0, 0x20, 0x72, 0x18, 0x5a,
+	0x71, 0x84, 0x6b, 0xcb, 0x71, 0x08, 0x11, 0x13, 0xf5, 0x08, 0x59, 0x10, 0xbd, 0x67, 0xe4, 0x8a,
+	0x8e, 0x61, 0xf8, 0xce, 0x73, 0x08, 0x15, 0x83, 0xc8, 0x1f, 0xaf, 0x91, 0xdd, 0x7b, 0xcb, 0x98,
+	0x21, 0xf9, 0x4c, 0x38, 0x1d, 0xd9, 0x32, 0x8e, 0x90, 0x91, 0x6b, 0x0a, 0x30, 0x62, 0x88, 0x11,
+	0x92, 0x7f, 0x87, 0xc6, 0x16, 0x26, 0x2c, 0xff, 0x9d, 0x67, 0x45, 0xc9, 0xa9, 0x01, 0xe3, 0x36,
+	0x06, 0x32, 0x2b, 0xfa, 0xdd, 0xa4, 0xcb, 0x08, 0x76, 0x05, 0xfa, 0x1a, 0xce, 0xca, 0xfd, 0xe6,
+	0x57, 0x7e, 0x90, 0xd1, 0x98, 0x62, 0xab, 0x44, 0x06, 0xea, 0x74, 0x97, 0x27, 0xcd, 0xbe, 0xe2,
+	0x32, 0x03, 0x53, 0xec, 0x81, 0xf1, 0xa7, 0x02, 0x17, 0xc8, 0xb3, 0xe4, 0xc0, 0xb7, 0x5d, 0x38,
+	0x65, 0x68, 0x1e, 0xd2, 0x32, 0xe5, 0x79, 0x23, 0xed, 0x34, 0xec, 0x81, 0xa8, 0x96, 0x45, 0x9a,
+	0x37, 0xbc, 0xf2, 0x1c, 0xe9, 0xa4, 0x61, 0x0f, 0xe8, 0x0c, 0xc6, 0xc9, 0x76, 0x5b, 0xf1, 0xba,
+	0x96, 0x56, 0x1a, 0x76, 0x52, 0x84, 0xed, 0x21, 0x2d, 0x3f, 0xf2, 0xaa, 0xe1, 0x7f, 0x34, 0x32,
+	0x6c, 0x53, 0x3c, 0x21, 0xc6, 0x5f, 0x0a, 0x4c, 0xed, 0xa4, 0x4c, 0x36, 0x69, 0x96, 0x36, 0x29,
+	0xaf, 0xe5, 0x3e, 0x5c, 0xba, 0x1c, 0x67, 0x68, 0x15, 0x9d, 0xc3, 0xa5, 0x0c, 0xfb, 0x43, 0x91,
+	0xbd, 0xe7, 0x55, 0x9d, 0x16, 0xb9, 0x1c, 0xe3, 0x1c, 0x5f, 0x62, 0x7a, 0x03, 0x93, 0x47, 0x2e,
+	0xd7, 0x14, 0xd3, 0x0c, 0xe7, 0x1a, 0x3e, 0x6b, 0xb1, 0xc6, 0xbe, 0xe6, 0x95, 0xb5, 0xe3, 0xf9,
+	0x71, 0x1a, 0x0d, 0x7b, 0x60, 0xfc, 0xa3, 0x80, 0x6a, 0x7f, 0x4c, 0xe4, 0xb6, 0xed, 0xf9, 0x7a,
+	0xdb, 0xee, 0x2c, 0x9e, 0x81, 0xd8, 0xb6, 0xde, 0x6f, 0x7e, 0xe1, 0x0f, 0x4d, 0x7b, 0x12, 0x9d,
+	0x14, 0x95, 0xee, 0x0f, 0x6b, 0xcf, 0xa1, 0x95, 0xf4, 0x3b, 0xd0, 0x9e, 0xbf, 0x0c, 0xd2, 0x58,
+	0xbf, 0xbb, 0xf9, 0xe4, 0x12, 0xc7, 0x5d, 0x07, 0xf6, 0xcd, 0xf4, 0x0d, 0xa8, 0x8f, 0x59, 0xb2,
+	0x9b, 0x8d, 0xe4, 0xd7, 0x02, 0x4c, 0x31, 0xa0, 0xe9, 0x66, 0xc9, 0x0e, 0x25, 0x37, 0xbe, 0x06,
+	0x55, 0x28, 0xaa, 0xc3, 0x38, 0x60, 0xcb, 0xa5, 0x75, 0xcf, 0xc8, 0x2b, 0x11, 0xec, 0xf8, 0x83,
+	0xbc, 0xb5, 0x8a, 0xb8, 0xb5, 0xc8, 0x2c, 0x87, 0x0c, 0xde, 0xa9, 0x3f, 0x0f, 0xca, 0xcd, 0xe6,
+	0x4c, 0xfa, 0x7d, 0xf3, 0xff, 0x00, 0xe1, 0x72, 0x8e, 0x6c, 0xe5, 0x04, 0x00, 0x00,
 }
@@ -44,6 +44,11 @@ const (
 	// The winning party has accepted the dispute and it is now complete. After the buyer
 	// leaves a review the state should be set to COMPLETE.
 	OrderState_RESOLVED OrderState = 12
+	// Vendor refunded part of the order and the remaining funds are still held for it
+	OrderState_PARTIALLY_REFUNDED OrderState = 13
+	// The buyer never completed the order and the vendor claimed the escrowed funds after the
+	// escrow timeout expired.
+	OrderState_PAYMENT_FINALIZED OrderState = 14
 )
 
 var OrderState_name = map[int32]string{
@@ -60,6 +65,8 @@ var OrderState_name = map[int32]string{
 	10: "DISPUTED",
 	11: "DECIDED",
 	12: "RESOLVED",
+	13: "PARTIALLY_REFUNDED",
+	14: "PAYMENT_FINALIZED",
 }
 var OrderState_value = map[string]int32{
 	"PENDING":              0,
@@ -75,6 +82,8 @@ var OrderState_value = map[string]int32{
 	"DISPUTED":             10,
 	"DECIDED":              11,
 	"RESOLVED":             12,
+	"PARTIALLY_REFUNDED":   13,
+	"PAYMENT_FINALIZED":    14,
 }
 
 func (x OrderState) String() string {
@@ -89,19 +98,20 @@ func init() {
 func init() { proto.RegisterFile("orders.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 215 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x44, 0x8f, 0x4d, 0x4e, 0xc3, 0x40,
-	0x0c, 0x46, 0x69, 0x29, 0xfd, 0x71, 0x83, 0xb0, 0xa6, 0x95, 0xe0, 0x0c, 0x2c, 0xd8, 0x70, 0x82,
-	0x61, 0xec, 0x54, 0x16, 0xee, 0x64, 0xd4, 0x64, 0x40, 0x65, 0x83, 0xa8, 0xe8, 0x3a, 0x55, 0xc8,
-	0xa1, 0x39, 0x06, 0x72, 0x10, 0x74, 0xf9, 0xde, 0xb3, 0x17, 0x1f, 0x14, 0x6d, 0xf7, 0x79, 0xec,
-	0xbe, 0x1e, 0x4e, 0x5d, 0xdb, 0xb7, 0xf7, 0xdf, 0x23, 0x80, 0xca, 0x44, 0xdd, 0x7f, 0xf4, 0x47,
-	0xb7, 0x84, 0x59, 0xe2, 0x48, 0x12, 0x37, 0x78, 0xe1, 0xd6, 0x80, 0xfe, 0xd5, 0x4b, 0x23, 0x71,
-	0xf3, 0x9e, 0xfc, 0x7e, 0xcb, 0xb1, 0xc1, 0x91, 0x5b, 0xc1, 0xcd, 0xd9, 0x4a, 0x78, 0xce, 0x09,
-	0xc7, 0xee, 0x0e, 0xd6, 0xff, 0xb2, 0xcc, 0x5a, 0x8a, 0xea, 0x70, 0x7e, 0xe9, 0x6e, 0x61, 0x95,
-	0xfc, 0xae, 0x11, 0xaf, 0xba, 0xff, 0x4b, 0x4c, 0x38, 0x71, 0xd7, 0xb0, 0x38, 0xe3, 0x95, 0x61,
-	0xa8, 0xb6, 0x49, 0xb9, 0x61, 0xc2, 0xa9, 0x2b, 0x60, 0x1e, 0x7c, 0x0c, 0x6c, 0x71, 0x66, 0x44,
-	0x1c, 0x54, 0x22, 0x13, 0xce, 0x8d, 0x76, 0x5c, 0xe6, 0x48, 0x4c, 0xb8, 0x18, 0x9a, 0xd4, 0x29,
-	0xdb, 0x1f, 0xd8, 0x00, 0xe2, 0x20, 0x96, 0x96, 0xbf, 0x87, 0x75, 0xa5, 0x2f, 0x4c, 0x58, 0x3c,
-	0x4d, 0xde, 0xc6, 0xa7, 0xc3, 0x61, 0x3a, 0xec, 0x7e, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0x23,
-	0x62, 0x6c, 0x81, 0x07, 0x01, 0x00, 0x00,
+	// 235 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x90, 0x4d, 0x4e, 0xc3, 0x30,
+	0x10, 0x85, 0x21, 0x94, 0xfe, 0x4c, 0x53, 0x18, 0xa6, 0xe5, 0xe7, 0x0c, 0x2c, 0xd8, 0x70, 0x02,
+	0xe3, 0x99, 0x54, 0x23, 0x5c, 0xc7, 0x6a, 0x12, 0x50, 0xbb, 0xa9, 0xa8, 0xe8, 0x3a, 0x55, 0xc8,
+	0x5d, 0xb8, 0x2e, 0x72, 0x80, 0x66, 0xf9, 0xbd, 0xef, 0xd9, 0xf2, 0x33, 0xa4, 0x75, 0xf3, 0x79,
+	0x68, 0xbe, 0x9e, 0x8e, 0x4d, 0xdd, 0xd6, 0x8f, 0xdf, 0x09, 0x40, 0x1e, 0x83, 0xa2, 0xfd, 0x68,
+	0x0f, 0x34, 0x85, 0x51, 0x10, 0xcf, 0xea, 0x97, 0x78, 0x46, 0x0b, 0x40, 0xf3, 0x6e, 0xb4, 0x54,
+	0xbf, 0xdc, 0x05, 0xb3, 0x59, 0x89, 0x2f, 0xf1, 0x9c, 0xe6, 0x70, 0xdd, 0xa7, 0x6a, 0x5f, 0xab,
+	0x80, 0x09, 0x3d, 0xc0, 0xe2, 0x14, 0x66, 0x95, 0xcb, 0xd4, 0xb9, 0xae, 0x7e, 0x41, 0xf7, 0x30,
+	0x0f, 0x66, 0x5d, 0xaa, 0x71, 0x6e, 0xf3, 0xaf, 0x84, 0x71, 0x40, 0x33, 0x98, 0xf4, 0x78, 0x19,
+	0xd1, 0xe6, 0xab, 0xe0, 0xa4, 0x14, 0xc6, 0x21, 0xa5, 0x30, 0xb6, 0xc6, 0x5b, 0x89, 0x72, 0x14,
+	0x89, 0xc5, 0x3a, 0xf5, 0xc2, 0x38, 0x8e, 0xb4, 0x96, 0xac, 0xf2, 0x2c, 0x8c, 0x93, 0xce, 0x69,
+	0x11, 0xaa, 0x78, 0x0e, 0xe2, 0x00, 0x16, 0xab, 0x51, 0x4d, 0x7f, 0x8b, 0x45, 0xee, 0xde, 0x84,
+	0x31, 0xa5, 0x3b, 0xa0, 0xfe, 0x25, 0xa7, 0x0b, 0x66, 0x74, 0x0b, 0x37, 0x7f, 0xeb, 0x76, 0x99,
+	0x7a, 0xe3, 0x74, 0x2b, 0x8c, 0x57, 0x2f, 0x83, 0x6d, 0x72, 0xdc, 0xef, 0x87, 0xdd, 0x37, 0x3d,
+	0xff, 0x0c, 0x00, 0x22, 0x85, 0xb1, 0xd1, 0x36, 0x01, 0x00, 0x00,
 }
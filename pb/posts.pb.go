@@ -0,0 +1,237 @@
+// Code generated by protoc-gen-go.
+// source: posts.proto
+// DO NOT EDIT!
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type Post struct {
+	Slug      string                     `protobuf:"bytes,1,opt,name=slug" json:"slug,omitempty"`
+	VendorID  *ID                        `protobuf:"bytes,2,opt,name=vendorID" json:"vendorID,omitempty"`
+	Status    string                     `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	LongForm  string                     `protobuf:"bytes,4,opt,name=longForm" json:"longForm,omitempty"`
+	Tags      []string                   `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+	Images    []*Post_Image              `protobuf:"bytes,6,rep,name=images" json:"images,omitempty"`
+	Listings  []*Post_ListingLink        `protobuf:"bytes,7,rep,name=listings" json:"listings,omitempty"`
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *Post) Reset()                    { *m = Post{} }
+func (m *Post) String() string            { return proto.CompactTextString(m) }
+func (*Post) ProtoMessage()               {}
+func (*Post) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+
+func (m *Post) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *Post) GetVendorID() *ID {
+	if m != nil {
+		return m.VendorID
+	}
+	return nil
+}
+
+func (m *Post) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Post) GetLongForm() string {
+	if m != nil {
+		return m.LongForm
+	}
+	return ""
+}
+
+func (m *Post) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Post) GetImages() []*Post_Image {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+func (m *Post) GetListings() []*Post_ListingLink {
+	if m != nil {
+		return m.Listings
+	}
+	return nil
+}
+
+func (m *Post) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type Post_Image struct {
+	Filename string `protobuf:"bytes,1,opt,name=filename" json:"filename,omitempty"`
+	Original string `protobuf:"bytes,2,opt,name=original" json:"original,omitempty"`
+	Large    string `protobuf:"bytes,3,opt,name=large" json:"large,omitempty"`
+	Medium   string `protobuf:"bytes,4,opt,name=medium" json:"medium,omitempty"`
+	Small    string `protobuf:"bytes,5,opt,name=small" json:"small,omitempty"`
+	Tiny     string `protobuf:"bytes,6,opt,name=tiny" json:"tiny,omitempty"`
+}
+
+func (m *Post_Image) Reset()                    { *m = Post_Image{} }
+func (m *Post_Image) String() string            { return proto.CompactTextString(m) }
+func (*Post_Image) ProtoMessage()               {}
+func (*Post_Image) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 0} }
+
+func (m *Post_Image) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *Post_Image) GetOriginal() string {
+	if m != nil {
+		return m.Original
+	}
+	return ""
+}
+
+func (m *Post_Image) GetLarge() string {
+	if m != nil {
+		return m.Large
+	}
+	return ""
+}
+
+func (m *Post_Image) GetMedium() string {
+	if m != nil {
+		return m.Medium
+	}
+	return ""
+}
+
+func (m *Post_Image) GetSmall() string {
+	if m != nil {
+		return m.Small
+	}
+	return ""
+}
+
+func (m *Post_Image) GetTiny() string {
+	if m != nil {
+		return m.Tiny
+	}
+	return ""
+}
+
+type Post_ListingLink struct {
+	PeerID string `protobuf:"bytes,1,opt,name=peerID" json:"peerID,omitempty"`
+	Slug   string `protobuf:"bytes,2,opt,name=slug" json:"slug,omitempty"`
+}
+
+func (m *Post_ListingLink) Reset()                    { *m = Post_ListingLink{} }
+func (m *Post_ListingLink) String() string            { return proto.CompactTextString(m) }
+func (*Post_ListingLink) ProtoMessage()               {}
+func (*Post_ListingLink) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 1} }
+
+func (m *Post_ListingLink) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *Post_ListingLink) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+type SignedPost struct {
+	Post      *Post  `protobuf:"bytes,1,opt,name=post" json:"post,omitempty"`
+	Hash      string `protobuf:"bytes,2,opt,name=hash" json:"hash,omitempty"`
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedPost) Reset()                    { *m = SignedPost{} }
+func (m *SignedPost) String() string            { return proto.CompactTextString(m) }
+func (*SignedPost) ProtoMessage()               {}
+func (*SignedPost) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{1} }
+
+func (m *SignedPost) GetPost() *Post {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *SignedPost) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *SignedPost) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Post)(nil), "Post")
+	proto.RegisterType((*Post_Image)(nil), "Post.Image")
+	proto.RegisterType((*Post_ListingLink)(nil), "Post.ListingLink")
+	proto.RegisterType((*SignedPost)(nil), "SignedPost")
+}
+
+func init() { proto.RegisterFile("posts.proto", fileDescriptor6) }
+
+var fileDescriptor6 = []byte{
+	// 387 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x52, 0x5f, 0xeb, 0xd3, 0x30,
+	0x14, 0xa5, 0xeb, 0x1f, 0xdb, 0x5b, 0x41, 0x0c, 0x22, 0xb1, 0x08, 0x2b, 0xf3, 0x65, 0x2f, 0x76,
+	0x30, 0x5f, 0xf4, 0x55, 0x86, 0x30, 0xd8, 0x83, 0x44, 0x5f, 0xf4, 0x2d, 0xdb, 0xb2, 0x2c, 0x98,
+	0x26, 0xa5, 0x49, 0x05, 0x3f, 0x89, 0xdf, 0xd0, 0xcf, 0x21, 0x49, 0xda, 0xec, 0xf7, 0x76, 0xcf,
+	0xc9, 0xb9, 0xbd, 0xf7, 0x9e, 0x53, 0xa8, 0x07, 0x6d, 0xac, 0xe9, 0x86, 0x51, 0x5b, 0xdd, 0xbc,
+	0xb8, 0x68, 0x65, 0x47, 0x7a, 0x89, 0xc4, 0x9a, 0x6b, 0xcd, 0x25, 0xdb, 0x79, 0x74, 0x9e, 0x6e,
+	0x3b, 0x2b, 0x7a, 0x66, 0x2c, 0xed, 0x87, 0x20, 0xd8, 0xfc, 0x4b, 0x21, 0xfb, 0xaa, 0x8d, 0x45,
+	0x08, 0x32, 0x23, 0x27, 0x8e, 0x93, 0x36, 0xd9, 0x56, 0xc4, 0xd7, 0x68, 0x0d, 0xe5, 0x6f, 0xa6,
+	0xae, 0x7a, 0x3c, 0x1e, 0xf0, 0xaa, 0x4d, 0xb6, 0xf5, 0x3e, 0xed, 0x8e, 0x07, 0x12, 0x49, 0xf4,
+	0x1a, 0x0a, 0x63, 0xa9, 0x9d, 0x0c, 0x4e, 0x7d, 0xdb, 0x8c, 0x50, 0x03, 0xa5, 0xd4, 0x8a, 0x7f,
+	0xd1, 0x63, 0x8f, 0x33, 0xff, 0x12, 0xb1, 0x1b, 0x64, 0x29, 0x37, 0x38, 0x6f, 0x53, 0x37, 0xc8,
+	0xd5, 0xe8, 0x1d, 0x14, 0xa2, 0xa7, 0x9c, 0x19, 0x5c, 0xb4, 0xe9, 0xb6, 0xde, 0xd7, 0x9d, 0xdb,
+	0xa9, 0x3b, 0x3a, 0x8e, 0xcc, 0x4f, 0xe8, 0x3d, 0x94, 0x52, 0x18, 0x2b, 0x14, 0x37, 0xf8, 0x99,
+	0x97, 0xbd, 0x0c, 0xb2, 0x53, 0x60, 0x4f, 0x42, 0xfd, 0x22, 0x51, 0x82, 0x3e, 0x42, 0x15, 0x8f,
+	0xc5, 0xa5, 0xdf, 0xbe, 0xe9, 0x82, 0x1d, 0xdd, 0x62, 0x47, 0xf7, 0x7d, 0x51, 0x90, 0x87, 0xb8,
+	0xf9, 0x9b, 0x40, 0xee, 0x47, 0xbb, 0x3b, 0x6e, 0x42, 0x32, 0x45, 0x7b, 0x36, 0x1b, 0x13, 0xb1,
+	0x7b, 0xd3, 0xa3, 0xe0, 0x42, 0x51, 0xe9, 0xcd, 0xa9, 0x48, 0xc4, 0xe8, 0x15, 0xe4, 0x92, 0x8e,
+	0x9c, 0xcd, 0xb6, 0x04, 0xe0, 0xdc, 0xea, 0xd9, 0x55, 0x4c, 0x8b, 0x27, 0x33, 0x72, 0x6a, 0xd3,
+	0x53, 0x29, 0x71, 0x1e, 0xd4, 0x1e, 0x78, 0x9f, 0x84, 0xfa, 0x83, 0x8b, 0x10, 0x88, 0xab, 0x9b,
+	0x4f, 0x50, 0x3f, 0x39, 0xd6, 0x7d, 0x70, 0x60, 0xcc, 0xa5, 0x13, 0x96, 0x9b, 0x51, 0xcc, 0x72,
+	0xf5, 0xc8, 0x72, 0xf3, 0x03, 0xe0, 0x9b, 0xe0, 0x8a, 0x5d, 0x7d, 0xda, 0x6f, 0x20, 0x73, 0xff,
+	0x8d, 0xef, 0xab, 0xf7, 0xb9, 0xf7, 0x91, 0x78, 0xca, 0x35, 0xdf, 0xa9, 0xb9, 0x2f, 0xcd, 0xae,
+	0x46, 0x6f, 0xa1, 0x32, 0x82, 0x2b, 0x6a, 0xa7, 0x31, 0xdc, 0xf4, 0x9c, 0x3c, 0x88, 0xcf, 0xd9,
+	0xcf, 0xd5, 0x70, 0x3e, 0x17, 0xde, 0xd4, 0x0f, 0xff, 0x07, 0x00, 0x53, 0x11, 0x86, 0x26, 0x91,
+	0x02, 0x00, 0x00,
+}
@@ -37,7 +37,7 @@ type Profile struct {
 func (m *Profile) Reset()                    { *m = Profile{} }
 func (m *Profile) String() string            { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()               {}
-func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0} }
 
 func (m *Profile) GetPeerID() string {
 	if m != nil {
@@ -168,7 +168,7 @@ type Profile_Contact struct {
 func (m *Profile_Contact) Reset()                    { *m = Profile_Contact{} }
 func (m *Profile_Contact) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Contact) ProtoMessage()               {}
-func (*Profile_Contact) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 0} }
+func (*Profile_Contact) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 0} }
 
 func (m *Profile_Contact) GetWebsite() string {
 	if m != nil {
@@ -207,7 +207,7 @@ type Profile_SocialAccount struct {
 func (m *Profile_SocialAccount) Reset()                    { *m = Profile_SocialAccount{} }
 func (m *Profile_SocialAccount) String() string            { return proto.CompactTextString(m) }
 func (*Profile_SocialAccount) ProtoMessage()               {}
-func (*Profile_SocialAccount) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 1} }
+func (*Profile_SocialAccount) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 1} }
 
 func (m *Profile_SocialAccount) GetType() string {
 	if m != nil {
@@ -241,7 +241,7 @@ type Profile_Image struct {
 func (m *Profile_Image) Reset()                    { *m = Profile_Image{} }
 func (m *Profile_Image) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Image) ProtoMessage()               {}
-func (*Profile_Image) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 2} }
+func (*Profile_Image) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 2} }
 
 func (m *Profile_Image) GetTiny() string {
 	if m != nil {
@@ -289,7 +289,7 @@ type Profile_Colors struct {
 func (m *Profile_Colors) Reset()                    { *m = Profile_Colors{} }
 func (m *Profile_Colors) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Colors) ProtoMessage()               {}
-func (*Profile_Colors) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 3} }
+func (*Profile_Colors) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 3} }
 
 func (m *Profile_Colors) GetPrimary() string {
 	if m != nil {
@@ -337,7 +337,7 @@ type Profile_Stats struct {
 func (m *Profile_Stats) Reset()                    { *m = Profile_Stats{} }
 func (m *Profile_Stats) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Stats) ProtoMessage()               {}
-func (*Profile_Stats) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 4} }
+func (*Profile_Stats) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 4} }
 
 func (m *Profile_Stats) GetFollowerCount() uint32 {
 	if m != nil {
@@ -383,9 +383,9 @@ func init() {
 	proto.RegisterType((*Profile_Stats)(nil), "Profile.Stats")
 }
 
-func init() { proto.RegisterFile("profile.proto", fileDescriptor6) }
+func init() { proto.RegisterFile("profile.proto", fileDescriptor7) }
 
-var fileDescriptor6 = []byte{
+var fileDescriptor7 = []byte{
 	// 675 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x93, 0xcf, 0x6e, 0xd4, 0x3a,
 	0x14, 0xc6, 0x35, 0xd3, 0xf9, 0xd3, 0x7a, 0x66, 0xda, 0x5e, 0xeb, 0xaa, 0xb2, 0xa2, 0x2b, 0xdd,
@@ -46,6 +46,38 @@ type WalletConfig struct {
 	RPCPassword      string
 }
 
+// An S3 compatible object store used to hold offline messages
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+}
+
+/* Settings for holding offline messages on behalf of other nodes. Recipients is the list of
+   peers allowed to register with us, nobody else may unless AllowAnyRecipient is set. MaxMessages
+   and MaxBytes limit what is held for each recipient, MaxMessagesPerSender what each peer may
+   leave with us and TotalMaxMessages and TotalMaxBytes everything we hold. */
+type RelayConfig struct {
+	Enabled              bool
+	MaxMessages          int
+	MaxBytes             int
+	MaxMessagesPerSender int
+	TotalMaxMessages     int
+	TotalMaxBytes        int
+	AllowAnyRecipient    bool
+	Recipients           []string
+}
+
+// Used for the relay limits missing from config files created before they were added
+const (
+	DefaultRelayMaxMessagesPerSender = 100
+	DefaultRelayTotalMaxMessages     = 10000
+	DefaultRelayTotalMaxBytes        = 500 * 1024 * 1024
+)
+
 var MalformedConfigError error = errors.New("Config file is malformed")
 
 func GetAPIConfig(cfgBytes []byte) (*APIConfig, error) {
@@ -349,6 +381,52 @@ func GetDropboxApiToken(cfgBytes []byte) (string, error) {
 	return tokenStr, nil
 }
 
+func GetS3Config(cfgBytes []byte) (*S3Config, error) {
+	var cfgIface interface{}
+	json.Unmarshal(cfgBytes, &cfgIface)
+
+	cfg, ok := cfgIface.(map[string]interface{})
+	if !ok {
+		return nil, MalformedConfigError
+	}
+
+	s3Iface, ok := cfg["S3"]
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	s3, ok := s3Iface.(map[string]interface{})
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	s3Cfg := new(S3Config)
+	fields := map[string]*string{
+		"Endpoint":  &s3Cfg.Endpoint,
+		"Region":    &s3Cfg.Region,
+		"Bucket":    &s3Cfg.Bucket,
+		"AccessKey": &s3Cfg.AccessKey,
+		"SecretKey": &s3Cfg.SecretKey,
+	}
+	for name, field := range fields {
+		v, ok := s3[name]
+		if !ok {
+			return nil, MalformedConfigError
+		}
+		*field, ok = v.(string)
+		if !ok {
+			return nil, MalformedConfigError
+		}
+	}
+	pathStyle, ok := s3["PathStyle"]
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	s3Cfg.PathStyle, ok = pathStyle.(bool)
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	return s3Cfg, nil
+}
+
 func GetCrosspostGateway(cfgBytes []byte) ([]string, error) {
 	var cfgIface interface{}
 	json.Unmarshal(cfgBytes, &cfgIface)
@@ -379,6 +457,130 @@ func GetCrosspostGateway(cfgBytes []byte) ([]string, error) {
 	return urls, nil
 }
 
+func GetOfflineRelays(cfgBytes []byte) ([]string, error) {
+	var cfgIface interface{}
+	json.Unmarshal(cfgBytes, &cfgIface)
+	var relays []string
+
+	cfg, ok := cfgIface.(map[string]interface{})
+	if !ok {
+		return relays, MalformedConfigError
+	}
+
+	relaysIface, ok := cfg["Offline-relays"]
+	if !ok {
+		return relays, MalformedConfigError
+	}
+	relayList, ok := relaysIface.([]interface{})
+	if !ok {
+		return relays, MalformedConfigError
+	}
+
+	for _, r := range relayList {
+		rStr, ok := r.(string)
+		if !ok {
+			return relays, MalformedConfigError
+		}
+		relays = append(relays, rStr)
+	}
+
+	return relays, nil
+}
+
+func GetRelayConfig(cfgBytes []byte) (*RelayConfig, error) {
+	var cfgIface interface{}
+	json.Unmarshal(cfgBytes, &cfgIface)
+
+	cfg, ok := cfgIface.(map[string]interface{})
+	if !ok {
+		return nil, MalformedConfigError
+	}
+
+	relayIface, ok := cfg["Relay"]
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	relay, ok := relayIface.(map[string]interface{})
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	enabled, ok := relay["Enabled"]
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	enabledBool, ok := enabled.(bool)
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	maxMessages, ok := relay["MaxMessages"]
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	maxMessagesFloat, ok := maxMessages.(float64)
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	maxBytes, ok := relay["MaxBytes"]
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	maxBytesFloat, ok := maxBytes.(float64)
+	if !ok {
+		return nil, MalformedConfigError
+	}
+	optionalInt := func(key string, def int) (int, error) {
+		v, ok := relay[key]
+		if !ok {
+			return def, nil
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return 0, MalformedConfigError
+		}
+		return int(f), nil
+	}
+	maxPerSender, err := optionalInt("MaxMessagesPerSender", DefaultRelayMaxMessagesPerSender)
+	if err != nil {
+		return nil, err
+	}
+	totalMaxMessages, err := optionalInt("TotalMaxMessages", DefaultRelayTotalMaxMessages)
+	if err != nil {
+		return nil, err
+	}
+	totalMaxBytes, err := optionalInt("TotalMaxBytes", DefaultRelayTotalMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	var allowAny bool
+	if a, ok := relay["AllowAnyRecipient"]; ok {
+		allowAny, ok = a.(bool)
+		if !ok {
+			return nil, MalformedConfigError
+		}
+	}
+	var recipients []string
+	if r, ok := relay["Recipients"].([]interface{}); ok {
+		for _, recipient := range r {
+			recipientStr, ok := recipient.(string)
+			if !ok {
+				return nil, MalformedConfigError
+			}
+			recipients = append(recipients, recipientStr)
+		}
+	}
+
+	return &RelayConfig{
+		Enabled:              enabledBool,
+		MaxMessages:          int(maxMessagesFloat),
+		MaxBytes:             int(maxBytesFloat),
+		MaxMessagesPerSender: maxPerSender,
+		TotalMaxMessages:     totalMaxMessages,
+		TotalMaxBytes:        totalMaxBytes,
+		AllowAnyRecipient:    allowAny,
+		Recipients:           recipients,
+	}, nil
+}
+
 func GetResolverUrl(cfgBytes []byte) (string, error) {
 	var cfgIface interface{}
 	json.Unmarshal(cfgBytes, &cfgIface)
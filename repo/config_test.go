@@ -128,6 +128,91 @@ func TestGetDropboxApiToken(t *testing.T) {
 	}
 }
 
+func TestGetS3Config(t *testing.T) {
+	configFile, err := ioutil.ReadFile(testConfigPath)
+	if err != nil {
+		t.Error(err)
+	}
+	config, err := GetS3Config(configFile)
+	if err != nil {
+		t.Error("GetS3Config threw an unexpected error", err)
+	}
+	expected := S3Config{
+		Endpoint:  "https://s3.example.com",
+		Region:    "us-east-1",
+		Bucket:    "openbazaar",
+		AccessKey: "s3access",
+		SecretKey: "s3secret",
+		PathStyle: true,
+	}
+	if config == nil || *config != expected {
+		t.Error("S3 config does not equal expected value", config)
+	}
+
+	_, err = GetS3Config([]byte{})
+	if err == nil {
+		t.Error("GetS3Config didn't throw an error")
+	}
+	_, err = GetS3Config([]byte(`{"S3": {"Endpoint": "https://s3.example.com"}}`))
+	if err == nil {
+		t.Error("GetS3Config didn't throw an error")
+	}
+}
+
+func TestGetOfflineRelays(t *testing.T) {
+	configFile, err := ioutil.ReadFile(testConfigPath)
+	if err != nil {
+		t.Error(err)
+	}
+	relays, err := GetOfflineRelays(configFile)
+	if err != nil {
+		t.Error("GetOfflineRelays threw an unexpected error", err)
+	}
+	if len(relays) != 1 || relays[0] != "QmbwSMS35CaYKdrYBvvR9aHU9FzeWhjJ7E3jLKeR2DWrs3" {
+		t.Error("Offline relays do not equal expected value", relays)
+	}
+	_, err = GetOfflineRelays([]byte{})
+	if err == nil {
+		t.Error("GetOfflineRelays didn't throw an error")
+	}
+}
+
+func TestGetRelayConfig(t *testing.T) {
+	configFile, err := ioutil.ReadFile(testConfigPath)
+	if err != nil {
+		t.Error(err)
+	}
+	config, err := GetRelayConfig(configFile)
+	if err != nil {
+		t.Fatal("GetRelayConfig threw an unexpected error", err)
+	}
+	if !config.Enabled || config.MaxMessages != 1000 || config.MaxBytes != 52428800 {
+		t.Error("Relay config does not equal expected value", config)
+	}
+	if len(config.Recipients) != 1 || config.Recipients[0] != "QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr" {
+		t.Error("Relay recipients do not equal expected value", config.Recipients)
+	}
+	if config.AllowAnyRecipient || config.MaxMessagesPerSender != DefaultRelayMaxMessagesPerSender ||
+		config.TotalMaxMessages != DefaultRelayTotalMaxMessages || config.TotalMaxBytes != DefaultRelayTotalMaxBytes {
+		t.Error("Relay limits missing from the config were not defaulted", config)
+	}
+	config, err = GetRelayConfig([]byte(`{"Relay": {"Enabled": true, "MaxMessages": 10, "MaxBytes": 100, "MaxMessagesPerSender": 5, "TotalMaxMessages": 50, "TotalMaxBytes": 500, "AllowAnyRecipient": true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.AllowAnyRecipient || config.MaxMessagesPerSender != 5 || config.TotalMaxMessages != 50 || config.TotalMaxBytes != 500 {
+		t.Error("Relay limits do not equal expected value", config)
+	}
+	_, err = GetRelayConfig([]byte{})
+	if err == nil {
+		t.Error("GetRelayConfig didn't throw an error")
+	}
+	_, err = GetRelayConfig([]byte(`{"Relay": {"Enabled": true}}`))
+	if err == nil {
+		t.Error("GetRelayConfig didn't throw an error")
+	}
+}
+
 func TestGetResolverUrl(t *testing.T) {
 	configFile, err := ioutil.ReadFile(testConfigPath)
 	if err != nil {
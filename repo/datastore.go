@@ -1,6 +1,7 @@
 package repo
 
 import (
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
 	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
 
 	notif "github.com/OpenBazaar/openbazaar-go/api/notifications"
@@ -27,6 +28,18 @@ type Datastore interface {
 	Coupons() Coupons
 	TxMetadata() TxMetadata
 	ModeratedStores() ModeratedStores
+	Bids() Bids
+	WebhookQueue() WebhookQueue
+	APITokens() APITokens
+	AuditLog() AuditLog
+	FrozenCoins() FrozenCoins
+	UnsignedTransactions() UnsignedTransactions
+	ColdStorage() ColdStorage
+	ExchangeRateHistory() ExchangeRateHistory
+	StoredMessages() StoredMessages
+	Outbox() Outbox
+	Relay() Relay
+	Capabilities() Capabilities
 	Close()
 }
 
@@ -44,6 +57,12 @@ type Config interface {
 	// Returns the date the seed was created
 	GetCreationDate() (time.Time, error)
 
+	// Save the public keys of a watch-only wallet
+	SetWatchOnlyKey(key []byte) error
+
+	// Return the public keys of a watch-only wallet or nil if the node holds its private keys
+	GetWatchOnlyKey() ([]byte, error)
+
 	// Returns true if the database has failed to decrypt properly ex) wrong pw
 	IsEncrypted() bool
 }
@@ -310,7 +329,7 @@ type Coupons interface {
 
 type TxMetadata interface {
 
-	// Put metadata for a transaction to the db
+	// Put metadata for a transaction to the db. Output labels are added to any already saved.
 	Put(m Metadata) error
 
 	// Get the metadata given the txid
@@ -334,3 +353,186 @@ type ModeratedStores interface {
 	// Delete a moderated store from the database
 	Delete(peerId string) error
 }
+
+type Bids interface {
+	// Put a signed bid to the database
+	Put(bid *pb.SignedBid) error
+
+	// Get the highest bid for the given vendor and listing slug
+	GetHighest(vendorID, slug string) (*pb.SignedBid, error)
+
+	// Get all bids for the given vendor and listing slug, highest first
+	GetAll(vendorID, slug string) ([]*pb.SignedBid, error)
+
+	// Delete all bids for the given vendor and listing slug
+	Delete(vendorID, slug string) error
+}
+
+type WebhookQueue interface {
+	// Add a webhook request to the queue
+	Put(url, eventType string, payload []byte, nextAttempt time.Time) error
+
+	// Get the requests whose next attempt is due at or before the given time
+	GetDue(t time.Time) ([]WebhookDelivery, error)
+
+	// Record a failed attempt and when to try again
+	Reschedule(id int, attempts int, nextAttempt time.Time) error
+
+	// Delete a request from the queue
+	Delete(id int) error
+}
+
+type APITokens interface {
+	// Put an API token to the database. Only the hash of the token is stored.
+	Put(token APIToken) error
+
+	// Get the API token with the given hash
+	GetByHash(tokenHash string) (*APIToken, error)
+
+	// Get all API tokens
+	GetAll() ([]APIToken, error)
+
+	// Delete the API token with the given name
+	Delete(name string) error
+}
+
+type AuditLog interface {
+	/* Append an entry to the log. The hash of each entry covers the hash of the entry
+	   before it so changing or removing an earlier entry breaks the chain. */
+	Put(entry AuditEntry) error
+
+	/* Get entries from the log, newest first.
+	   The offset and limit arguments can be used to for lazy loading. */
+	Get(offsetId int, limit int) ([]AuditEntry, error)
+
+	// Get every entry in the log, oldest first
+	GetAll() ([]AuditEntry, error)
+
+	/* Check the hash chain and return the ID of the first entry which fails to verify or zero if the
+	   log is intact. The newest entry is also recorded separately from the log so if entries were
+	   removed from the end the ID following the last remaining entry is returned. */
+	Verify() (int, error)
+}
+
+type FrozenCoins interface {
+	// Freeze a coin given its outpoint as txid:index
+	Put(outpoint string) error
+
+	// Return the outpoints of all frozen coins
+	GetAll() ([]string, error)
+
+	// Unfreeze a coin
+	Delete(outpoint string) error
+}
+
+type UnsignedTransactions interface {
+	// Save a transaction waiting to be signed offline or update it once signed
+	Put(tx UnsignedTransaction) error
+
+	// Fetch a transaction by its ID
+	Get(id string) (*UnsignedTransaction, error)
+
+	// Fetch the transaction created by a wallet call so retrying the call finds its signatures
+	GetByIntent(intent string) (*UnsignedTransaction, error)
+
+	// Return all transactions newest first
+	GetAll() ([]UnsignedTransaction, error)
+
+	// Discard a transaction
+	Delete(id string) error
+
+	/* Save the chaincode an escrow key was derived from. The watch-only wallet only gets the public
+	   key so it looks the chaincode up to tell the offline signer which key to use. */
+	PutChaincode(pubkey string, chaincode []byte) error
+
+	// Return the chaincode of the hex encoded escrow public key
+	GetChaincode(pubkey string) ([]byte, error)
+}
+
+type ColdStorage interface {
+	// Return the index of the next unused address derived from the extended public key
+	GetIndex(xpub string) (int, error)
+
+	// Save the index of the next unused address
+	PutIndex(xpub string, index int) error
+}
+
+type ExchangeRateHistory interface {
+	// Save a snapshot of the exchange rates taken at the given time
+	Put(rates map[string]float64, timestamp time.Time) error
+
+	/* Return the saved rate for the currency closest to the given time and the time it was taken.
+	   Errors if no rate was saved close enough to the time to be meaningful. */
+	GetAt(currencyCode string, timestamp time.Time) (float64, time.Time, error)
+}
+
+type StoredMessages interface {
+	// Record where the ciphertext of an offline message with the given pointer was stored
+	Put(pointerID peer.ID, addr ma.Multiaddr, timestamp time.Time) error
+
+	// Fetch the record for a pointer
+	Get(pointerID peer.ID) (StoredMessage, error)
+
+	// Mark the message as received by the recipient
+	Acknowledge(pointerID peer.ID) error
+
+	// Return the messages which were acknowledged or stored before the cutoff
+	GetCollectable(cutoff time.Time) ([]StoredMessage, error)
+
+	// Delete the record once the ciphertext has been removed from storage
+	Delete(pointerID peer.ID) error
+}
+
+type Outbox interface {
+	// Record an offline message we sent
+	Put(message OutboxMessage) error
+
+	// Fetch a message given the ID of its pointer
+	Get(pointerID string) (OutboxMessage, error)
+
+	// Return the messages the recipient has not received yet, oldest first
+	GetPending() ([]OutboxMessage, error)
+
+	// Return all the messages sent for an order, oldest first
+	GetByOrderId(orderId string) ([]OutboxMessage, error)
+
+	// Update the delivery status. The message itself is dropped once it's no longer pending.
+	SetStatus(pointerID string, status string, timestamp time.Time) error
+}
+
+type Relay interface {
+	// Accept messages for a recipient
+	Register(recipient string, timestamp time.Time) error
+
+	// Has the recipient registered with us?
+	IsRegistered(recipient string) bool
+
+	// Hold a message from the sender until the recipient connects
+	Put(recipient, sender string, message []byte, timestamp time.Time) error
+
+	// Return the number of messages and total bytes held for a recipient
+	Usage(recipient string) (int, int, error)
+
+	// Return the number of messages held which were left by the sender
+	SenderUsage(sender string) (int, error)
+
+	// Return the number of messages and total bytes held for all recipients
+	TotalUsage() (int, int, error)
+
+	// Return the messages held for a recipient, oldest first
+	GetAll(recipient string) ([]RelayMessage, error)
+
+	// Delete a message once it's been delivered
+	Delete(id int) error
+
+	// Delete messages held since before the cutoff
+	DeleteExpired(cutoff time.Time) error
+}
+
+type Capabilities interface {
+	// Record what a peer told us it supports, replacing anything we knew before
+	Put(capabilities PeerCapabilities) error
+
+	// Return the last capabilities recorded for a peer
+	Get(peerID string) (PeerCapabilities, error)
+}
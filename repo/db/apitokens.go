@@ -0,0 +1,108 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type APITokensDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (a *APITokensDB) Put(token repo.APIToken) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if token.Name == "" || token.TokenHash == "" {
+		return errors.New("API token is missing required fields")
+	}
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert into apitokens(name, tokenHash, scopes, created) values(?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(token.Name, token.TokenHash, strings.Join(token.Scopes, ","), int(token.Created.Unix()))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (a *APITokensDB) GetByHash(tokenHash string) (*repo.APIToken, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	stmt, err := a.db.Prepare("select name, tokenHash, scopes, created from apitokens where tokenHash=?")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var name, hash, scopes string
+	var created int
+	err = stmt.QueryRow(tokenHash).Scan(&name, &hash, &scopes, &created)
+	if err != nil {
+		return nil, err
+	}
+	return newAPIToken(name, hash, scopes, created), nil
+}
+
+func (a *APITokensDB) GetAll() ([]repo.APIToken, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	rows, err := a.db.Query("select name, tokenHash, scopes, created from apitokens order by created asc")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.APIToken
+	for rows.Next() {
+		var name, hash, scopes string
+		var created int
+		if err := rows.Scan(&name, &hash, &scopes, &created); err != nil {
+			continue
+		}
+		ret = append(ret, *newAPIToken(name, hash, scopes, created))
+	}
+	return ret, nil
+}
+
+func (a *APITokensDB) Delete(name string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	res, err := a.db.Exec("delete from apitokens where name=?", name)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("API token not found")
+	}
+	return nil
+}
+
+func newAPIToken(name, hash, scopes string, created int) *repo.APIToken {
+	token := &repo.APIToken{
+		Name:      name,
+		TokenHash: hash,
+		Scopes:    []string{},
+		Created:   time.Unix(int64(created), 0),
+	}
+	if scopes != "" {
+		token.Scopes = strings.Split(scopes, ",")
+	}
+	return token
+}
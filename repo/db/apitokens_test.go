@@ -0,0 +1,86 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var atdb APITokensDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	atdb = APITokensDB{
+		db: conn,
+	}
+}
+
+func TestAPITokensDB_Put(t *testing.T) {
+	err := atdb.Put(repo.APIToken{Name: "put", TokenHash: "puthash", Scopes: []string{"read-only", "listings-write"}, Created: time.Now()})
+	if err != nil {
+		t.Error(err)
+	}
+	var name, scopes string
+	err = atdb.db.QueryRow("select name, scopes from apitokens where tokenHash=?", "puthash").Scan(&name, &scopes)
+	if err != nil {
+		t.Error(err)
+	}
+	if name != "put" || scopes != "read-only,listings-write" {
+		t.Error("API tokens db returned incorrect values")
+	}
+	err = atdb.Put(repo.APIToken{Name: "put", TokenHash: "otherhash", Created: time.Now()})
+	if err == nil {
+		t.Error("Put should fail when the name is already used")
+	}
+}
+
+func TestAPITokensDB_GetByHash(t *testing.T) {
+	atdb.Put(repo.APIToken{Name: "get", TokenHash: "gethash", Scopes: []string{"orders"}, Created: time.Now()})
+	token, err := atdb.GetByHash("gethash")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if token.Name != "get" || len(token.Scopes) != 1 || token.Scopes[0] != "orders" {
+		t.Error("Returned incorrect API token")
+	}
+	_, err = atdb.GetByHash("none")
+	if err == nil {
+		t.Error("GetByHash should return an error for an unknown token")
+	}
+}
+
+func TestAPITokensDB_GetAll(t *testing.T) {
+	atdb.Put(repo.APIToken{Name: "all", TokenHash: "allhash", Created: time.Now()})
+	tokens, err := atdb.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	found := false
+	for _, token := range tokens {
+		if token.Name == "all" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Failed to return all API tokens")
+	}
+}
+
+func TestAPITokensDB_Delete(t *testing.T) {
+	atdb.Put(repo.APIToken{Name: "delete", TokenHash: "deletehash", Created: time.Now()})
+	err := atdb.Delete("delete")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = atdb.GetByHash("deletehash")
+	if err == nil {
+		t.Error("Failed to delete API token")
+	}
+	if atdb.Delete("delete") == nil {
+		t.Error("Delete should fail for an unknown token")
+	}
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type AuditLogDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (a *AuditLogDB) Put(entry repo.AuditEntry) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	// The chain continues from the head rather than the last row so removed entries are noticed
+	var headId int
+	var prevHash string
+	err = tx.QueryRow("select entryId, hash from auditloghead where id=1").Scan(&headId, &prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return err
+	}
+	entry.PrevHash = prevHash
+	entry.Hash = auditEntryHash(entry)
+
+	stmt, err := tx.Prepare("insert into auditlog(timestamp, method, path, principal, request, status, result, prevHash, hash) values(?,?,?,?,?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(int(entry.Timestamp.Unix()), entry.Method, entry.Path, entry.Principal, entry.Request, entry.Status, entry.Result, entry.PrevHash, entry.Hash)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec("insert or replace into auditloghead(id, entryId, hash) values(1,?,?)", id, entry.Hash)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (a *AuditLogDB) Get(offsetId int, limit int) ([]repo.AuditEntry, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	stm := "select id, timestamp, method, path, principal, request, status, result, prevHash, hash from auditlog"
+	var args []interface{}
+	if offsetId > 0 {
+		stm += " where id<?"
+		args = append(args, offsetId)
+	}
+	stm += " order by id desc limit " + strconv.Itoa(limit)
+	rows, err := a.db.Query(stm, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAuditEntries(rows), nil
+}
+
+func (a *AuditLogDB) GetAll() ([]repo.AuditEntry, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.getAll()
+}
+
+func (a *AuditLogDB) getAll() ([]repo.AuditEntry, error) {
+	rows, err := a.db.Query("select id, timestamp, method, path, principal, request, status, result, prevHash, hash from auditlog order by id asc")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAuditEntries(rows), nil
+}
+
+func (a *AuditLogDB) Verify() (int, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	entries, err := a.getAll()
+	if err != nil {
+		return 0, err
+	}
+	var headId int
+	var headHash string
+	err = a.db.QueryRow("select entryId, hash from auditloghead where id=1").Scan(&headId, &headHash)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	prevHash := ""
+	lastId := 0
+	for _, e := range entries {
+		if e.PrevHash != prevHash || e.Hash != auditEntryHash(e) {
+			return e.ID, nil
+		}
+		prevHash = e.Hash
+		lastId = e.ID
+	}
+	// The last entry must be the head, otherwise entries were removed from the end of the log
+	if lastId != headId || prevHash != headHash {
+		return lastId + 1, nil
+	}
+	return 0, nil
+}
+
+func scanAuditEntries(rows *sql.Rows) []repo.AuditEntry {
+	var ret []repo.AuditEntry
+	for rows.Next() {
+		var e repo.AuditEntry
+		var timestamp int
+		if err := rows.Scan(&e.ID, &timestamp, &e.Method, &e.Path, &e.Principal, &e.Request, &e.Status, &e.Result, &e.PrevHash, &e.Hash); err != nil {
+			continue
+		}
+		e.Timestamp = time.Unix(int64(timestamp), 0)
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+// Hash every field of the entry except its ID and its own hash
+func auditEntryHash(e repo.AuditEntry) string {
+	ser, _ := json.Marshal([]interface{}{
+		e.PrevHash,
+		e.Timestamp.Unix(),
+		e.Method,
+		e.Path,
+		e.Principal,
+		e.Request,
+		e.Status,
+		e.Result,
+	})
+	h := sha256.Sum256(ser)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+func newTestAuditLog() AuditLogDB {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	return AuditLogDB{
+		db: conn,
+	}
+}
+
+func newTestAuditEntry(path string) repo.AuditEntry {
+	return repo.AuditEntry{
+		Timestamp: time.Now(),
+		Method:    "POST",
+		Path:      path,
+		Principal: "cookie",
+		Request:   "{}",
+		Status:    200,
+		Result:    "{}",
+	}
+}
+
+func TestAuditLogDB_Put(t *testing.T) {
+	adb := newTestAuditLog()
+	err := adb.Put(newTestAuditEntry("/ob/refund"))
+	if err != nil {
+		t.Error(err)
+	}
+	err = adb.Put(newTestAuditEntry("/wallet/spend"))
+	if err != nil {
+		t.Error(err)
+	}
+	entries, err := adb.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 2 {
+		t.Error("Returned incorrect number of entries")
+		return
+	}
+	if entries[0].Path != "/ob/refund" || entries[0].PrevHash != "" || entries[0].Hash == "" {
+		t.Error("Returned incorrect first entry")
+	}
+	if entries[1].PrevHash != entries[0].Hash {
+		t.Error("Entries are not chained")
+	}
+}
+
+func TestAuditLogDB_Get(t *testing.T) {
+	adb := newTestAuditLog()
+	adb.Put(newTestAuditEntry("/ob/listing"))
+	adb.Put(newTestAuditEntry("/ob/inventory"))
+	adb.Put(newTestAuditEntry("/ob/refund"))
+	entries, err := adb.Get(0, 2)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 2 || entries[0].Path != "/ob/refund" || entries[1].Path != "/ob/inventory" {
+		t.Error("Entries were not returned newest first")
+		return
+	}
+	entries, err = adb.Get(entries[1].ID, -1)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 1 || entries[0].Path != "/ob/listing" {
+		t.Error("Returned incorrect entries after the offset")
+	}
+}
+
+func TestAuditLogDB_Verify(t *testing.T) {
+	adb := newTestAuditLog()
+	adb.Put(newTestAuditEntry("/ob/listing"))
+	adb.Put(newTestAuditEntry("/wallet/spend"))
+	adb.Put(newTestAuditEntry("/ob/refund"))
+	id, err := adb.Verify()
+	if err != nil {
+		t.Error(err)
+	}
+	if id != 0 {
+		t.Error("Intact log failed to verify")
+	}
+	_, err = adb.db.Exec("update auditlog set principal='someone else' where path='/wallet/spend'")
+	if err != nil {
+		t.Error(err)
+	}
+	var tampered int
+	adb.db.QueryRow("select id from auditlog where path='/wallet/spend'").Scan(&tampered)
+	id, err = adb.Verify()
+	if err != nil {
+		t.Error(err)
+	}
+	if id != tampered {
+		t.Error("Failed to detect the tampered entry")
+	}
+}
+
+func TestAuditLogDB_VerifyTruncated(t *testing.T) {
+	adb := newTestAuditLog()
+	adb.Put(newTestAuditEntry("/ob/listing"))
+	adb.Put(newTestAuditEntry("/wallet/spend"))
+	adb.Put(newTestAuditEntry("/ob/refund"))
+	var removed int
+	adb.db.QueryRow("select id from auditlog where path='/ob/refund'").Scan(&removed)
+	_, err := adb.db.Exec("delete from auditlog where id=?", removed)
+	if err != nil {
+		t.Error(err)
+	}
+	id, err := adb.Verify()
+	if err != nil {
+		t.Error(err)
+	}
+	if id != removed {
+		t.Error("Failed to detect the removed entry")
+	}
+
+	// New entries still chain from the removed one
+	adb.Put(newTestAuditEntry("/ob/listing"))
+	id, err = adb.Verify()
+	if err != nil {
+		t.Error(err)
+	}
+	if id == 0 {
+		t.Error("Removed entry went unnoticed after a new entry was added")
+	}
+}
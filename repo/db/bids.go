@@ -0,0 +1,116 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+type BidsDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (b *BidsDB) Put(bid *pb.SignedBid) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if bid.Bid == nil || bid.Bid.BuyerID == nil || bid.Bid.Timestamp == nil {
+		return errors.New("Bid is missing required fields")
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: true,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(bid)
+	if err != nil {
+		return err
+	}
+	h := sha256.Sum256(bid.Signature)
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into bids(bidID, vendorID, slug, buyerID, amount, timestamp, bid) values(?,?,?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		hex.EncodeToString(h[:]),
+		bid.Bid.VendorID,
+		bid.Bid.Slug,
+		bid.Bid.BuyerID.PeerID,
+		int(bid.Bid.Amount),
+		int(bid.Bid.Timestamp.Seconds),
+		out,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (b *BidsDB) GetHighest(vendorID, slug string) (*pb.SignedBid, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	stmt, err := b.db.Prepare("select bid from bids where vendorID=? and slug=? order by amount desc, timestamp asc limit 1")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var serializedBid string
+	err = stmt.QueryRow(vendorID, slug).Scan(&serializedBid)
+	if err != nil {
+		return nil, err
+	}
+	bid := new(pb.SignedBid)
+	err = jsonpb.UnmarshalString(serializedBid, bid)
+	if err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+func (b *BidsDB) GetAll(vendorID, slug string) ([]*pb.SignedBid, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	rows, err := b.db.Query("select bid from bids where vendorID=? and slug=? order by amount desc, timestamp asc", vendorID, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []*pb.SignedBid
+	for rows.Next() {
+		var serializedBid string
+		if err := rows.Scan(&serializedBid); err != nil {
+			continue
+		}
+		bid := new(pb.SignedBid)
+		if err := jsonpb.UnmarshalString(serializedBid, bid); err != nil {
+			continue
+		}
+		ret = append(ret, bid)
+	}
+	return ret, nil
+}
+
+func (b *BidsDB) Delete(vendorID, slug string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	_, err := b.db.Exec("delete from bids where vendorID=? and slug=?", vendorID, slug)
+	if err != nil {
+		return err
+	}
+	return nil
+}
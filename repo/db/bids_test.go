@@ -0,0 +1,112 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+var bdb BidsDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	bdb = BidsDB{
+		db: conn,
+	}
+}
+
+func newTestBid(slug, buyer string, amount uint64, sig string) *pb.SignedBid {
+	return &pb.SignedBid{
+		Bid: &pb.Bid{
+			ListingHash: "QmListing",
+			VendorID:    "QmVendor",
+			Slug:        slug,
+			BuyerID:     &pb.ID{PeerID: buyer},
+			Amount:      amount,
+			Timestamp:   &timestamp.Timestamp{Seconds: int64(amount)},
+		},
+		Signature: []byte(sig),
+	}
+}
+
+func TestBidsDB_Put(t *testing.T) {
+	err := bdb.Put(newTestBid("put-slug", "QmBuyer", 1000, "sig1"))
+	if err != nil {
+		t.Error(err)
+	}
+	stmt, err := bdb.db.Prepare("select vendorID, slug, buyerID, amount from bids where slug=?")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer stmt.Close()
+	var vendorID, slug, buyerID string
+	var amount int
+	err = stmt.QueryRow("put-slug").Scan(&vendorID, &slug, &buyerID, &amount)
+	if err != nil {
+		t.Error(err)
+	}
+	if vendorID != "QmVendor" || slug != "put-slug" || buyerID != "QmBuyer" || amount != 1000 {
+		t.Error("Bids db returned incorrect values")
+	}
+}
+
+func TestBidsDB_PutMissingFields(t *testing.T) {
+	err := bdb.Put(&pb.SignedBid{Signature: []byte("sig")})
+	if err == nil {
+		t.Error("Put should fail on a bid with missing fields")
+	}
+}
+
+func TestBidsDB_GetHighest(t *testing.T) {
+	bdb.Put(newTestBid("highest-slug", "QmBuyer1", 1000, "sig2"))
+	bdb.Put(newTestBid("highest-slug", "QmBuyer2", 3000, "sig3"))
+	bdb.Put(newTestBid("highest-slug", "QmBuyer3", 2000, "sig4"))
+	bid, err := bdb.GetHighest("QmVendor", "highest-slug")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if bid.Bid.Amount != 3000 || bid.Bid.BuyerID.PeerID != "QmBuyer2" || string(bid.Signature) != "sig3" {
+		t.Error("Returned incorrect highest bid")
+	}
+	_, err = bdb.GetHighest("QmVendor", "none")
+	if err == nil {
+		t.Error("GetHighest should return an error when there are no bids")
+	}
+}
+
+func TestBidsDB_GetAll(t *testing.T) {
+	bdb.Put(newTestBid("all-slug", "QmBuyer1", 1000, "sig5"))
+	bdb.Put(newTestBid("all-slug", "QmBuyer2", 3000, "sig6"))
+	bdb.Put(newTestBid("other-slug", "QmBuyer3", 2000, "sig7"))
+	bids, err := bdb.GetAll("QmVendor", "all-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(bids) != 2 {
+		t.Error("Returned incorrect number of bids")
+		return
+	}
+	if bids[0].Bid.Amount != 3000 || bids[1].Bid.Amount != 1000 {
+		t.Error("Bids were not sorted highest first")
+	}
+}
+
+func TestBidsDB_Delete(t *testing.T) {
+	bdb.Put(newTestBid("delete-slug", "QmBuyer1", 1000, "sig8"))
+	err := bdb.Delete("QmVendor", "delete-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	bids, err := bdb.GetAll("QmVendor", "delete-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(bids) != 0 {
+		t.Error("Failed to delete bids")
+	}
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CapabilitiesDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (c *CapabilitiesDB) Put(capabilities repo.PeerCapabilities) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into capabilities(peerID, protocolVersion, features, userAgent, updated) values(?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		capabilities.PeerID,
+		capabilities.ProtocolVersion,
+		strings.Join(capabilities.Features, ","),
+		capabilities.UserAgent,
+		int(capabilities.Updated.Unix()),
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (c *CapabilitiesDB) Get(peerID string) (repo.PeerCapabilities, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var ret repo.PeerCapabilities
+	var features string
+	var updated int
+	err := c.db.QueryRow("select peerID, protocolVersion, features, userAgent, updated from capabilities where peerID=?", peerID).Scan(&ret.PeerID, &ret.ProtocolVersion, &features, &ret.UserAgent, &updated)
+	if err != nil {
+		return ret, err
+	}
+	ret.Features = []string{}
+	if features != "" {
+		ret.Features = strings.Split(features, ",")
+	}
+	ret.Updated = time.Unix(int64(updated), 0)
+	return ret, nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"testing"
+	"time"
+)
+
+var capabilitiesDB CapabilitiesDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	capabilitiesDB = CapabilitiesDB{
+		db: conn,
+	}
+}
+
+func TestCapabilitiesDB(t *testing.T) {
+	if _, err := capabilitiesDB.Get("QmPeer"); err == nil {
+		t.Error("Returned capabilities for an unknown peer")
+	}
+	now := time.Unix(1500000000, 0)
+	if err := capabilitiesDB.Put(repo.PeerCapabilities{"QmPeer", 1, []string{"auctions", "partial-refunds"}, "/openbazaar-go:0.7.0/", now}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := capabilitiesDB.Get("QmPeer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ProtocolVersion != 1 || c.UserAgent != "/openbazaar-go:0.7.0/" || !c.Updated.Equal(now) {
+		t.Error("Returned incorrect capabilities")
+	}
+	if !c.Supports("auctions") || !c.Supports("partial-refunds") || c.Supports("relay") {
+		t.Error("Returned incorrect features")
+	}
+
+	if err := capabilitiesDB.Put(repo.PeerCapabilities{"QmPeer", 0, nil, "", now.Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	c, err = capabilitiesDB.Get("QmPeer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ProtocolVersion != 0 || len(c.Features) != 0 || !c.Updated.Equal(now.Add(time.Hour)) {
+		t.Error("Failed to replace capabilities")
+	}
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+)
+
+type ColdStorageDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (c *ColdStorageDB) GetIndex(xpub string) (int, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	stmt, err := c.db.Prepare("select nextIndex from coldstorage where xpub=?")
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	var index int
+	err = stmt.QueryRow(xpub).Scan(&index)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return index, err
+}
+
+func (c *ColdStorageDB) PutIndex(xpub string, index int) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into coldstorage(xpub, nextIndex) values(?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(xpub, index)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
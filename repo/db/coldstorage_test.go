@@ -0,0 +1,40 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+var coldDB ColdStorageDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	coldDB = ColdStorageDB{
+		db: conn,
+	}
+}
+
+func TestColdStorageDB_Index(t *testing.T) {
+	xpub := "tpubDDFo7ZwsXvVs4h6ftj6pDGDm3tWWxL5swDaP6pVdyJXRD2aDuuNFVvyCLzkb1M8kPFtwhL9xa7cmgRhMd2wYQ7qFpqnAyXGY2zpGhwXeRbf"
+	index, err := coldDB.GetIndex(xpub)
+	if err != nil {
+		t.Error(err)
+	}
+	if index != 0 {
+		t.Error("Expected index zero for a new xpub")
+	}
+	if err := coldDB.PutIndex(xpub, 3); err != nil {
+		t.Error(err)
+	}
+	if err := coldDB.PutIndex("other", 7); err != nil {
+		t.Error(err)
+	}
+	index, err = coldDB.GetIndex(xpub)
+	if err != nil {
+		t.Error(err)
+	}
+	if index != 3 {
+		t.Errorf("Expected index 3, got %d", index)
+	}
+}
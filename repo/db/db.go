@@ -35,6 +35,18 @@ type SQLiteDatastore struct {
 	coupons         repo.Coupons
 	txMetadata      repo.TxMetadata
 	moderatedStores repo.ModeratedStores
+	bids            repo.Bids
+	webhookQueue    repo.WebhookQueue
+	apiTokens       repo.APITokens
+	auditLog        repo.AuditLog
+	frozenCoins     repo.FrozenCoins
+	unsignedTxs     repo.UnsignedTransactions
+	coldStorage     repo.ColdStorage
+	rateHistory     repo.ExchangeRateHistory
+	storedMessages  repo.StoredMessages
+	outbox          repo.Outbox
+	relay           repo.Relay
+	capabilities    repo.Capabilities
 	db              *sql.DB
 	lock            sync.RWMutex
 }
@@ -137,6 +149,54 @@ func Create(repoPath, password string, testnet bool) (*SQLiteDatastore, error) {
 			db:   conn,
 			lock: l,
 		},
+		bids: &BidsDB{
+			db:   conn,
+			lock: l,
+		},
+		webhookQueue: &WebhookQueueDB{
+			db:   conn,
+			lock: l,
+		},
+		apiTokens: &APITokensDB{
+			db:   conn,
+			lock: l,
+		},
+		auditLog: &AuditLogDB{
+			db:   conn,
+			lock: l,
+		},
+		frozenCoins: &FrozenCoinsDB{
+			db:   conn,
+			lock: l,
+		},
+		unsignedTxs: &UnsignedTransactionsDB{
+			db:   conn,
+			lock: l,
+		},
+		coldStorage: &ColdStorageDB{
+			db:   conn,
+			lock: l,
+		},
+		rateHistory: &ExchangeRateHistoryDB{
+			db:   conn,
+			lock: l,
+		},
+		storedMessages: &StoredMessagesDB{
+			db:   conn,
+			lock: l,
+		},
+		outbox: &OutboxDB{
+			db:   conn,
+			lock: l,
+		},
+		relay: &RelayDB{
+			db:   conn,
+			lock: l,
+		},
+		capabilities: &CapabilitiesDB{
+			db:   conn,
+			lock: l,
+		},
 		db:   conn,
 		lock: l,
 	}
@@ -228,6 +288,54 @@ func (d *SQLiteDatastore) ModeratedStores() repo.ModeratedStores {
 	return d.moderatedStores
 }
 
+func (d *SQLiteDatastore) Bids() repo.Bids {
+	return d.bids
+}
+
+func (d *SQLiteDatastore) WebhookQueue() repo.WebhookQueue {
+	return d.webhookQueue
+}
+
+func (d *SQLiteDatastore) APITokens() repo.APITokens {
+	return d.apiTokens
+}
+
+func (d *SQLiteDatastore) AuditLog() repo.AuditLog {
+	return d.auditLog
+}
+
+func (d *SQLiteDatastore) FrozenCoins() repo.FrozenCoins {
+	return d.frozenCoins
+}
+
+func (d *SQLiteDatastore) UnsignedTransactions() repo.UnsignedTransactions {
+	return d.unsignedTxs
+}
+
+func (d *SQLiteDatastore) ColdStorage() repo.ColdStorage {
+	return d.coldStorage
+}
+
+func (d *SQLiteDatastore) ExchangeRateHistory() repo.ExchangeRateHistory {
+	return d.rateHistory
+}
+
+func (d *SQLiteDatastore) StoredMessages() repo.StoredMessages {
+	return d.storedMessages
+}
+
+func (d *SQLiteDatastore) Outbox() repo.Outbox {
+	return d.outbox
+}
+
+func (d *SQLiteDatastore) Relay() repo.Relay {
+	return d.relay
+}
+
+func (d *SQLiteDatastore) Capabilities() repo.Capabilities {
+	return d.capabilities
+}
+
 func (d *SQLiteDatastore) Copy(dbPath string, password string) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -283,6 +391,7 @@ func initDatabaseTables(db *sql.DB, password string) error {
 	create table stxos (outpoint text primary key not null, value integer, height integer, scriptPubKey text, watchOnly integer, spendHeight integer, spendTxid text);
 	create table txns (txid text primary key not null, value integer, height integer, timestamp integer, watchOnly integer, tx blob);
 	create table txmetadata (txid text primary key not null, address text, memo text, orderID text, thumbnail text, canBumpFee integer);
+	create table txlabels (txid text not null, address text not null, label text, primary key (txid, address));
 	create table inventory (invID text primary key not null, slug text, variantIndex integer, count integer);
 	create index index_inventory on inventory (slug);
 	create table purchases (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, vendorID text, vendorBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob);
@@ -299,6 +408,27 @@ func initDatabaseTables(db *sql.DB, password string) error {
 	create table coupons (slug text, code text, hash text);
 	create index index_coupons on coupons (slug);
 	create table moderatedstores (peerID text primary key not null);
+	create table bids (bidID text primary key not null, vendorID text, slug text, buyerID text, amount integer, timestamp integer, bid blob);
+	create index index_bids on bids (vendorID, slug, amount);
+	create table webhookqueue (id integer primary key autoincrement, url text, eventType text, payload blob, attempts integer, nextAttempt integer);
+	create index index_webhookqueue on webhookqueue (nextAttempt);
+	create table apitokens (name text primary key not null, tokenHash text unique, scopes text, created integer);
+	create table auditlog (id integer primary key autoincrement, timestamp integer, method text, path text, principal text, request text, status integer, result text, prevHash text, hash text);
+	create table auditloghead (id integer primary key not null, entryId integer, hash text);
+	create table frozencoins (outpoint text primary key not null);
+	create table unsignedtxs (id text primary key not null, intent text, timestamp integer, package blob, signed blob, txid text);
+	create index index_unsignedtxs on unsignedtxs (intent);
+	create table escrowchaincodes (pubkey text primary key not null, chaincode blob);
+	create table coldstorage (xpub text primary key not null, nextIndex integer);
+	create table exchangerates (currencyCode text not null, timestamp integer not null, rate real, primary key (currencyCode, timestamp));
+	create table storedmessages (pointerID text primary key not null, address text, timestamp integer, acknowledged integer);
+	create table outbox (pointerID text primary key not null, messageType text, recipient text, orderID text, location text, timestamp integer, status text, updated integer, message blob);
+	create index index_outbox on outbox (orderID);
+	create table relayrecipients (peerID text primary key not null, timestamp integer);
+	create table relaymessages (id integer primary key autoincrement, recipient text, sender text, message blob, size integer, timestamp integer);
+	create index index_relaymessages on relaymessages (recipient);
+	create index index_relaymessages_sender on relaymessages (sender);
+	create table capabilities (peerID text primary key not null, protocolVersion integer, features text, userAgent text, updated integer);
 	`
 	_, err := db.Exec(sqlStmt)
 	if err != nil {
@@ -393,6 +523,31 @@ func (c *ConfigDB) GetCreationDate() (time.Time, error) {
 	return time.Parse(time.RFC3339, string(creationDate))
 }
 
+func (c *ConfigDB) SetWatchOnlyKey(key []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("insert or replace into config(key, value) values(?,?)", "watchOnlyKey", key)
+	return err
+}
+
+func (c *ConfigDB) GetWatchOnlyKey() ([]byte, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	stmt, err := c.db.Prepare("select value from config where key=?")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var key []byte
+	err = stmt.QueryRow("watchOnlyKey").Scan(&key)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 func (c *ConfigDB) IsEncrypted() bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -52,6 +52,26 @@ func TestInit(t *testing.T) {
 	}
 }
 
+func TestWatchOnlyKey(t *testing.T) {
+	key, err := testDB.config.GetWatchOnlyKey()
+	if err != nil {
+		t.Error(err)
+	}
+	if key != nil {
+		t.Error("Node should not be watch-only")
+	}
+	if err := testDB.config.SetWatchOnlyKey([]byte("xpub")); err != nil {
+		t.Error(err)
+	}
+	key, err = testDB.config.GetWatchOnlyKey()
+	if err != nil {
+		t.Error(err)
+	}
+	if string(key) != "xpub" {
+		t.Error("Config returned wrong watch-only key")
+	}
+}
+
 func TestInterface(t *testing.T) {
 	if testDB.Config() != testDB.config {
 		t.Error("Config() return wrong value")
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+/* A snapshot further than this from the requested time is not used. It is twice the interval
+   the exchange rates are saved at so a single missed snapshot is tolerated. */
+const MaxRateDistance = 2 * time.Hour
+
+type ExchangeRateHistoryDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (e *ExchangeRateHistoryDB) Put(rates map[string]float64, timestamp time.Time) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	tx, err := e.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into exchangerates(currencyCode, timestamp, rate) values(?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for currencyCode, rate := range rates {
+		_, err = stmt.Exec(currencyCode, int(timestamp.Unix()), rate)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (e *ExchangeRateHistoryDB) GetAt(currencyCode string, timestamp time.Time) (float64, time.Time, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	t := int(timestamp.Unix())
+	var rate float64
+	var closest int
+	found := false
+	queries := []string{
+		"select rate, timestamp from exchangerates where currencyCode=? and timestamp<=? order by timestamp desc limit 1",
+		"select rate, timestamp from exchangerates where currencyCode=? and timestamp>? order by timestamp asc limit 1",
+	}
+	for _, query := range queries {
+		var r float64
+		var ts int
+		err := e.db.QueryRow(query, currencyCode, t).Scan(&r, &ts)
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			return 0, time.Time{}, err
+		}
+		if !found || ts-t < t-closest {
+			rate, closest = r, ts
+			found = true
+		}
+	}
+	if !found {
+		return 0, time.Time{}, errors.New("No exchange rate recorded for " + currencyCode)
+	}
+	if d := time.Duration(closest-t) * time.Second; d > MaxRateDistance || -d > MaxRateDistance {
+		return 0, time.Time{}, errors.New("No exchange rate recorded for " + currencyCode + " near that time")
+	}
+	return rate, time.Unix(int64(closest), 0), nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+var rateHistoryDB ExchangeRateHistoryDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	rateHistoryDB = ExchangeRateHistoryDB{
+		db: conn,
+	}
+}
+
+func TestExchangeRateHistoryDB_GetAt(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	if _, _, err := rateHistoryDB.GetAt("USD", start); err == nil {
+		t.Error("Returned a rate before any were saved")
+	}
+	for i, usd := range []float64{2000, 2100, 2200} {
+		err := rateHistoryDB.Put(map[string]float64{"USD": usd, "EUR": usd * 0.9}, start.Add(time.Hour*time.Duration(i)))
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	tests := []struct {
+		timestamp time.Time
+		rate      float64
+		recorded  time.Time
+	}{
+		{start.Add(-time.Hour * 2), 2000, start},
+		{start, 2000, start},
+		{start.Add(time.Minute * 20), 2000, start},
+		{start.Add(time.Minute * 40), 2100, start.Add(time.Hour)},
+		{start.Add(time.Hour * 2), 2200, start.Add(time.Hour * 2)},
+		{start.Add(time.Hour * 4), 2200, start.Add(time.Hour * 2)},
+	}
+	for _, test := range tests {
+		rate, recorded, err := rateHistoryDB.GetAt("USD", test.timestamp)
+		if err != nil {
+			t.Error(err)
+		}
+		if rate != test.rate || !recorded.Equal(test.recorded) {
+			t.Errorf("Rate at %s is %f from %s, expected %f from %s", test.timestamp, rate, recorded, test.rate, test.recorded)
+		}
+	}
+	for _, timestamp := range []time.Time{start.Add(-time.Hour*2 - time.Second), start.Add(time.Hour*4 + time.Second), start.Add(time.Hour * 24)} {
+		if _, _, err := rateHistoryDB.GetAt("USD", timestamp); err == nil {
+			t.Errorf("Returned a stale rate for %s", timestamp)
+		}
+	}
+	if _, _, err := rateHistoryDB.GetAt("GBP", start); err == nil {
+		t.Error("Returned a rate for an untracked currency")
+	}
+}
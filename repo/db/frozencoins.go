@@ -0,0 +1,59 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+)
+
+type FrozenCoinsDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (f *FrozenCoinsDB) Put(outpoint string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	tx, err := f.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into frozencoins(outpoint) values(?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(outpoint)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (f *FrozenCoinsDB) GetAll() ([]string, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var ret []string
+	rows, err := f.db.Query("select outpoint from frozencoins")
+	if err != nil {
+		return ret, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var outpoint string
+		if err := rows.Scan(&outpoint); err != nil {
+			continue
+		}
+		ret = append(ret, outpoint)
+	}
+	return ret, nil
+}
+
+func (f *FrozenCoinsDB) Delete(outpoint string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	_, err := f.db.Exec("delete from frozencoins where outpoint=?", outpoint)
+	return err
+}
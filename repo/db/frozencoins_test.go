@@ -0,0 +1,51 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+var frozenDB FrozenCoinsDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	frozenDB = FrozenCoinsDB{
+		db: conn,
+	}
+}
+
+func TestFrozenCoinsDB_Put(t *testing.T) {
+	outpoint := "a0ee0dda2d6f6a1ad9b6d3f1bd87c0bcbd5f6bb6be6d5b0c34e9e3a2f6e5b4c3:1"
+	if err := frozenDB.Put(outpoint); err != nil {
+		t.Error(err)
+	}
+	// Freezing twice is not an error
+	if err := frozenDB.Put(outpoint); err != nil {
+		t.Error(err)
+	}
+	frozen, err := frozenDB.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(frozen) != 1 || frozen[0] != outpoint {
+		t.Errorf("Expected %s to be frozen, got %v", outpoint, frozen)
+	}
+}
+
+func TestFrozenCoinsDB_Delete(t *testing.T) {
+	outpoint := "b1ee0dda2d6f6a1ad9b6d3f1bd87c0bcbd5f6bb6be6d5b0c34e9e3a2f6e5b4c3:0"
+	frozenDB.Put(outpoint)
+	if err := frozenDB.Delete(outpoint); err != nil {
+		t.Error(err)
+	}
+	frozen, err := frozenDB.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	for _, f := range frozen {
+		if f == outpoint {
+			t.Error("Coin is still frozen after being deleted")
+		}
+	}
+}
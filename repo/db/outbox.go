@@ -0,0 +1,109 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"sync"
+	"time"
+)
+
+type OutboxDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (o *OutboxDB) Put(message repo.OutboxMessage) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	tx, err := o.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into outbox(pointerID, messageType, recipient, orderID, location, timestamp, status, updated, message) values(?,?,?,?,?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		message.PointerID,
+		message.MessageType,
+		message.Recipient,
+		message.OrderId,
+		message.Location,
+		int(message.Timestamp.Unix()),
+		message.Status,
+		int(message.Updated.Unix()),
+		message.Message,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (o *OutboxDB) Get(pointerID string) (repo.OutboxMessage, error) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	row := o.db.QueryRow("select pointerID, messageType, recipient, orderID, location, timestamp, status, updated, message from outbox where pointerID=?", pointerID)
+	return scanOutboxMessage(row)
+}
+
+func (o *OutboxDB) GetPending() ([]repo.OutboxMessage, error) {
+	return o.query("select pointerID, messageType, recipient, orderID, location, timestamp, status, updated, message from outbox where status=? order by timestamp asc", repo.OutboxPending)
+}
+
+func (o *OutboxDB) GetByOrderId(orderId string) ([]repo.OutboxMessage, error) {
+	return o.query("select pointerID, messageType, recipient, orderID, location, timestamp, status, updated, message from outbox where orderID=? order by timestamp asc", orderId)
+}
+
+func (o *OutboxDB) SetStatus(pointerID string, status string, timestamp time.Time) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	var err error
+	if status == repo.OutboxPending {
+		_, err = o.db.Exec("update outbox set status=?, updated=? where pointerID=?", status, int(timestamp.Unix()), pointerID)
+	} else {
+		_, err = o.db.Exec("update outbox set status=?, updated=?, message=null where pointerID=?", status, int(timestamp.Unix()), pointerID)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *OutboxDB) query(stm string, arg interface{}) ([]repo.OutboxMessage, error) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	rows, err := o.db.Query(stm, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.OutboxMessage
+	for rows.Next() {
+		m, err := scanOutboxMessage(rows)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+func scanOutboxMessage(row interface {
+	Scan(dest ...interface{}) error
+}) (repo.OutboxMessage, error) {
+	var m repo.OutboxMessage
+	var timestamp int
+	var updated int
+	err := row.Scan(&m.PointerID, &m.MessageType, &m.Recipient, &m.OrderId, &m.Location, &timestamp, &m.Status, &updated, &m.Message)
+	if err != nil {
+		return m, err
+	}
+	m.Timestamp = time.Unix(int64(timestamp), 0)
+	m.Updated = time.Unix(int64(updated), 0)
+	return m, nil
+}
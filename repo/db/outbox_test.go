@@ -0,0 +1,66 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"testing"
+	"time"
+)
+
+var outboxDB OutboxDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	outboxDB = OutboxDB{
+		db: conn,
+	}
+}
+
+func TestOutboxDB(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	messages := []repo.OutboxMessage{
+		{"QmPointer2", "ORDER_FULFILLMENT", "QmRecipient", "QmOrder", "/ipfs/QmLocation2", now.Add(time.Minute), repo.OutboxPending, now.Add(time.Minute), []byte("fulfillment")},
+		{"QmPointer1", "ORDER_CONFIRMATION", "QmRecipient", "QmOrder", "/ipfs/QmLocation1", now, repo.OutboxPending, now, []byte("confirmation")},
+		{"QmPointer3", "CHAT", "QmOther", "", "/ipfs/QmLocation3", now, repo.OutboxPending, now, []byte("chat")},
+	}
+	for _, m := range messages {
+		if err := outboxDB.Put(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := outboxDB.Get("QmPointer2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MessageType != "ORDER_FULFILLMENT" || m.Recipient != "QmRecipient" || m.OrderId != "QmOrder" ||
+		m.Location != "/ipfs/QmLocation2" || !m.Timestamp.Equal(now.Add(time.Minute)) || string(m.Message) != "fulfillment" {
+		t.Error("Returned incorrect outbox message")
+	}
+
+	if err := outboxDB.SetStatus("QmPointer1", repo.OutboxAcknowledged, now.Add(time.Hour)); err != nil {
+		t.Error(err)
+	}
+	m, err = outboxDB.Get("QmPointer1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Status != repo.OutboxAcknowledged || !m.Updated.Equal(now.Add(time.Hour)) || m.Message != nil {
+		t.Error("Failed to update outbox message status")
+	}
+
+	pending, err := outboxDB.GetPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].PointerID != "QmPointer3" || pending[1].PointerID != "QmPointer2" {
+		t.Error("Returned incorrect pending messages")
+	}
+	order, err := outboxDB.GetByOrderId("QmOrder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 2 || order[0].PointerID != "QmPointer1" || order[1].PointerID != "QmPointer2" {
+		t.Error("Returned incorrect messages for order")
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"sync"
+	"time"
+)
+
+type RelayDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (r *RelayDB) Register(recipient string, timestamp time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, err := r.db.Exec("insert or replace into relayrecipients(peerID, timestamp) values(?,?)", recipient, int(timestamp.Unix()))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RelayDB) IsRegistered(recipient string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var peerID string
+	err := r.db.QueryRow("select peerID from relayrecipients where peerID=?", recipient).Scan(&peerID)
+	return err == nil
+}
+
+func (r *RelayDB) Put(recipient, sender string, message []byte, timestamp time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert into relaymessages(recipient, sender, message, size, timestamp) values(?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(recipient, sender, message, len(message), int(timestamp.Unix()))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (r *RelayDB) Usage(recipient string) (int, int, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var count int
+	var size sql.NullInt64
+	err := r.db.QueryRow("select count(*), sum(size) from relaymessages where recipient=?", recipient).Scan(&count, &size)
+	if err != nil {
+		return 0, 0, err
+	}
+	return count, int(size.Int64), nil
+}
+
+func (r *RelayDB) SenderUsage(sender string) (int, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var count int
+	err := r.db.QueryRow("select count(*) from relaymessages where sender=?", sender).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *RelayDB) TotalUsage() (int, int, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var count int
+	var size sql.NullInt64
+	err := r.db.QueryRow("select count(*), sum(size) from relaymessages").Scan(&count, &size)
+	if err != nil {
+		return 0, 0, err
+	}
+	return count, int(size.Int64), nil
+}
+
+func (r *RelayDB) GetAll(recipient string) ([]repo.RelayMessage, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	rows, err := r.db.Query("select id, recipient, message, timestamp from relaymessages where recipient=? order by id asc", recipient)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.RelayMessage
+	for rows.Next() {
+		var m repo.RelayMessage
+		var timestamp int
+		if err := rows.Scan(&m.ID, &m.Recipient, &m.Message, &timestamp); err != nil {
+			return ret, err
+		}
+		m.Timestamp = time.Unix(int64(timestamp), 0)
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+func (r *RelayDB) Delete(id int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, err := r.db.Exec("delete from relaymessages where id=?", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *RelayDB) DeleteExpired(cutoff time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, err := r.db.Exec("delete from relaymessages where timestamp<?", int(cutoff.Unix()))
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+var relayDB RelayDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	relayDB = RelayDB{
+		db: conn,
+	}
+}
+
+func TestRelayDB_Register(t *testing.T) {
+	if relayDB.IsRegistered("QmRecipient") {
+		t.Error("Unknown recipient is registered")
+	}
+	if err := relayDB.Register("QmRecipient", time.Now()); err != nil {
+		t.Error(err)
+	}
+	if !relayDB.IsRegistered("QmRecipient") {
+		t.Error("Failed to register recipient")
+	}
+}
+
+func TestRelayDB_Messages(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	count, size, err := relayDB.Usage("QmHolder")
+	if err != nil || count != 0 || size != 0 {
+		t.Error("Returned usage for a recipient without messages")
+	}
+	if err := relayDB.Put("QmHolder", "QmSender", []byte("old"), now.Add(-time.Hour*24*31)); err != nil {
+		t.Error(err)
+	}
+	if err := relayDB.Put("QmHolder", "QmSender", []byte("first"), now); err != nil {
+		t.Error(err)
+	}
+	if err := relayDB.Put("QmHolder", "QmSender", []byte("second"), now); err != nil {
+		t.Error(err)
+	}
+	if err := relayDB.Put("QmOther", "QmSomeoneElse", []byte("other"), now); err != nil {
+		t.Error(err)
+	}
+	count, size, err = relayDB.Usage("QmHolder")
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 3 || size != 14 {
+		t.Errorf("Usage is %d messages and %d bytes, expected 3 and 14", count, size)
+	}
+	count, err = relayDB.SenderUsage("QmSender")
+	if err != nil || count != 3 {
+		t.Errorf("Sender usage is %d messages, expected 3", count)
+	}
+	count, size, err = relayDB.TotalUsage()
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 4 || size != 19 {
+		t.Errorf("Total usage is %d messages and %d bytes, expected 4 and 19", count, size)
+	}
+
+	if err := relayDB.DeleteExpired(now.Add(-time.Hour * 24 * 30)); err != nil {
+		t.Error(err)
+	}
+	messages, err := relayDB.GetAll("QmHolder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || string(messages[0].Message) != "first" || string(messages[1].Message) != "second" {
+		t.Fatal("Returned incorrect messages")
+	}
+	if messages[0].Recipient != "QmHolder" || !messages[0].Timestamp.Equal(now) {
+		t.Error("Returned incorrect message details")
+	}
+	if err := relayDB.Delete(messages[0].ID); err != nil {
+		t.Error(err)
+	}
+	messages, err = relayDB.GetAll("QmHolder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || string(messages[0].Message) != "second" {
+		t.Error("Failed to delete message")
+	}
+}
@@ -107,6 +107,18 @@ func (s *SettingsDB) Update(settings repo.SettingsData) error {
 	if settings.SMTPSettings == nil {
 		settings.SMTPSettings = current.SMTPSettings
 	}
+	if settings.WebhookSettings == nil {
+		settings.WebhookSettings = current.WebhookSettings
+	}
+	if settings.CommandSettings == nil {
+		settings.CommandSettings = current.CommandSettings
+	}
+	if settings.OrderCompletion == nil {
+		settings.OrderCompletion = current.OrderCompletion
+	}
+	if settings.ColdStorage == nil {
+		settings.ColdStorage = current.ColdStorage
+	}
 	err = s.Put(settings)
 	if err != nil {
 		return err
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+	"sync"
+	"time"
+)
+
+type StoredMessagesDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (s *StoredMessagesDB) Put(pointerID peer.ID, addr ma.Multiaddr, timestamp time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into storedmessages(pointerID, address, timestamp, acknowledged) values(?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(pointerID.Pretty(), addr.String(), int(timestamp.Unix()), 0)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (s *StoredMessagesDB) Get(pointerID peer.ID) (repo.StoredMessage, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	row := s.db.QueryRow("select pointerID, address, timestamp, acknowledged from storedmessages where pointerID=?", pointerID.Pretty())
+	return scanStoredMessage(row)
+}
+
+func (s *StoredMessagesDB) Acknowledge(pointerID peer.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err := s.db.Exec("update storedmessages set acknowledged=1 where pointerID=?", pointerID.Pretty())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *StoredMessagesDB) GetCollectable(cutoff time.Time) ([]repo.StoredMessage, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	rows, err := s.db.Query("select pointerID, address, timestamp, acknowledged from storedmessages where acknowledged=1 or timestamp<?", int(cutoff.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.StoredMessage
+	for rows.Next() {
+		m, err := scanStoredMessage(rows)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+func (s *StoredMessagesDB) Delete(pointerID peer.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err := s.db.Exec("delete from storedmessages where pointerID=?", pointerID.Pretty())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func scanStoredMessage(row interface {
+	Scan(dest ...interface{}) error
+}) (repo.StoredMessage, error) {
+	var pointerID string
+	var address string
+	var timestamp int
+	var acknowledged int
+	if err := row.Scan(&pointerID, &address, &timestamp, &acknowledged); err != nil {
+		return repo.StoredMessage{}, err
+	}
+	pid, err := peer.IDB58Decode(pointerID)
+	if err != nil {
+		return repo.StoredMessage{}, err
+	}
+	addr, err := ma.NewMultiaddr(address)
+	if err != nil {
+		return repo.StoredMessage{}, err
+	}
+	return repo.StoredMessage{
+		PointerID:    pid,
+		Address:      addr,
+		Timestamp:    time.Unix(int64(timestamp), 0),
+		Acknowledged: acknowledged == 1,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"database/sql"
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+	"testing"
+	"time"
+)
+
+var storedMessagesDB StoredMessagesDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	storedMessagesDB = StoredMessagesDB{
+		db: conn,
+	}
+}
+
+func TestStoredMessagesDB(t *testing.T) {
+	old, _ := peer.IDB58Decode("QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr")
+	acked, _ := peer.IDB58Decode("QmbwSMS35CaYKdrYBvvR9aHU9FzeWhjJ7E3jLKeR2DWrs3")
+	pending, _ := peer.IDB58Decode("QmNaYCcRVMHD2UAdh6XPYZELfg4ry7XZvJHu8o6b8dAgaq")
+	addr, _ := ma.NewMultiaddr("/ipfs/QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub/")
+	now := time.Unix(1500000000, 0)
+
+	for _, m := range []struct {
+		pointerID peer.ID
+		timestamp time.Time
+	}{
+		{old, now.Add(-time.Hour * 24 * 31)},
+		{acked, now.Add(-time.Hour)},
+		{pending, now.Add(-time.Hour)},
+	} {
+		if err := storedMessagesDB.Put(m.pointerID, addr, m.timestamp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storedMessagesDB.Acknowledge(acked); err != nil {
+		t.Error(err)
+	}
+	m, err := storedMessagesDB.Get(acked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Acknowledged || !m.Timestamp.Equal(now.Add(-time.Hour)) || m.Address.String() != addr.String() {
+		t.Error("Returned incorrect stored message")
+	}
+
+	collectable, err := storedMessagesDB.GetCollectable(now.Add(-time.Hour * 24 * 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collectable) != 2 {
+		t.Fatalf("Returned %d collectable messages, expected 2", len(collectable))
+	}
+	for _, m := range collectable {
+		if m.PointerID == pending {
+			t.Error("Returned a message which is neither acknowledged nor expired")
+		}
+		if err := storedMessagesDB.Delete(m.PointerID); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := storedMessagesDB.Get(old); err == nil {
+		t.Error("Failed to delete stored message")
+	}
+	if _, err := storedMessagesDB.Get(pending); err != nil {
+		t.Error(err)
+	}
+}
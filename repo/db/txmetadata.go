@@ -30,6 +30,13 @@ func (t *TxMetadataDB) Put(m repo.Metadata) error {
 		tx.Rollback()
 		return err
 	}
+	for address, label := range m.Labels {
+		_, err = tx.Exec("insert or replace into txlabels(txid, address, label) values(?,?,?)", m.Txid, address, label)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 	tx.Commit()
 	return nil
 }
@@ -50,7 +57,11 @@ func (t *TxMetadataDB) Get(txid string) (repo.Metadata, error) {
 	if canBumpFee > 0 {
 		bumpable = true
 	}
-	m = repo.Metadata{id, address, memo, orderId, thumbnail, bumpable}
+	labels, err := t.getLabels("select txid, address, label from txlabels where txid=?", txid)
+	if err != nil {
+		return m, err
+	}
+	m = repo.Metadata{id, address, memo, orderId, thumbnail, bumpable, labels[id]}
 	return m, nil
 }
 
@@ -58,6 +69,10 @@ func (t *TxMetadataDB) GetAll() (map[string]repo.Metadata, error) {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
 	ret := make(map[string]repo.Metadata)
+	labels, err := t.getLabels("select txid, address, label from txlabels")
+	if err != nil {
+		return ret, err
+	}
 	stm := "select txid, address, memo, orderID, thumbnail, canBumpFee from txmetadata"
 	rows, err := t.db.Query(stm)
 	if err != nil {
@@ -81,12 +96,34 @@ func (t *TxMetadataDB) GetAll() (map[string]repo.Metadata, error) {
 			OrderId:    orderId,
 			Thumbnail:  thumbnail,
 			CanBumpFee: bumpable,
+			Labels:     labels[txid],
 		}
 		ret[txid] = m
 	}
 	return ret, nil
 }
 
+// Return the output labels selected by the query keyed by txid then address
+func (t *TxMetadataDB) getLabels(query string, args ...interface{}) (map[string]map[string]string, error) {
+	ret := make(map[string]map[string]string)
+	rows, err := t.db.Query(query, args...)
+	if err != nil {
+		return ret, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var txid, address, label string
+		if err := rows.Scan(&txid, &address, &label); err != nil {
+			return ret, err
+		}
+		if ret[txid] == nil {
+			ret[txid] = make(map[string]string)
+		}
+		ret[txid][address] = label
+	}
+	return ret, nil
+}
+
 func (t *TxMetadataDB) Delete(txid string) error {
 	t.lock.Lock()
 	defer t.lock.Unlock()
@@ -94,5 +131,9 @@ func (t *TxMetadataDB) Delete(txid string) error {
 	if err != nil {
 		return err
 	}
+	_, err = t.db.Exec("delete from txlabels where txid=?", txid)
+	if err != nil {
+		return err
+	}
 	return nil
 }
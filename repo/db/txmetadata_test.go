@@ -15,7 +15,7 @@ func init() {
 	metDB = TxMetadataDB{
 		db: conn,
 	}
-	m = repo.Metadata{"16e4a210d8c798f7d7a32584038c1f55074377bdd19f4caa24edb657fff9538f", "1Xtkf3Rdq6eix4tFXpEuHdXfubt3Mt452", "Some memo", "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", "QmZY1kx6VrNjgDB4SJDByxvSVuiBfsisRLdUMJRDppTTsS", false}
+	m = repo.Metadata{"16e4a210d8c798f7d7a32584038c1f55074377bdd19f4caa24edb657fff9538f", "1Xtkf3Rdq6eix4tFXpEuHdXfubt3Mt452", "Some memo", "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", "QmZY1kx6VrNjgDB4SJDByxvSVuiBfsisRLdUMJRDppTTsS", false, nil}
 }
 
 func TestTxMetadataDB_Put(t *testing.T) {
@@ -134,3 +134,59 @@ func TestTxMetadataDB_Delete(t *testing.T) {
 		t.Error("TxMetadataDB failed to delete row")
 	}
 }
+
+func TestTxMetadataDB_Labels(t *testing.T) {
+	labelled := repo.Metadata{
+		Txid:   "c4ba4a5b6cd4d8ac5c7cf2ad9bd01e6b3e58dd4e2a66a7d3f7ad24a54dcb0c11",
+		Labels: map[string]string{"1Xtkf3Rdq6eix4tFXpEuHdXfubt3Mt452": "Rent", "1HYhu8e2wv19LZ2umXoo1pMiwzy2rL32UQ": "Deposit"},
+	}
+	if err := metDB.Put(labelled); err != nil {
+		t.Error(err)
+	}
+	ret, err := metDB.Get(labelled.Txid)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(ret.Labels) != 2 || ret.Labels["1Xtkf3Rdq6eix4tFXpEuHdXfubt3Mt452"] != "Rent" || ret.Labels["1HYhu8e2wv19LZ2umXoo1pMiwzy2rL32UQ"] != "Deposit" {
+		t.Error("TxMetadataDB failed to get the output labels")
+	}
+	all, err := metDB.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(all[labelled.Txid].Labels) != 2 {
+		t.Error("TxMetadataDB failed to get the output labels of every transaction")
+	}
+
+	// Updating the rest of the metadata keeps the labels
+	labelled.Labels = nil
+	labelled.Memo = "Payroll"
+	if err := metDB.Put(labelled); err != nil {
+		t.Error(err)
+	}
+	ret, err = metDB.Get(labelled.Txid)
+	if err != nil {
+		t.Error(err)
+	}
+	if ret.Memo != "Payroll" || len(ret.Labels) != 2 {
+		t.Error("TxMetadataDB lost the output labels on update")
+	}
+
+	if err := metDB.Delete(labelled.Txid); err != nil {
+		t.Error(err)
+	}
+	all, err = metDB.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := all[labelled.Txid]; ok {
+		t.Error("TxMetadataDB failed to delete the metadata")
+	}
+	labels, err := metDB.getLabels("select txid, address, label from txlabels where txid=?", labelled.Txid)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(labels) != 0 {
+		t.Error("TxMetadataDB failed to delete the output labels")
+	}
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type UnsignedTransactionsDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (u *UnsignedTransactionsDB) Put(utx repo.UnsignedTransaction) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into unsignedtxs(id, intent, timestamp, package, signed, txid) values(?,?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(utx.ID, utx.Intent, int(utx.Timestamp.Unix()), utx.Package, utx.Signed, utx.Txid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (u *UnsignedTransactionsDB) Get(id string) (*repo.UnsignedTransaction, error) {
+	return u.getBy("id", id)
+}
+
+func (u *UnsignedTransactionsDB) GetByIntent(intent string) (*repo.UnsignedTransaction, error) {
+	return u.getBy("intent", intent)
+}
+
+func (u *UnsignedTransactionsDB) getBy(column, value string) (*repo.UnsignedTransaction, error) {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	rows, err := u.db.Query("select id, intent, timestamp, package, signed, txid from unsignedtxs where "+column+"=? limit 1", value)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ret := scanUnsignedTransactions(rows)
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &ret[0], nil
+}
+
+func (u *UnsignedTransactionsDB) GetAll() ([]repo.UnsignedTransaction, error) {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	rows, err := u.db.Query("select id, intent, timestamp, package, signed, txid from unsignedtxs order by timestamp desc")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanUnsignedTransactions(rows), nil
+}
+
+func (u *UnsignedTransactionsDB) Delete(id string) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	_, err := u.db.Exec("delete from unsignedtxs where id=?", id)
+	return err
+}
+
+func (u *UnsignedTransactionsDB) PutChaincode(pubkey string, chaincode []byte) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	_, err := u.db.Exec("insert or replace into escrowchaincodes(pubkey, chaincode) values(?,?)", pubkey, chaincode)
+	return err
+}
+
+func (u *UnsignedTransactionsDB) GetChaincode(pubkey string) ([]byte, error) {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	var chaincode []byte
+	err := u.db.QueryRow("select chaincode from escrowchaincodes where pubkey=?", pubkey).Scan(&chaincode)
+	if err != nil {
+		return nil, err
+	}
+	return chaincode, nil
+}
+
+func scanUnsignedTransactions(rows *sql.Rows) []repo.UnsignedTransaction {
+	var ret []repo.UnsignedTransaction
+	for rows.Next() {
+		var utx repo.UnsignedTransaction
+		var timestamp int
+		if err := rows.Scan(&utx.ID, &utx.Intent, &timestamp, &utx.Package, &utx.Signed, &utx.Txid); err != nil {
+			continue
+		}
+		utx.Timestamp = time.Unix(int64(timestamp), 0)
+		ret = append(ret, utx)
+	}
+	return ret
+}
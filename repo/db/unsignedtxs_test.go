@@ -0,0 +1,95 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var unsignedTxsDB UnsignedTransactionsDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	unsignedTxsDB = UnsignedTransactionsDB{
+		db: conn,
+	}
+}
+
+func TestUnsignedTransactionsDB_Put(t *testing.T) {
+	utx := repo.UnsignedTransaction{
+		ID:        "tx1",
+		Intent:    "sweep:abc",
+		Timestamp: time.Now(),
+		Package:   []byte(`{"id":"tx1"}`),
+	}
+	if err := unsignedTxsDB.Put(utx); err != nil {
+		t.Error(err)
+	}
+	ret, err := unsignedTxsDB.Get("tx1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Intent != utx.Intent || !bytes.Equal(ret.Package, utx.Package) || ret.Signed != nil || ret.Txid != "" {
+		t.Error("Returned the wrong transaction")
+	}
+
+	// Importing the signatures updates the row
+	utx.Signed = []byte(`{"id":"tx1"}`)
+	utx.Txid = "abcd"
+	if err := unsignedTxsDB.Put(utx); err != nil {
+		t.Error(err)
+	}
+	ret, err = unsignedTxsDB.GetByIntent("sweep:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.ID != "tx1" || !bytes.Equal(ret.Signed, utx.Signed) || ret.Txid != "abcd" {
+		t.Error("Failed to update the transaction")
+	}
+	if _, err := unsignedTxsDB.Get("tx2"); err == nil {
+		t.Error("Returned a transaction that doesn't exist")
+	}
+}
+
+func TestUnsignedTransactionsDB_GetAll(t *testing.T) {
+	for _, id := range []string{"a", "b"} {
+		if err := unsignedTxsDB.Put(repo.UnsignedTransaction{ID: id, Timestamp: time.Now()}); err != nil {
+			t.Error(err)
+		}
+	}
+	all, err := unsignedTxsDB.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(all) < 2 {
+		t.Error("Returned the wrong number of transactions")
+	}
+	if err := unsignedTxsDB.Delete("a"); err != nil {
+		t.Error(err)
+	}
+	if _, err := unsignedTxsDB.Get("a"); err == nil {
+		t.Error("Failed to delete the transaction")
+	}
+}
+
+func TestUnsignedTransactionsDB_Chaincode(t *testing.T) {
+	pubkey := "02c6d6a5d1a4d0e6e98b2e3a1cbbb4e5b3e8d5f5f2d3f1e7a7c9b1d2e3f4a5b6c7"
+	chaincode := bytes.Repeat([]byte{0x07}, 32)
+	if _, err := unsignedTxsDB.GetChaincode(pubkey); err == nil {
+		t.Error("Returned a chaincode which was never saved")
+	}
+	if err := unsignedTxsDB.PutChaincode(pubkey, chaincode); err != nil {
+		t.Error(err)
+	}
+	ret, err := unsignedTxsDB.GetChaincode(pubkey)
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(ret, chaincode) {
+		t.Error("Returned incorrect chaincode")
+	}
+}
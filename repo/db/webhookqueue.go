@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type WebhookQueueDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (w *WebhookQueueDB) Put(url, eventType string, payload []byte, nextAttempt time.Time) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	tx, err := w.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert into webhookqueue(url, eventType, payload, attempts, nextAttempt) values(?,?,?,?,?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(url, eventType, payload, 0, int(nextAttempt.Unix()))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (w *WebhookQueueDB) GetDue(t time.Time) ([]repo.WebhookDelivery, error) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	rows, err := w.db.Query("select id, url, eventType, payload, attempts, nextAttempt from webhookqueue where nextAttempt<=? order by id asc", int(t.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.WebhookDelivery
+	for rows.Next() {
+		var d repo.WebhookDelivery
+		var nextAttempt int
+		if err := rows.Scan(&d.ID, &d.URL, &d.EventType, &d.Payload, &d.Attempts, &nextAttempt); err != nil {
+			continue
+		}
+		d.NextAttempt = time.Unix(int64(nextAttempt), 0)
+		ret = append(ret, d)
+	}
+	return ret, nil
+}
+
+func (w *WebhookQueueDB) Reschedule(id int, attempts int, nextAttempt time.Time) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := w.db.Exec("update webhookqueue set attempts=?, nextAttempt=? where id=?", attempts, int(nextAttempt.Unix()), id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WebhookQueueDB) Delete(id int) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := w.db.Exec("delete from webhookqueue where id=?", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
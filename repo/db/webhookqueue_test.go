@@ -0,0 +1,113 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+var wqdb WebhookQueueDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	wqdb = WebhookQueueDB{
+		db: conn,
+	}
+}
+
+func TestWebhookQueueDB_Put(t *testing.T) {
+	err := wqdb.Put("https://example.com/put", "order", []byte("{}"), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+	stmt, err := wqdb.db.Prepare("select url, eventType, payload, attempts from webhookqueue where url=?")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer stmt.Close()
+	var url, eventType string
+	var payload []byte
+	var attempts int
+	err = stmt.QueryRow("https://example.com/put").Scan(&url, &eventType, &payload, &attempts)
+	if err != nil {
+		t.Error(err)
+	}
+	if eventType != "order" || string(payload) != "{}" || attempts != 0 {
+		t.Error("Webhook queue db returned incorrect values")
+	}
+}
+
+func TestWebhookQueueDB_GetDue(t *testing.T) {
+	now := time.Now()
+	wqdb.Put("https://example.com/due", "payment", []byte("{}"), now.Add(-time.Minute))
+	wqdb.Put("https://example.com/notdue", "payment", []byte("{}"), now.Add(time.Hour))
+	due, err := wqdb.GetDue(now)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, d := range due {
+		if d.URL == "https://example.com/notdue" {
+			t.Error("Returned a request which is not yet due")
+		}
+	}
+	found := false
+	for _, d := range due {
+		if d.URL == "https://example.com/due" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Failed to return a due request")
+	}
+}
+
+func TestWebhookQueueDB_Reschedule(t *testing.T) {
+	wqdb.Put("https://example.com/reschedule", "refund", []byte("{}"), time.Now().Add(-time.Minute))
+	due, err := wqdb.GetDue(time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+	for _, d := range due {
+		if d.URL != "https://example.com/reschedule" {
+			continue
+		}
+		next := time.Now().Add(time.Hour)
+		if err := wqdb.Reschedule(d.ID, 3, next); err != nil {
+			t.Error(err)
+		}
+		var attempts, nextAttempt int
+		err = wqdb.db.QueryRow("select attempts, nextAttempt from webhookqueue where id=?", d.ID).Scan(&attempts, &nextAttempt)
+		if err != nil {
+			t.Error(err)
+		}
+		if attempts != 3 || int64(nextAttempt) != next.Unix() {
+			t.Error("Failed to reschedule request")
+		}
+	}
+}
+
+func TestWebhookQueueDB_Delete(t *testing.T) {
+	wqdb.Put("https://example.com/delete", "order", []byte("{}"), time.Now().Add(-time.Minute))
+	due, err := wqdb.GetDue(time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+	for _, d := range due {
+		if d.URL == "https://example.com/delete" {
+			if err := wqdb.Delete(d.ID); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	due, err = wqdb.GetDue(time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+	for _, d := range due {
+		if d.URL == "https://example.com/delete" {
+			t.Error("Failed to delete request")
+		}
+	}
+}
@@ -32,11 +32,7 @@ func DoInit(repoRoot string, nBitsForKeypair int, testnet bool, password string,
 		return err
 	}
 
-	conf, err := InitConfig(repoRoot)
-	if err != nil {
-		return err
-	}
-
+	var err error
 	if mnemonic == "" {
 		mnemonic, err = createMnemonic(bip39.NewEntropy, bip39.NewMnemonic)
 		if err != nil {
@@ -51,6 +47,32 @@ func DoInit(repoRoot string, nBitsForKeypair int, testnet bool, password string,
 	}
 	fmt.Printf("Done\n")
 
+	return initRepo(repoRoot, testnet, password, mnemonic, identityKey, creationDate, dbInit)
+}
+
+/* Initialize a watch-only node. There is no mnemonic in the database, the identity key is
+   the one derived from the mnemonic by the offline wallet. */
+func DoInitWatchOnly(repoRoot string, testnet bool, password string, identityKey []byte, creationDate time.Time, dbInit func(string, []byte, string, time.Time) error) error {
+	if err := maybeCreateOBDirectories(repoRoot); err != nil {
+		return err
+	}
+
+	if fsrepo.IsInitialized(repoRoot) {
+		return ErrRepoExists
+	}
+
+	if err := checkWriteable(repoRoot); err != nil {
+		return err
+	}
+	return initRepo(repoRoot, testnet, password, "", identityKey, creationDate, dbInit)
+}
+
+func initRepo(repoRoot string, testnet bool, password string, mnemonic string, identityKey []byte, creationDate time.Time, dbInit func(string, []byte, string, time.Time) error) error {
+	conf, err := InitConfig(repoRoot)
+	if err != nil {
+		return err
+	}
+
 	identity, err := ipfs.IdentityFromKey(identityKey)
 	if err != nil {
 		return err
@@ -214,6 +236,22 @@ func addConfigExtensions(repoRoot string, testnet bool) error {
 	if err := extendConfigFile(r, "Dropbox-api-token", ""); err != nil {
 		return err
 	}
+	if err := extendConfigFile(r, "S3", S3Config{Region: "us-east-1"}); err != nil {
+		return err
+	}
+	if err := extendConfigFile(r, "Offline-relays", []string{}); err != nil {
+		return err
+	}
+	if err := extendConfigFile(r, "Relay", RelayConfig{
+		MaxMessages:          1000,
+		MaxBytes:             50 * 1024 * 1024,
+		MaxMessagesPerSender: DefaultRelayMaxMessagesPerSender,
+		TotalMaxMessages:     DefaultRelayTotalMaxMessages,
+		TotalMaxBytes:        DefaultRelayTotalMaxBytes,
+		Recipients:           []string{},
+	}); err != nil {
+		return err
+	}
 	if err := extendConfigFile(r, "JSON-API", a); err != nil {
 		return err
 	}
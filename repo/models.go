@@ -1,24 +1,30 @@
 package repo
 
 import (
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
 	"time"
 )
 
 type SettingsData struct {
-	PaymentDataInQR    *bool              `json:"paymentDataInQR"`
-	ShowNotifications  *bool              `json:"showNotifications"`
-	ShowNsfw           *bool              `json:"showNsfw"`
-	ShippingAddresses  *[]ShippingAddress `json:"shippingAddresses"`
-	LocalCurrency      *string            `json:"localCurrency"`
-	Country            *string            `json:"country"`
-	Language           *string            `json:"language"`
-	TermsAndConditions *string            `json:"termsAndConditions"`
-	RefundPolicy       *string            `json:"refundPolicy"`
-	BlockedNodes       *[]string          `json:"blockedNodes"`
-	StoreModerators    *[]string          `json:"storeModerators"`
-	MisPaymentBuffer   *float32           `json:"mispaymentBuffer"`
-	SMTPSettings       *SMTPSettings      `json:"smtpSettings"`
-	Version            *string            `json:"version"`
+	PaymentDataInQR    *bool                    `json:"paymentDataInQR"`
+	ShowNotifications  *bool                    `json:"showNotifications"`
+	ShowNsfw           *bool                    `json:"showNsfw"`
+	ShippingAddresses  *[]ShippingAddress       `json:"shippingAddresses"`
+	LocalCurrency      *string                  `json:"localCurrency"`
+	Country            *string                  `json:"country"`
+	Language           *string                  `json:"language"`
+	TermsAndConditions *string                  `json:"termsAndConditions"`
+	RefundPolicy       *string                  `json:"refundPolicy"`
+	BlockedNodes       *[]string                `json:"blockedNodes"`
+	StoreModerators    *[]string                `json:"storeModerators"`
+	MisPaymentBuffer   *float32                 `json:"mispaymentBuffer"`
+	SMTPSettings       *SMTPSettings            `json:"smtpSettings"`
+	WebhookSettings    *WebhookSettings         `json:"webhookSettings"`
+	CommandSettings    *CommandSettings         `json:"commandSettings"`
+	OrderCompletion    *OrderCompletionSettings `json:"orderCompletion"`
+	ColdStorage        *ColdStorageSettings     `json:"coldStorage"`
+	Version            *string                  `json:"version"`
 }
 
 type ShippingAddress struct {
@@ -42,6 +48,98 @@ type SMTPSettings struct {
 	RecipientEmail string `json:"recipientEmail"`
 }
 
+type WebhookSettings struct {
+	Notifications bool      `json:"notifications"`
+	Webhooks      []Webhook `json:"webhooks"`
+}
+
+type Webhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+
+	// Notification types to send to this URL. If empty all notifications are sent.
+	EventTypes []string `json:"eventTypes"`
+}
+
+type CommandSettings struct {
+	Notifications bool `json:"notifications"`
+
+	// Name of an executable in the scripts directory of the repo
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+type OrderCompletionSettings struct {
+	// Complete our purchases once they have been fulfilled for this many days
+	AutoComplete     bool `json:"autoComplete"`
+	AutoCompleteDays int  `json:"autoCompleteDays"`
+
+	// Anonymous rating left on each item of an automatically completed purchase, 0 for none
+	DefaultRating int `json:"defaultRating"`
+
+	// Remind buyers every this many days to complete our fulfilled sales
+	Reminders    bool `json:"reminders"`
+	ReminderDays int  `json:"reminderDays"`
+}
+
+type ColdStorageSettings struct {
+	// Sweep confirmed funds above the threshold to the cold wallet
+	AutoSweep bool `json:"autoSweep"`
+
+	// Extended public key of the cold wallet's account. Funds are sent to its external chain.
+	Xpub string `json:"xpub"`
+
+	// Confirmed balance in satoshis to keep in the hot wallet
+	Threshold int64 `json:"threshold"`
+
+	// Don't sweep less than this many satoshis
+	MinimumSweep int64 `json:"minimumSweep"`
+
+	// PRIORITY, NORMAL or ECONOMIC. Defaults to ECONOMIC.
+	FeeLevel string `json:"feeLevel"`
+}
+
+// A webhook request waiting to be delivered
+type WebhookDelivery struct {
+	ID          int
+	URL         string
+	EventType   string
+	Payload     []byte
+	Attempts    int
+	NextAttempt time.Time
+}
+
+type APIToken struct {
+	Name      string    `json:"name"`
+	TokenHash string    `json:"-"`
+	Scopes    []string  `json:"scopes"`
+	Created   time.Time `json:"created"`
+}
+
+// A transaction exported by a watch-only node for offline signing
+type UnsignedTransaction struct {
+	ID        string
+	Intent    string
+	Timestamp time.Time
+	Package   []byte // The JSON encoded package exported for signing
+	Signed    []byte // The JSON encoded signatures once imported
+	Txid      string // Set once the signed transaction has been broadcast
+}
+
+// An entry in the audit log of state changing API calls and wallet spends
+type AuditEntry struct {
+	ID        int       `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Principal string    `json:"principal"`
+	Request   string    `json:"request"`
+	Status    int       `json:"status"`
+	Result    string    `json:"result"`
+	PrevHash  string    `json:"prevHash"`
+	Hash      string    `json:"hash"`
+}
+
 type Coupon struct {
 	Slug string
 	Code string
@@ -79,40 +177,45 @@ type Metadata struct {
 	OrderId    string
 	Thumbnail  string
 	CanBumpFee bool
+
+	// Labels given to the outputs of the transaction keyed by address
+	Labels map[string]string
 }
 
 type Purchase struct {
-	OrderId            string    `json:"orderId"`
-	Slug               string    `json:"slug"`
-	Timestamp          time.Time `json:"timestamp"`
-	Title              string    `json:"title"`
-	Thumbnail          string    `json:"thumbnail"`
-	Total              uint64    `json:"total"`
-	VendorId           string    `json:"vendorId"`
-	VendorHandle       string    `json:"vendorHandle"`
-	ShippingName       string    `json:"shippingName"`
-	ShippingAddress    string    `json:"shippingAddress"`
-	State              string    `json:"state"`
-	Read               bool      `json:"read"`
-	Moderated          bool      `json:"moderated"`
-	UnreadChatMessages int       `json:"unreadChatMessages"`
+	OrderId            string     `json:"orderId"`
+	Slug               string     `json:"slug"`
+	Timestamp          time.Time  `json:"timestamp"`
+	Title              string     `json:"title"`
+	Thumbnail          string     `json:"thumbnail"`
+	Total              uint64     `json:"total"`
+	VendorId           string     `json:"vendorId"`
+	VendorHandle       string     `json:"vendorHandle"`
+	ShippingName       string     `json:"shippingName"`
+	ShippingAddress    string     `json:"shippingAddress"`
+	State              string     `json:"state"`
+	Read               bool       `json:"read"`
+	Moderated          bool       `json:"moderated"`
+	UnreadChatMessages int        `json:"unreadChatMessages"`
+	FiatValue          *FiatValue `json:"fiatValue,omitempty"`
 }
 
 type Sale struct {
-	OrderId            string    `json:"orderId"`
-	Slug               string    `json:"slug"`
-	Timestamp          time.Time `json:"timestamp"`
-	Title              string    `json:"title"`
-	Thumbnail          string    `json:"thumbnail"`
-	Total              uint64    `json:"total"`
-	BuyerId            string    `json:"buyerId"`
-	BuyerHandle        string    `json:"buyerHandle"`
-	ShippingName       string    `json:"shippingName"`
-	ShippingAddress    string    `json:"shippingAddress"`
-	State              string    `json:"state"`
-	Read               bool      `json:"read"`
-	Moderated          bool      `json:"moderated"`
-	UnreadChatMessages int       `json:"unreadChatMessages"`
+	OrderId            string     `json:"orderId"`
+	Slug               string     `json:"slug"`
+	Timestamp          time.Time  `json:"timestamp"`
+	Title              string     `json:"title"`
+	Thumbnail          string     `json:"thumbnail"`
+	Total              uint64     `json:"total"`
+	BuyerId            string     `json:"buyerId"`
+	BuyerHandle        string     `json:"buyerHandle"`
+	ShippingName       string     `json:"shippingName"`
+	ShippingAddress    string     `json:"shippingAddress"`
+	State              string     `json:"state"`
+	Read               bool       `json:"read"`
+	Moderated          bool       `json:"moderated"`
+	UnreadChatMessages int        `json:"unreadChatMessages"`
+	FiatValue          *FiatValue `json:"fiatValue,omitempty"`
 }
 
 type Case struct {
@@ -131,3 +234,65 @@ type Case struct {
 	Read               bool      `json:"read"`
 	UnreadChatMessages int       `json:"unreadChatMessages"`
 }
+
+// The value of an amount of bitcoin in a fiat currency using the exchange rate at some point in time
+type FiatValue struct {
+	CurrencyCode  string    `json:"currencyCode"`
+	Amount        float64   `json:"amount"`
+	Rate          float64   `json:"rate"`
+	RateTimestamp time.Time `json:"rateTimestamp"`
+}
+
+// Where the ciphertext of an offline message we sent was stored
+type StoredMessage struct {
+	PointerID    peer.ID
+	Address      ma.Multiaddr
+	Timestamp    time.Time
+	Acknowledged bool
+}
+
+// Delivery status of a message in the outbox
+const (
+	OutboxPending      = "PENDING"
+	OutboxAcknowledged = "ACKNOWLEDGED"
+	OutboxDelivered    = "DELIVERED"
+)
+
+// An offline message we sent and whether the recipient has received it
+type OutboxMessage struct {
+	PointerID   string    `json:"pointerId"`
+	MessageType string    `json:"messageType"`
+	Recipient   string    `json:"recipient"`
+	OrderId     string    `json:"orderId"`
+	Location    string    `json:"location"`
+	Timestamp   time.Time `json:"timestamp"`
+	Status      string    `json:"status"`
+	Updated     time.Time `json:"updated"`
+	Message     []byte    `json:"-"`
+}
+
+// A serialized pb.RelayedMessage we're holding for another node
+type RelayMessage struct {
+	ID        int
+	Recipient string
+	Message   []byte
+	Timestamp time.Time
+}
+
+// The protocol version and features a peer reported in its last handshake
+type PeerCapabilities struct {
+	PeerID          string    `json:"peerId"`
+	ProtocolVersion int       `json:"protocolVersion"`
+	Features        []string  `json:"features"`
+	UserAgent       string    `json:"userAgent"`
+	Updated         time.Time `json:"updated"`
+}
+
+func (c PeerCapabilities) Supports(feature string) bool {
+	for _, f := range c.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package net
+
+import (
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/op/go-logging"
+)
+
+var log = logging.MustGetLogger("storage")
+
+// Unacknowledged messages are kept for as long as the republisher keeps their pointers alive
+const MessageExpiry = time.Hour * 24 * 30
+
+/* MessageCollector deletes the ciphertexts of offline messages we sent once the recipient has
+   acknowledged them or their pointers have expired. */
+type MessageCollector struct {
+	db      repo.Datastore
+	storage OfflineMessagingStorage
+}
+
+func NewMessageCollector(database repo.Datastore, storage OfflineMessagingStorage) *MessageCollector {
+	return &MessageCollector{
+		db:      database,
+		storage: storage,
+	}
+}
+
+func (c *MessageCollector) Run() {
+	tick := time.NewTicker(time.Hour * 24)
+	defer tick.Stop()
+	go c.Collect()
+	for range tick.C {
+		go c.Collect()
+	}
+}
+
+func (c *MessageCollector) Collect() {
+	messages, err := c.db.StoredMessages().GetCollectable(time.Now().Add(-MessageExpiry))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, m := range messages {
+		if err := c.Delete(m); err != nil {
+			log.Errorf("Error deleting offline message %s: %s", m.Address.String(), err.Error())
+		}
+	}
+}
+
+// Remove the ciphertext from storage. The record is kept if this fails so it's retried next run.
+func (c *MessageCollector) Delete(m repo.StoredMessage) error {
+	if err := c.storage.Delete(m.Address); err != nil {
+		return err
+	}
+	return c.db.StoredMessages().Delete(m.PointerID)
+}
@@ -0,0 +1,92 @@
+package net
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo/db"
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+type mockStorage struct {
+	messages map[string]bool
+	fail     bool
+}
+
+func (s *mockStorage) Store(peerID peer.ID, ciphertext []byte) (ma.Multiaddr, error) {
+	return nil, errors.New("Not implemented")
+}
+
+func (s *mockStorage) Delete(addr ma.Multiaddr) error {
+	if s.fail {
+		return errors.New("Storage is unreachable")
+	}
+	delete(s.messages, addr.String())
+	return nil
+}
+
+func TestMessageCollector_Collect(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "collector")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoPath)
+	os.Mkdir(path.Join(repoPath, "datastore"), os.ModePerm)
+	database, err := db.Create(repoPath, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if err := database.Config().Init("", []byte{}, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []struct {
+		pointerID string
+		addr      string
+		age       time.Duration
+		acked     bool
+		collected bool
+	}{
+		{"QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr", "/ipfs/QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub", MessageExpiry + time.Hour, false, true},
+		{"QmbwSMS35CaYKdrYBvvR9aHU9FzeWhjJ7E3jLKeR2DWrs3", "/ipfs/QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn", time.Hour, true, true},
+		{"QmNaYCcRVMHD2UAdh6XPYZELfg4ry7XZvJHu8o6b8dAgaq", "/ipfs/QmTUR3qNEpjJKrnbvBUTfq5rxQCbM4gHHd3rCjGkoNgBov", time.Hour, false, false},
+	}
+	storage := &mockStorage{messages: make(map[string]bool), fail: true}
+	for _, m := range messages {
+		pid, _ := peer.IDB58Decode(m.pointerID)
+		addr, _ := ma.NewMultiaddr(m.addr)
+		storage.messages[m.addr] = true
+		if err := database.StoredMessages().Put(pid, addr, time.Now().Add(-m.age)); err != nil {
+			t.Fatal(err)
+		}
+		if m.acked {
+			database.StoredMessages().Acknowledge(pid)
+		}
+	}
+
+	// Records are kept until the storage confirms the delete
+	collector := NewMessageCollector(database, storage)
+	collector.Collect()
+	if len(storage.messages) != 3 {
+		t.Error("Messages deleted while storage was failing")
+	}
+	storage.fail = false
+	collector.Collect()
+
+	for _, m := range messages {
+		pid, _ := peer.IDB58Decode(m.pointerID)
+		_, err := database.StoredMessages().Get(pid)
+		if m.collected && (storage.messages[m.addr] || err == nil) {
+			t.Errorf("Message %s was not collected", m.addr)
+		}
+		if !m.collected && (!storage.messages[m.addr] || err != nil) {
+			t.Errorf("Message %s was collected", m.addr)
+		}
+	}
+}
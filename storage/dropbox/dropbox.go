@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	mh "gx/ipfs/QmVGtdTZdTFaLsaj2RwdVG8jcjNNcp1DE914DKZ2kHmXHw/go-multihash"
 	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
 	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/dropbox/dropbox-sdk-go-unofficial"
 	"github.com/dropbox/dropbox-sdk-go-unofficial/files"
@@ -64,3 +68,33 @@ func (s *DropBoxStorage) Store(peerID peer.ID, ciphertext []byte) (ma.Multiaddr,
 	}
 	return addr, nil
 }
+
+func (s *DropBoxStorage) Delete(addr ma.Multiaddr) error {
+	enc, err := addr.ValueForProtocol(ma.P_IPFS)
+	if err != nil {
+		return err
+	}
+	m, err := mh.FromB58String(enc)
+	if err != nil {
+		return err
+	}
+	d, err := mh.Decode(m)
+	if err != nil {
+		return err
+	}
+
+	// Shared links end with the name of the file which is the hash of the ciphertext
+	u, err := url.Parse(string(d.Digest))
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(u.Host, "dropbox.com") {
+		return errors.New("Message is not stored in Dropbox")
+	}
+	api := dropbox.Client(s.apiToken, dropbox.Options{Verbose: true})
+	_, err = api.Delete(files.NewDeleteArg("/" + path.Base(u.Path)))
+	if err != nil && !strings.Contains(err.Error(), "not_found") {
+		return err
+	}
+	return nil
+}
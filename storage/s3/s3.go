@@ -0,0 +1,220 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"golang.org/x/net/proxy"
+	mh "gx/ipfs/QmVGtdTZdTFaLsaj2RwdVG8jcjNNcp1DE914DKZ2kHmXHw/go-multihash"
+	ma "gx/ipfs/QmcyqRMCAXVtYPS4DiBrA7sezL9rRGfW8Ctx7cywL4TXJj/go-multiaddr"
+	peer "gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+/* S3Storage stores offline messages in a bucket of an S3 compatible object store such as AWS or MinIO.
+   The recipient downloads the message over HTTPS so the bucket must allow anonymous reads of its
+   objects. Messages are deleted from the bucket once the recipient acknowledges them. */
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3Storage(cfg *repo.S3Config, dialer proxy.Dialer) (*S3Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("S3 endpoint and bucket must be set in the config file")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme != "https" && endpoint.Scheme != "http" {
+		return nil, errors.New("S3 endpoint must be an http or https URL")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	dial := net.Dial
+	if dialer != nil {
+		dial = dialer.Dial
+	}
+	s := &S3Storage{
+		endpoint:  endpoint,
+		region:    region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		pathStyle: cfg.PathStyle,
+		client:    &http.Client{Transport: &http.Transport{Dial: dial}, Timeout: time.Minute},
+		now:       time.Now,
+	}
+
+	// Make sure the bucket exists and the credentials are good before we rely on it
+	resp, err := s.do("HEAD", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("S3 bucket %s is not accessible: %s", cfg.Bucket, resp.Status)
+	}
+	return s, nil
+}
+
+func (s *S3Storage) Store(peerID peer.ID, ciphertext []byte) (ma.Multiaddr, error) {
+	hash := sha256.Sum256(ciphertext)
+	key := hex.EncodeToString(hash[:])
+
+	resp, err := s.do("PUT", key, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("S3 upload failed: %s", resp.Status)
+	}
+
+	// The download URL is carried in the digest of a multihash like the Dropbox storage does
+	b, err := mh.Encode([]byte(s.objectURL(key)), mh.SHA1)
+	if err != nil {
+		return nil, err
+	}
+	m, err := mh.Cast(b)
+	if err != nil {
+		return nil, err
+	}
+	return ma.NewMultiaddr("/ipfs/" + m.B58String() + "/https/")
+}
+
+// Delete removes a message stored by Store given the address it returned
+func (s *S3Storage) Delete(addr ma.Multiaddr) error {
+	enc, err := addr.ValueForProtocol(ma.P_IPFS)
+	if err != nil {
+		return err
+	}
+	m, err := mh.FromB58String(enc)
+	if err != nil {
+		return err
+	}
+	d, err := mh.Decode(m)
+	if err != nil {
+		return err
+	}
+	prefix := s.objectURL("")
+	if !strings.HasPrefix(string(d.Digest), prefix) {
+		return errors.New("Message is not stored in this bucket")
+	}
+	resp, err := s.do("DELETE", strings.TrimPrefix(string(d.Digest), prefix), nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("S3 delete failed: %s", resp.Status)
+	}
+	return nil
+}
+
+// The URL of the object with the given key, or of the bucket if the key is empty
+func (s *S3Storage) objectURL(key string) string {
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	return u.String()
+}
+
+func (s *S3Storage) do(method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		hash := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(hash[:])
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	s.sign(req, payloadHash)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
+
+// Add an AWS signature version 4 authorization header covering the host and all the request's headers
+func (s *S3Storage) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	var names []string
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders string
+	for _, k := range names {
+		canonicalHeaders += k + ":" + headers[k] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
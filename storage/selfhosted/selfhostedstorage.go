@@ -15,6 +15,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -66,3 +67,25 @@ func (s *SelfHostedStorage) Store(peerID peer.ID, ciphertext []byte) (ma.Multiad
 	}
 	return maAddr, nil
 }
+
+func (s *SelfHostedStorage) Delete(addr ma.Multiaddr) error {
+	hash, err := addr.ValueForProtocol(ma.P_IPFS)
+	if err != nil {
+		return err
+	}
+	// The outbox file is named after the hash of the ciphertext rather than the IPFS hash
+	ciphertext, err := ipfs.Cat(s.context, hash)
+	if err != nil {
+		return err
+	}
+	b := sha256.Sum256(ciphertext)
+	err = os.Remove(path.Join(s.repoPath, "outbox", hex.EncodeToString(b[:])))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	err = ipfs.UnPinDir(s.context, hash)
+	if err != nil && !strings.Contains(err.Error(), "not pinned") {
+		return err
+	}
+	return nil
+}
@@ -21,4 +21,9 @@ type OfflineMessagingStorage interface {
 
 	   Note all messages are encrypted before passed in here. */
 	Store(peerID peer.ID, ciphertext []byte) (ma.Multiaddr, error)
+
+	/* Remove a message given the address returned by Store. This is called once
+	   the recipient acknowledges the message or it expires so implementations
+	   should not return an error if the message is already gone. */
+	Delete(addr ma.Multiaddr) error
 }
@@ -0,0 +1,254 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	lis "github.com/OpenBazaar/openbazaar-go/bitcoin/listeners"
+	"github.com/OpenBazaar/openbazaar-go/core"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/net"
+	"github.com/OpenBazaar/openbazaar-go/net/service"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/openbazaar-go/repo/db"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ipfs/go-ipfs/routing/dht"
+	"github.com/tyler-smith/go-bip39"
+	mocknet "gx/ipfs/QmQA5mdxru8Bh6dpC9PJfSkumqnmHgJX7knxSgBo5Lpime/go-libp2p/p2p/net/mock"
+	"gx/ipfs/QmdS9KpbDyPrieswibZhkod1oXqRwZJrUPzxCofAMWpFGq/go-libp2p-peer"
+)
+
+// NetworkNode is an OpenBazaar node of a test network
+type NetworkNode struct {
+	*core.OpenBazaarNode
+	Repository *Repository
+	MockWallet *MockWallet
+}
+
+/* Network is a set of OpenBazaar nodes connected over an in-memory libp2p network. Their wallets
+   share a mock chain so orders can be paid and settled without bitcoind. */
+type Network struct {
+	Chain *MockChain
+	Nodes []*NetworkNode
+	mn    mocknet.Mocknet
+}
+
+// NewNetwork creates the given number of nodes, connects them to each other and publishes their profiles
+func NewNetwork(size int) (*Network, error) {
+	nw := &Network{
+		Chain: NewMockChain(&chaincfg.TestNet3Params),
+		mn:    mocknet.New(context.Background()),
+	}
+	for i := 0; i < size; i++ {
+		n, err := nw.newNode(i)
+		if err != nil {
+			nw.Close()
+			return nil, err
+		}
+		nw.Nodes = append(nw.Nodes, n)
+	}
+	if err := nw.mn.LinkAll(); err != nil {
+		nw.Close()
+		return nil, err
+	}
+	if err := nw.mn.ConnectAllButSelf(); err != nil {
+		nw.Close()
+		return nil, err
+	}
+	if err := nw.waitForRouting(); err != nil {
+		nw.Close()
+		return nil, err
+	}
+	for i, n := range nw.Nodes {
+		if err := n.UpdateProfile(&pb.Profile{Name: fmt.Sprintf("Node %d", i)}); err != nil {
+			nw.Close()
+			return nil, err
+		}
+		if err := n.Publish(); err != nil {
+			nw.Close()
+			return nil, err
+		}
+	}
+	return nw, nil
+}
+
+func (nw *Network) newNode(i int) (*NetworkNode, error) {
+	repoPath, err := ioutil.TempDir("", "openbazaar-network")
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository{
+		Path:     repoPath,
+		Password: fmt.Sprintf("%s %d", GetPassword(), i),
+	}
+	r.DB, err = db.Create(r.Path, "", true)
+	if err != nil {
+		return nil, err
+	}
+	err = repo.DoInit(r.Path, 4096, true, "", r.Password, time.Now(), r.DB.Config().Init)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same identity DoInit derives from the mnemonic
+	identityKey, err := ipfs.IdentityKeyFromSeed(bip39.NewSeed(r.Password, "Secret Passphrase"), 4096)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := ipfs.IdentityFromKey(identityKey)
+	if err != nil {
+		return nil, err
+	}
+	ipfsNode, ctx, err := ipfs.NewMockNetworkNode(nw.mn, identity, r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := NewMockWallet(nw.Chain, r.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &core.OpenBazaarNode{
+		Context:    ctx,
+		IpfsNode:   ipfsNode,
+		RepoPath:   r.Path,
+		Datastore:  r.DB,
+		Broadcast:  make(chan interface{}),
+		Wallet:     wallet,
+		UserAgent:  core.USERAGENT,
+		BanManager: net.NewBanManager([]peer.ID{}),
+	}
+	node.Service = service.New(node, ctx, r.DB)
+
+	// Nobody is listening on the websocket
+	go func() {
+		for range node.Broadcast {
+		}
+	}()
+
+	TL := lis.NewTransactionListener(node.Datastore, node.Broadcast, node.Wallet)
+	WL := lis.NewWalletListener(node.Datastore, node.Broadcast)
+	wallet.AddTransactionListener(TL.OnTransactionReceived)
+	wallet.AddTransactionListener(WL.OnTransactionReceived)
+
+	return &NetworkNode{
+		OpenBazaarNode: node,
+		Repository:     r,
+		MockWallet:     wallet,
+	}, nil
+}
+
+// The DHT adds peers to its routing table in the background once they are connected
+func (nw *Network) waitForRouting() error {
+	for _, n := range nw.Nodes {
+		d, ok := n.IpfsNode.Routing.(*dht.IpfsDHT)
+		if !ok {
+			return errors.New("Node is not using the DHT")
+		}
+		for _, other := range nw.Nodes {
+			id := other.IpfsNode.Identity
+			if id == n.IpfsNode.Identity {
+				continue
+			}
+			for i := 0; d.FindLocal(id).ID != id; i++ {
+				if i == 100 {
+					return fmt.Errorf("%s did not add %s to its routing table", n.IpfsNode.Identity.Pretty(), id.Pretty())
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	}
+	return nil
+}
+
+// Close shuts down the nodes and removes their repositories
+func (nw *Network) Close() {
+	for _, n := range nw.Nodes {
+		n.IpfsNode.Close()
+		n.Datastore.Close()
+		n.Repository.RemoveRepo()
+	}
+}
+
+// Publish adds the node's root directory to IPFS and waits for it to be published at the node's peer ID
+func (n *NetworkNode) Publish() error {
+	rootHash, err := ipfs.AddDirectory(n.Context, path.Join(n.RepoPath, "root"))
+	if err != nil {
+		return err
+	}
+	if _, err := ipfs.Publish(n.Context, rootHash); err != nil {
+		return err
+	}
+	n.RootHash = rootHash
+	return nil
+}
+
+// SetModerator turns the node into a moderator charging the given percentage of disputed funds
+func (n *NetworkNode) SetModerator(percentage float32) error {
+	profile, err := n.GetProfile()
+	if err != nil {
+		return err
+	}
+	profile.Moderator = true
+	profile.ModeratorInfo = &pb.Moderator{
+		Description: "Test moderator",
+		Fee: &pb.Moderator_Fee{
+			FeeType:    pb.Moderator_Fee_PERCENTAGE,
+			Percentage: percentage,
+		},
+	}
+	if err := n.UpdateProfile(&profile); err != nil {
+		return err
+	}
+	return n.Publish()
+}
+
+// AddListing signs and saves the listing like the listings API and returns its IPFS hash
+func (n *NetworkNode) AddListing(listing *pb.Listing) (string, error) {
+	if err := n.SetListingInventory(listing); err != nil {
+		return "", err
+	}
+	signedListing, err := n.SignListing(listing)
+	if err != nil {
+		return "", err
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(signedListing)
+	if err != nil {
+		return "", err
+	}
+	listingPath := path.Join(n.RepoPath, "root", "listings", signedListing.Listing.Slug+".json")
+	if err := ioutil.WriteFile(listingPath, []byte(out), 0644); err != nil {
+		return "", err
+	}
+	if err := n.UpdateListingIndex(signedListing); err != nil {
+		return "", err
+	}
+	hash, err := ipfs.AddFile(n.Context, listingPath)
+	if err != nil {
+		return "", err
+	}
+	return hash, n.Publish()
+}
+
+// Fund sends coins to the node's wallet and confirms them
+func (n *NetworkNode) Fund(value int64) error {
+	if _, err := n.MockWallet.chain.Fund(n.Wallet.CurrentAddress(spvwallet.EXTERNAL), value); err != nil {
+		return err
+	}
+	n.MockWallet.chain.Mine()
+	return nil
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/core"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+)
+
+const listingPrice = 1000000
+
+func newTestListing(slug string, moderators ...string) *pb.Listing {
+	expiry, _ := ptypes.TimestampProto(time.Now().Add(time.Hour * 24 * 30))
+	return &pb.Listing{
+		Slug: slug,
+		Metadata: &pb.Listing_Metadata{
+			ContractType:    pb.Listing_Metadata_DIGITAL_GOOD,
+			Format:          pb.Listing_Metadata_FIXED_PRICE,
+			Expiry:          expiry,
+			PricingCurrency: "BTC",
+		},
+		Item: &pb.Listing_Item{
+			Title: "Ron Swanson Tshirt",
+			Price: listingPrice,
+			Images: []*pb.Listing_Item_Image{{
+				Filename: "swanson.jpg",
+				Tiny:     "QmNedYJ6WmLhacAL2ozxb4k33Gxd9wmKB7HyoxZCwXid1e",
+				Small:    "QmamudHQGtztShX7Nc9HcczehdpGGWpFBWu2JvKWcpELxr",
+				Medium:   "QmbyUYWZEBRFw9uxVThS4FYMwkdhWfGAsYwppBKTF6L968",
+				Large:    "QmanB2z2s6jig7SXxDtSTdZpnu9fZN9eNVQtqDeUroE5w4",
+				Original: "QmecpJrN9RJ7smyYByQdZUy5mF6aapgCfKLKRmDtycv9aG",
+			}},
+		},
+		Moderators: moderators,
+	}
+}
+
+func newTestNetwork(t *testing.T, size int) *Network {
+	nw, err := NewNetwork(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nw
+}
+
+func purchase(t *testing.T, buyer *NetworkNode, listingHash, moderator string) (orderId, paymentAddress string, amount uint64) {
+	var data core.PurchaseData
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"moderator": %q, "items": [{"listingHash": %q, "quantity": 1}]}`, moderator, listingHash)), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderId, paymentAddress, amount, online, err := buyer.Purchase(&data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !online {
+		t.Fatal("Vendor did not respond to the order")
+	}
+	return orderId, paymentAddress, amount
+}
+
+func pay(t *testing.T, buyer *NetworkNode, paymentAddress string, amount uint64) {
+	addr, err := buyer.Wallet.DecodeAddress(paymentAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := buyer.Wallet.Spend(int64(amount), addr, spvwallet.NORMAL); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Messages between the nodes are handled in the background so poll for the expected state
+func waitForState(t *testing.T, name string, state pb.OrderState, get func() (pb.OrderState, error)) {
+	var current pb.OrderState
+	for i := 0; i < 100; i++ {
+		s, err := get()
+		if err == nil && s == state {
+			return
+		}
+		current = s
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("%s is in state %s, expected %s", name, current, state)
+}
+
+func waitForSale(t *testing.T, n *NetworkNode, orderId string, state pb.OrderState) {
+	waitForState(t, "Sale", state, func() (pb.OrderState, error) {
+		_, s, _, _, _, err := n.Datastore.Sales().GetByOrderId(orderId)
+		return s, err
+	})
+}
+
+func waitForPurchase(t *testing.T, n *NetworkNode, orderId string, state pb.OrderState) {
+	waitForState(t, "Purchase", state, func() (pb.OrderState, error) {
+		_, s, _, _, _, err := n.Datastore.Purchases().GetByOrderId(orderId)
+		return s, err
+	})
+}
+
+func TestPurchaseThroughCompletion(t *testing.T) {
+	nw := newTestNetwork(t, 2)
+	defer nw.Close()
+	vendor, buyer := nw.Nodes[0], nw.Nodes[1]
+
+	listingHash, err := vendor.AddListing(newTestListing("ron-swanson-tshirt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := buyer.Fund(5000000); err != nil {
+		t.Fatal(err)
+	}
+
+	orderId, paymentAddress, amount := purchase(t, buyer, listingHash, "")
+	if amount != listingPrice {
+		t.Errorf("Expected to pay %d, got %d", listingPrice, amount)
+	}
+	pay(t, buyer, paymentAddress, amount)
+	waitForSale(t, vendor, orderId, pb.OrderState_AWAITING_FULFILLMENT)
+	waitForPurchase(t, buyer, orderId, pb.OrderState_AWAITING_FULFILLMENT)
+	nw.Chain.Mine()
+	if confirmed, _ := vendor.Wallet.Balance(); confirmed != listingPrice {
+		t.Errorf("Vendor's balance is %d, expected %d", confirmed, listingPrice)
+	}
+
+	contract, _, _, records, _, err := vendor.Datastore.Sales().GetByOrderId(orderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vendor.FulfillOrder(&pb.OrderFulfillment{OrderId: orderId}, contract, records); err != nil {
+		t.Fatal(err)
+	}
+	waitForPurchase(t, buyer, orderId, pb.OrderState_FULFILLED)
+
+	contract, _, _, records, _, err = buyer.Datastore.Purchases().GetByOrderId(orderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ratings := &core.OrderRatings{
+		OrderId: orderId,
+		Ratings: []core.RatingData{{
+			Slug:            "ron-swanson-tshirt",
+			Overall:         5,
+			Quality:         5,
+			Description:     5,
+			DeliverySpeed:   5,
+			CustomerService: 5,
+			Review:          "Great shirt",
+		}},
+	}
+	if err := buyer.CompleteOrder(context.Background(), ratings, contract, records); err != nil {
+		t.Fatal(err)
+	}
+	waitForPurchase(t, buyer, orderId, pb.OrderState_COMPLETED)
+	waitForSale(t, vendor, orderId, pb.OrderState_COMPLETED)
+}
+
+func TestDisputeThroughRelease(t *testing.T) {
+	nw := newTestNetwork(t, 3)
+	defer nw.Close()
+	vendor, buyer, moderator := nw.Nodes[0], nw.Nodes[1], nw.Nodes[2]
+
+	if err := moderator.SetModerator(5); err != nil {
+		t.Fatal(err)
+	}
+	moderatorID := moderator.IpfsNode.Identity.Pretty()
+	listingHash, err := vendor.AddListing(newTestListing("ron-swanson-tshirt", moderatorID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := buyer.Fund(5000000); err != nil {
+		t.Fatal(err)
+	}
+
+	orderId, paymentAddress, amount := purchase(t, buyer, listingHash, moderatorID)
+	pay(t, buyer, paymentAddress, amount)
+	nw.Chain.Mine()
+	waitForSale(t, vendor, orderId, pb.OrderState_AWAITING_FULFILLMENT)
+	waitForPurchase(t, buyer, orderId, pb.OrderState_AWAITING_FULFILLMENT)
+	buyerBalance, _ := buyer.Wallet.Balance()
+
+	contract, _, _, records, _, err := buyer.Datastore.Purchases().GetByOrderId(orderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := buyer.OpenDispute(orderId, contract, records, "The vendor never delivered"); err != nil {
+		t.Fatal(err)
+	}
+	waitForPurchase(t, buyer, orderId, pb.OrderState_DISPUTED)
+	waitForSale(t, vendor, orderId, pb.OrderState_DISPUTED)
+	waitForState(t, "Case", pb.OrderState_DISPUTED, func() (pb.OrderState, error) {
+		_, _, _, _, _, _, s, err := moderator.Datastore.Cases().GetPayoutDetails(orderId)
+		return s, err
+	})
+
+	if err := moderator.CloseDispute(orderId, 100, 0, "The buyer gets a full refund"); err != nil {
+		t.Fatal(err)
+	}
+	waitForPurchase(t, buyer, orderId, pb.OrderState_DECIDED)
+	waitForSale(t, vendor, orderId, pb.OrderState_DECIDED)
+
+	contract, _, _, records, _, err = buyer.Datastore.Purchases().GetByOrderId(orderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := buyer.ReleaseFunds(context.Background(), contract, records); err != nil {
+		t.Fatal(err)
+	}
+	waitForPurchase(t, buyer, orderId, pb.OrderState_RESOLVED)
+	waitForSale(t, vendor, orderId, pb.OrderState_RESOLVED)
+
+	// The transaction fee is shared by the refund and the moderator's 5% fee
+	nw.Chain.Mine()
+	refunded, _ := buyer.Wallet.Balance()
+	refunded -= buyerBalance
+	if refunded < int64(amount)*94/100 || refunded >= int64(amount)*95/100 {
+		t.Errorf("Buyer was refunded %d of %d", refunded, amount)
+	}
+	if fee, _ := moderator.Wallet.Balance(); fee < int64(amount)*49/1000 || fee >= int64(amount)*5/100 {
+		t.Errorf("Moderator received %d of %d", fee, amount)
+	}
+}
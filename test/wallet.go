@@ -0,0 +1,773 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/btcsuite/btcutil/txsort"
+	"github.com/btcsuite/btcwallet/wallet/txauthor"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Fees paid by the mock wallets in satoshis per byte
+var mockFees = map[spvwallet.FeeLevel]uint64{
+	spvwallet.PRIOIRTY: 30,
+	spvwallet.NORMAL:   20,
+	spvwallet.ECONOMIC: 10,
+	spvwallet.FEE_BUMP: 60,
+}
+
+type mockChainTx struct {
+	tx     *wire.MsgTx
+	height int32
+}
+
+/* MockChain is an in-memory blockchain shared by the mock wallets of a test network. Transactions
+   are checked against the unspent outputs and their scripts are executed before they are accepted.
+   They stay unconfirmed until Mine is called. */
+type MockChain struct {
+	params  *chaincfg.Params
+	height  uint32
+	txs     map[chainhash.Hash]*mockChainTx
+	order   []chainhash.Hash
+	utxos   map[wire.OutPoint]*wire.TxOut
+	wallets []*MockWallet
+	sync.Mutex
+}
+
+// NewMockChain creates an empty chain with the given network parameters
+func NewMockChain(params *chaincfg.Params) *MockChain {
+	return &MockChain{
+		params: params,
+		txs:    make(map[chainhash.Hash]*mockChainTx),
+		utxos:  make(map[wire.OutPoint]*wire.TxOut),
+	}
+}
+
+// Height returns the height of the best block
+func (c *MockChain) Height() uint32 {
+	c.Lock()
+	defer c.Unlock()
+	return c.height
+}
+
+// Broadcast validates the transaction, adds it to the mempool and notifies the wallets
+func (c *MockChain) Broadcast(tx *wire.MsgTx) error {
+	c.Lock()
+	txid := tx.TxHash()
+	if _, ok := c.txs[txid]; ok {
+		c.Unlock()
+		return nil
+	}
+	if err := c.validate(tx); err != nil {
+		c.Unlock()
+		return err
+	}
+	c.add(tx)
+	c.Unlock()
+	c.notify(tx, 0)
+	return nil
+}
+
+// Fund sends a new coin of the given value to the address as if it came from an exchange
+func (c *MockChain) Fund(addr btc.Address, value int64) (*chainhash.Hash, error) {
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	c.Lock()
+	// Each coinbase needs a distinct signature script so the transactions have different IDs
+	coinbase := wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte(fmt.Sprintf("fund %d", len(c.order))))
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(coinbase)
+	tx.AddTxOut(wire.NewTxOut(value, script))
+	c.add(tx)
+	c.Unlock()
+	c.notify(tx, 0)
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+// Mine confirms every transaction in the mempool in a new block
+func (c *MockChain) Mine() {
+	c.Lock()
+	c.height++
+	height := int32(c.height)
+	var mined []*wire.MsgTx
+	for _, txid := range c.order {
+		t := c.txs[txid]
+		if t.height == 0 {
+			t.height = height
+			mined = append(mined, t.tx)
+		}
+	}
+	c.Unlock()
+	for _, tx := range mined {
+		c.notify(tx, height)
+	}
+}
+
+// Return the transaction and its height. The height is zero while it is unconfirmed.
+func (c *MockChain) GetTransaction(txid chainhash.Hash) (*wire.MsgTx, int32, error) {
+	c.Lock()
+	defer c.Unlock()
+	t, ok := c.txs[txid]
+	if !ok {
+		return nil, 0, errors.New("Transaction not found")
+	}
+	return t.tx, t.height, nil
+}
+
+func (c *MockChain) validate(tx *wire.MsgTx) error {
+	if len(tx.TxIn) == 0 || len(tx.TxOut) == 0 {
+		return errors.New("Transaction must have inputs and outputs")
+	}
+	var in, out int64
+	seen := make(map[wire.OutPoint]bool)
+	for i, txIn := range tx.TxIn {
+		prev, ok := c.utxos[txIn.PreviousOutPoint]
+		if !ok || seen[txIn.PreviousOutPoint] {
+			return fmt.Errorf("Input %d spends a missing or spent output", i)
+		}
+		seen[txIn.PreviousOutPoint] = true
+		engine, err := txscript.NewEngine(prev.PkScript, tx, i, txscript.StandardVerifyFlags, nil)
+		if err != nil {
+			return err
+		}
+		if err := engine.Execute(); err != nil {
+			return fmt.Errorf("Input %d failed to verify: %s", i, err)
+		}
+		in += prev.Value
+	}
+	for _, txOut := range tx.TxOut {
+		if txOut.Value < 0 {
+			return errors.New("Negative output value")
+		}
+		out += txOut.Value
+	}
+	if out > in {
+		return errors.New("Transaction spends more than its inputs")
+	}
+	return nil
+}
+
+func (c *MockChain) add(tx *wire.MsgTx) {
+	txid := tx.TxHash()
+	for _, txIn := range tx.TxIn {
+		delete(c.utxos, txIn.PreviousOutPoint)
+	}
+	for i, txOut := range tx.TxOut {
+		c.utxos[*wire.NewOutPoint(&txid, uint32(i))] = txOut
+	}
+	c.txs[txid] = &mockChainTx{tx: tx}
+	c.order = append(c.order, txid)
+}
+
+func (c *MockChain) notify(tx *wire.MsgTx, height int32) {
+	c.Lock()
+	wallets := make([]*MockWallet, len(c.wallets))
+	copy(wallets, c.wallets)
+	c.Unlock()
+	for _, w := range wallets {
+		w.ingest(tx, height)
+	}
+}
+
+func (c *MockChain) addWallet(w *MockWallet) {
+	c.Lock()
+	defer c.Unlock()
+	c.wallets = append(c.wallets, w)
+}
+
+// MockWallet is a bitcoin.BitcoinWallet holding its coins on a MockChain
+type MockWallet struct {
+	chain     *MockChain
+	params    *chaincfg.Params
+	masterKey *hd.ExtendedKey
+	masterPub *hd.ExtendedKey
+	chains    map[spvwallet.KeyPurpose]*hd.ExtendedKey
+	indexes   map[spvwallet.KeyPurpose]uint32
+	keys      map[string]*hd.ExtendedKey
+	watched   map[string]bool
+	utxos     map[wire.OutPoint]spvwallet.Utxo
+	spent     map[wire.OutPoint]bool
+	txns      map[chainhash.Hash]*spvwallet.Txn
+	listeners []func(spvwallet.TransactionCallback)
+	sync.Mutex
+}
+
+// NewMockWallet creates a wallet on the chain using the keys derived from the mnemonic
+func NewMockWallet(chain *MockChain, mnemonic string) (*MockWallet, error) {
+	masterKey, err := hd.NewMaster(bip39.NewSeed(mnemonic, ""), chain.params)
+	if err != nil {
+		return nil, err
+	}
+	masterPub, err := masterKey.Neuter()
+	if err != nil {
+		return nil, err
+	}
+	internal, external, err := spvwallet.Bip44Derivation(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	w := &MockWallet{
+		chain:     chain,
+		params:    chain.params,
+		masterKey: masterKey,
+		masterPub: masterPub,
+		chains:    map[spvwallet.KeyPurpose]*hd.ExtendedKey{spvwallet.EXTERNAL: external, spvwallet.INTERNAL: internal},
+		indexes:   make(map[spvwallet.KeyPurpose]uint32),
+		keys:      make(map[string]*hd.ExtendedKey),
+		watched:   make(map[string]bool),
+		utxos:     make(map[wire.OutPoint]spvwallet.Utxo),
+		spent:     make(map[wire.OutPoint]bool),
+		txns:      make(map[chainhash.Hash]*spvwallet.Txn),
+	}
+	chain.addWallet(w)
+	return w, nil
+}
+
+func (w *MockWallet) Start() {}
+
+func (w *MockWallet) Params() *chaincfg.Params {
+	return w.params
+}
+
+func (w *MockWallet) CurrencyCode() string {
+	if w.params.Name == chaincfg.MainNetParams.Name {
+		return "btc"
+	}
+	return "tbtc"
+}
+
+func (w *MockWallet) IsDust(amount int64) bool {
+	return txrules.IsDustAmount(btc.Amount(amount), 25, txrules.DefaultRelayFeePerKb)
+}
+
+func (w *MockWallet) MasterPrivateKey() *hd.ExtendedKey {
+	return w.masterKey
+}
+
+func (w *MockWallet) MasterPublicKey() *hd.ExtendedKey {
+	return w.masterPub
+}
+
+func (w *MockWallet) CurrentAddress(purpose spvwallet.KeyPurpose) btc.Address {
+	w.Lock()
+	defer w.Unlock()
+	return w.address(purpose, w.indexes[purpose])
+}
+
+func (w *MockWallet) NewAddress(purpose spvwallet.KeyPurpose) btc.Address {
+	w.Lock()
+	defer w.Unlock()
+	w.indexes[purpose]++
+	return w.address(purpose, w.indexes[purpose])
+}
+
+// Derive the address and remember its key so coins sent to it are found
+func (w *MockWallet) address(purpose spvwallet.KeyPurpose, index uint32) btc.Address {
+	key, err := w.chains[purpose].Child(index)
+	if err != nil {
+		return nil
+	}
+	addr, err := key.Address(w.params)
+	if err != nil {
+		return nil
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil
+	}
+	w.keys[string(script)] = key
+	return addr
+}
+
+func (w *MockWallet) DecodeAddress(addr string) (btc.Address, error) {
+	return btc.DecodeAddress(addr, w.params)
+}
+
+func (w *MockWallet) ScriptToAddress(script []byte) (btc.Address, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(script, w.params)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("unknown script")
+	}
+	return addrs[0], nil
+}
+
+func (w *MockWallet) AddressToScript(addr btc.Address) ([]byte, error) {
+	return txscript.PayToAddrScript(addr)
+}
+
+func (w *MockWallet) HasKey(addr btc.Address) bool {
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return false
+	}
+	w.Lock()
+	defer w.Unlock()
+	_, ok := w.keys[string(script)]
+	return ok
+}
+
+func (w *MockWallet) Balance() (confirmed, unconfirmed int64) {
+	w.Lock()
+	defer w.Unlock()
+	for _, u := range w.utxos {
+		if u.WatchOnly {
+			continue
+		}
+		if u.AtHeight > 0 {
+			confirmed += u.Value
+		} else {
+			unconfirmed += u.Value
+		}
+	}
+	return confirmed, unconfirmed
+}
+
+func (w *MockWallet) ListUnspent() ([]spvwallet.Utxo, error) {
+	w.Lock()
+	defer w.Unlock()
+	var ret []spvwallet.Utxo
+	for _, u := range w.utxos {
+		if !u.WatchOnly {
+			ret = append(ret, u)
+		}
+	}
+	return ret, nil
+}
+
+func (w *MockWallet) Transactions() ([]spvwallet.Txn, error) {
+	w.Lock()
+	defer w.Unlock()
+	var ret []spvwallet.Txn
+	for _, txn := range w.txns {
+		if !txn.WatchOnly {
+			ret = append(ret, *txn)
+		}
+	}
+	return ret, nil
+}
+
+func (w *MockWallet) GetTransaction(txid chainhash.Hash) (spvwallet.Txn, error) {
+	w.Lock()
+	defer w.Unlock()
+	txn, ok := w.txns[txid]
+	if !ok {
+		return spvwallet.Txn{}, errors.New("Transaction not found")
+	}
+	return *txn, nil
+}
+
+func (w *MockWallet) ChainTip() uint32 {
+	return w.chain.Height()
+}
+
+func (w *MockWallet) GetFeePerByte(feeLevel spvwallet.FeeLevel) uint64 {
+	return mockFees[feeLevel]
+}
+
+func (w *MockWallet) Spend(amount int64, addr btc.Address, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	return w.SpendCoins([]spvwallet.TransactionOutput{{ScriptPubKey: script, Value: amount}}, nil, feeLevel)
+}
+
+func (w *MockWallet) SpendMany(outs []spvwallet.TransactionOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	return w.SpendCoins(outs, nil, feeLevel)
+}
+
+func (w *MockWallet) SpendCoins(outs []spvwallet.TransactionOutput, allow func(op wire.OutPoint) bool, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	if len(outs) == 0 {
+		return nil, errors.New("No outputs to spend to")
+	}
+	var outputs []*wire.TxOut
+	for _, out := range outs {
+		if txrules.IsDustAmount(btc.Amount(out.Value), len(out.ScriptPubKey), txrules.DefaultRelayFeePerKb) {
+			return nil, errors.New("Amount is below dust threshold")
+		}
+		outputs = append(outputs, wire.NewTxOut(out.Value, out.ScriptPubKey))
+	}
+
+	coins, err := w.ListUnspent()
+	if err != nil {
+		return nil, err
+	}
+	prevScripts := make(map[wire.OutPoint][]byte)
+	inputSource := func(target btc.Amount) (total btc.Amount, inputs []*wire.TxIn, scripts [][]byte, err error) {
+		for _, u := range coins {
+			if allow != nil && !allow(u.Op) {
+				continue
+			}
+			if total >= target {
+				break
+			}
+			op := u.Op
+			total += btc.Amount(u.Value)
+			inputs = append(inputs, wire.NewTxIn(&op, nil))
+			scripts = append(scripts, u.ScriptPubkey)
+			prevScripts[op] = u.ScriptPubkey
+		}
+		if total < target {
+			return total, inputs, scripts, errors.New("insuffient funds")
+		}
+		return total, inputs, scripts, nil
+	}
+	changeSource := func() ([]byte, error) {
+		return txscript.PayToAddrScript(w.CurrentAddress(spvwallet.INTERNAL))
+	}
+	feePerKB := btc.Amount(w.GetFeePerByte(feeLevel) * 1000)
+	authored, err := txauthor.NewUnsignedTransaction(outputs, feePerKB, inputSource, changeSource)
+	if err != nil {
+		return nil, err
+	}
+	tx := authored.Tx
+	txsort.InPlaceSort(tx)
+	for i, txIn := range tx.TxIn {
+		if err := w.sign(tx, i, prevScripts[txIn.PreviousOutPoint], nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Broadcast(tx); err != nil {
+		return nil, err
+	}
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+func (w *MockWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
+	txn, err := w.GetTransaction(txid)
+	if err != nil {
+		return nil, err
+	}
+	if txn.Height > 0 {
+		return nil, spvwallet.BumpFeeAlreadyConfirmedError
+	}
+	// Child pays for parent using our unconfirmed output of the transaction
+	utxos, _ := w.ListUnspent()
+	for _, u := range utxos {
+		if u.Op.Hash.IsEqual(&txid) && u.AtHeight == 0 {
+			w.Lock()
+			key := w.keys[string(u.ScriptPubkey)]
+			w.Unlock()
+			if key == nil {
+				continue
+			}
+			return w.SweepAddress([]spvwallet.Utxo{u}, nil, key, nil, spvwallet.FEE_BUMP)
+		}
+	}
+	return nil, spvwallet.BumpFeeNotFoundError
+}
+
+func (w *MockWallet) EstimateFee(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, feePerByte uint64) uint64 {
+	var txOuts []*wire.TxOut
+	for _, out := range outs {
+		txOuts = append(txOuts, wire.NewTxOut(out.Value, out.ScriptPubKey))
+	}
+	return uint64(spvwallet.EstimateSerializeSize(len(ins), txOuts, false)) * feePerByte
+}
+
+func (w *MockWallet) SweepAddress(utxos []spvwallet.Utxo, address *btc.Address, key *hd.ExtendedKey, redeemScript *[]byte, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	var addr btc.Address
+	if address != nil {
+		addr = *address
+	} else {
+		addr = w.CurrentAddress(spvwallet.INTERNAL)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	prevScripts := make(map[wire.OutPoint][]byte)
+	var val int64
+	for _, u := range utxos {
+		op := u.Op
+		val += u.Value
+		tx.AddTxIn(wire.NewTxIn(&op, nil))
+		prevScripts[op] = u.ScriptPubkey
+	}
+	out := wire.NewTxOut(val, script)
+	fee := int64(spvwallet.EstimateSerializeSize(len(utxos), []*wire.TxOut{out}, false)) * int64(w.GetFeePerByte(feeLevel))
+	out.Value = val - fee
+	if out.Value < 0 {
+		out.Value = 0
+	}
+	tx.AddTxOut(out)
+	txsort.InPlaceSort(tx)
+
+	var rs []byte
+	if redeemScript != nil {
+		rs = *redeemScript
+	}
+	for i, txIn := range tx.TxIn {
+		if err := w.sign(tx, i, prevScripts[txIn.PreviousOutPoint], key, rs); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Broadcast(tx); err != nil {
+		return nil, err
+	}
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+func (w *MockWallet) CreateMultisigSignature(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, key *hd.ExtendedKey, redeemScript []byte, feePerByte uint64) ([]spvwallet.Signature, error) {
+	var sigs []spvwallet.Signature
+	tx, err := spvwallet.BuildMultisigTx(ins, outs, feePerByte)
+	if err != nil {
+		return sigs, err
+	}
+	signingKey, err := key.ECPrivKey()
+	if err != nil {
+		return sigs, err
+	}
+	for i := range tx.TxIn {
+		sig, err := txscript.RawTxInSignature(tx, i, redeemScript, txscript.SigHashAll, signingKey)
+		if err != nil {
+			continue
+		}
+		sigs = append(sigs, spvwallet.Signature{InputIndex: uint32(i), Signature: sig})
+	}
+	return sigs, nil
+}
+
+func (w *MockWallet) Broadcast(tx *wire.MsgTx) error {
+	return w.chain.Broadcast(tx)
+}
+
+func (w *MockWallet) Multisign(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, sigs1 []spvwallet.Signature, sigs2 []spvwallet.Signature, redeemScript []byte, feePerByte uint64, broadcast bool) ([]byte, error) {
+	tx, err := spvwallet.BuildMultisigTx(ins, outs, feePerByte)
+	if err != nil {
+		return nil, err
+	}
+	for i, input := range tx.TxIn {
+		var sig1, sig2 []byte
+		for _, sig := range sigs1 {
+			if int(sig.InputIndex) == i {
+				sig1 = sig.Signature
+			}
+		}
+		for _, sig := range sigs2 {
+			if int(sig.InputIndex) == i {
+				sig2 = sig.Signature
+			}
+		}
+		builder := txscript.NewScriptBuilder()
+		builder.AddOp(txscript.OP_0)
+		builder.AddData(sig1)
+		builder.AddData(sig2)
+		builder.AddData(redeemScript)
+		scriptSig, err := builder.Script()
+		if err != nil {
+			return nil, err
+		}
+		input.SignatureScript = scriptSig
+	}
+	if broadcast {
+		if err := w.Broadcast(tx); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if err := tx.BtcEncode(&buf, wire.ProtocolVersion); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *MockWallet) GenerateMultisigScript(keys []hd.ExtendedKey, threshold int) (addr btc.Address, redeemScript []byte, err error) {
+	var addrPubKeys []*btc.AddressPubKey
+	for _, key := range keys {
+		ecKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		k, err := btc.NewAddressPubKey(ecKey.SerializeCompressed(), w.params)
+		if err != nil {
+			return nil, nil, err
+		}
+		addrPubKeys = append(addrPubKeys, k)
+	}
+	redeemScript, err = txscript.MultiSigScript(addrPubKeys, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err = btc.NewAddressScriptHash(redeemScript, w.params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, redeemScript, nil
+}
+
+func (w *MockWallet) AddWatchedScript(script []byte) error {
+	w.Lock()
+	defer w.Unlock()
+	w.watched[string(script)] = true
+	return nil
+}
+
+func (w *MockWallet) AddTransactionListener(callback func(spvwallet.TransactionCallback)) {
+	w.Lock()
+	defer w.Unlock()
+	w.listeners = append(w.listeners, callback)
+}
+
+// There is nothing to download so this only replays the chain from the given height
+func (w *MockWallet) ReSyncBlockchain(fromHeight int32) {
+	w.chain.Lock()
+	var txs []*mockChainTx
+	for _, txid := range w.chain.order {
+		t := w.chain.txs[txid]
+		if t.height == 0 || t.height >= fromHeight {
+			txs = append(txs, t)
+		}
+	}
+	w.chain.Unlock()
+	for _, t := range txs {
+		w.ingest(t.tx, t.height)
+	}
+}
+
+func (w *MockWallet) GetConfirmations(txid chainhash.Hash) (confirms, atHeight uint32, err error) {
+	txn, err := w.GetTransaction(txid)
+	if err != nil {
+		return 0, 0, err
+	}
+	if txn.Height <= 0 {
+		return 0, 0, nil
+	}
+	return w.ChainTip() - uint32(txn.Height) + 1, uint32(txn.Height), nil
+}
+
+func (w *MockWallet) Close() {}
+
+/* Sign an input spending one of our coins or, with a key and redeem script, a coin held by a
+   multisig address. */
+func (w *MockWallet) sign(tx *wire.MsgTx, i int, prevScript []byte, key *hd.ExtendedKey, redeemScript []byte) error {
+	if key == nil {
+		w.Lock()
+		key = w.keys[string(prevScript)]
+		w.Unlock()
+		if key == nil {
+			return errors.New("No key for input")
+		}
+	}
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return err
+	}
+	getKey := txscript.KeyClosure(func(addr btc.Address) (*btcec.PrivateKey, bool, error) {
+		return privKey, true, nil
+	})
+	getScript := txscript.ScriptClosure(func(addr btc.Address) ([]byte, error) {
+		return redeemScript, nil
+	})
+	script, err := txscript.SignTxOutput(w.params, tx, i, prevScript, txscript.SigHashAll, getKey, getScript, tx.TxIn[i].SignatureScript)
+	if err != nil {
+		return errors.New("Failed to sign transaction")
+	}
+	tx.TxIn[i].SignatureScript = script
+	return nil
+}
+
+/* Record the coins the transaction sends to our keys or watched scripts and those it spends, then
+   pass it to the listeners. Like the SPV wallet it is seen once in the mempool and again when it
+   is mined. */
+func (w *MockWallet) ingest(tx *wire.MsgTx, height int32) {
+	txid := tx.TxHash()
+	w.Lock()
+	if txn, ok := w.txns[txid]; ok && (txn.Height > 0 || height == 0) {
+		w.Unlock()
+		return
+	}
+	cb := spvwallet.TransactionCallback{Txid: txid.CloneBytes(), Height: height, Timestamp: time.Now()}
+	var value int64
+	hits, watchOnly := false, false
+	for i, txOut := range tx.TxOut {
+		cb.Outputs = append(cb.Outputs, spvwallet.TransactionOutput{ScriptPubKey: txOut.PkScript, Value: txOut.Value, Index: uint32(i)})
+		op := *wire.NewOutPoint(&txid, uint32(i))
+		_, mine := w.keys[string(txOut.PkScript)]
+		watched := w.watched[string(txOut.PkScript)]
+		if !mine && !watched {
+			continue
+		}
+		if mine {
+			hits = true
+			value += txOut.Value
+		} else {
+			watchOnly = true
+		}
+		if !w.spent[op] {
+			w.utxos[op] = spvwallet.Utxo{Op: op, AtHeight: height, Value: txOut.Value, ScriptPubkey: txOut.PkScript, WatchOnly: !mine}
+		}
+	}
+	for _, txIn := range tx.TxIn {
+		u, ok := w.utxos[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+		delete(w.utxos, txIn.PreviousOutPoint)
+		w.spent[txIn.PreviousOutPoint] = true
+		if u.WatchOnly {
+			watchOnly = true
+		} else {
+			hits = true
+			value -= u.Value
+		}
+		cb.Inputs = append(cb.Inputs, spvwallet.TransactionInput{
+			OutpointHash:       u.Op.Hash.CloneBytes(),
+			OutpointIndex:      u.Op.Index,
+			LinkedScriptPubKey: u.ScriptPubkey,
+			Value:              u.Value,
+		})
+	}
+	txn, seen := w.txns[txid]
+	if !hits && !watchOnly && !seen {
+		w.Unlock()
+		return
+	}
+	if seen {
+		txn.Height = height
+		cb.Value = txn.Value
+		cb.WatchOnly = txn.WatchOnly
+	} else {
+		var buf bytes.Buffer
+		tx.BtcEncode(&buf, wire.ProtocolVersion)
+		txn = &spvwallet.Txn{
+			Txid:      txid.String(),
+			Value:     value,
+			Height:    height,
+			Timestamp: cb.Timestamp,
+			WatchOnly: !hits,
+			Bytes:     buf.Bytes(),
+		}
+		w.txns[txid] = txn
+		cb.Value = value
+		cb.WatchOnly = !hits
+	}
+	listeners := make([]func(spvwallet.TransactionCallback), len(w.listeners))
+	copy(listeners, w.listeners)
+	w.Unlock()
+	for _, l := range listeners {
+		l(cb)
+	}
+}